@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import "context"
+
+// ZenTaoAPI is what tools and resources need from a ZenTao client.
+// *ZenTaoClient implements it; tests substitute in-memory fakes.
+type ZenTaoAPI interface {
+	Get(path string) ([]byte, error)
+	Post(path string, body interface{}) ([]byte, error)
+	Put(path string, body interface{}) ([]byte, error)
+	Delete(path string) ([]byte, error)
+
+	GetCtx(ctx context.Context, path string) ([]byte, error)
+	PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error)
+	PutCtx(ctx context.Context, path string, body interface{}) ([]byte, error)
+	DeleteCtx(ctx context.Context, path string) ([]byte, error)
+
+	// GetJSON and PostJSON unwrap ZenTao's response envelope (see envelope.go)
+	GetJSON(ctx context.Context, path string, out interface{}) error
+	PostJSON(ctx context.Context, path string, body interface{}, out interface{}) error
+
+	GetAuthMethod() AuthMethod
+	IsAuthenticated() bool
+	SetAppCredentials(code, key string)
+	GetSessionIDCtx(ctx context.Context) error
+	LoginCtx(ctx context.Context, account, password string) error
+	LoginSessionCtx(ctx context.Context, account, password string) error
+	LoginTokenCtx(ctx context.Context, account, password string) error
+}
+
+var _ ZenTaoAPI = (*ZenTaoClient)(nil)
@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// ErrUnavailable is the kind of *APIError returned while the circuit breaker is open
+var ErrUnavailable = errors.New("unavailable")
+
+// BreakerState is the state of the connectivity circuit breaker
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerConfig controls when the breaker trips. A zero FailureThreshold disables it.
+type BreakerConfig struct {
+	FailureThreshold int           // consecutive transport/5xx failures before opening
+	Cooldown         time.Duration // time spent open before a probe is let through
+	Clock            Clock         // nil means the wall clock
+}
+
+// DefaultBreakerConfig returns the breaker settings used when nothing is configured
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// BreakerStatus is a snapshot of the breaker for health reporting
+type BreakerStatus struct {
+	Enabled             bool       `json:"enabled"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	FailureThreshold    int        `json:"failure_threshold"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryInSeconds      float64    `json:"retry_in_seconds,omitempty"`
+	TimesOpened         int        `json:"times_opened"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	config   BreakerConfig
+	clock    Clock
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+	opened   int
+	lastErr  string
+}
+
+func newCircuitBreaker(config BreakerConfig) *circuitBreaker {
+	clock := config.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &circuitBreaker{config: config, clock: clock}
+}
+
+// allow returns an error if the request must fail fast. In half-open state a
+// single probe request is let through; everyone else keeps failing fast.
+func (b *circuitBreaker) allow(method, path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return nil
+	case BreakerOpen:
+		remaining := b.config.Cooldown - b.clock.Now().Sub(b.openedAt)
+		if remaining > 0 {
+			return b.unavailableError(method, path, fmt.Sprintf("circuit breaker open after %d consecutive failures, next probe in %s", b.failures, remaining.Round(time.Second)))
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		logger.Info("client", "Circuit breaker half-open, probing ZenTao", map[string]interface{}{
+			"method": method,
+			"path":   path,
+		})
+		return nil
+	default: // half-open
+		if b.probing {
+			return b.unavailableError(method, path, "circuit breaker half-open, waiting for probe result")
+		}
+		b.probing = true
+		return nil
+	}
+}
+
+func (b *circuitBreaker) unavailableError(method, path, message string) error {
+	if b.lastErr != "" {
+		message += "; last error: " + b.lastErr
+	}
+	return &APIError{
+		Kind:     ErrUnavailable,
+		Method:   method,
+		Endpoint: path,
+		Message:  message,
+	}
+}
+
+// record feeds the outcome of an upstream call into the breaker. Only
+// connectivity problems count as failures: a 404 still proves ZenTao is up.
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ctx.Err() != nil {
+		// The caller gave up; that says nothing about ZenTao's health
+		b.probing = false
+		return
+	}
+
+	failed := false
+	if err != nil {
+		var transportErr *TransportError
+		failed = errors.As(err, &transportErr) || errors.Is(err, ErrServerError)
+	}
+
+	wasProbe := b.probing
+	b.probing = false
+
+	if !failed {
+		if b.state != BreakerClosed {
+			logger.Info("client", "Circuit breaker closed, ZenTao reachable again", map[string]interface{}{
+				"previous_state": b.state.String(),
+				"down_for_ms":    b.clock.Now().Sub(b.openedAt).Milliseconds(),
+			})
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+		b.lastErr = ""
+		return
+	}
+
+	b.failures++
+	b.lastErr = err.Error()
+
+	if b.state == BreakerHalfOpen && wasProbe {
+		b.trip("probe failed")
+		return
+	}
+	if b.state == BreakerClosed && b.failures >= b.config.FailureThreshold {
+		b.trip("failure threshold reached")
+	}
+}
+
+func (b *circuitBreaker) trip(reason string) {
+	b.state = BreakerOpen
+	b.openedAt = b.clock.Now()
+	b.opened++
+
+	logger.Warn("client", "Circuit breaker opened, failing fast", map[string]interface{}{
+		"reason":               reason,
+		"consecutive_failures": b.failures,
+		"cooldown_seconds":     b.config.Cooldown.Seconds(),
+		"last_error":           b.lastErr,
+	})
+}
+
+func (b *circuitBreaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		Enabled:             true,
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+		FailureThreshold:    b.config.FailureThreshold,
+		TimesOpened:         b.opened,
+		LastError:           b.lastErr,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+		if remaining := b.config.Cooldown - b.clock.Now().Sub(b.openedAt); remaining > 0 {
+			status.RetryInSeconds = remaining.Seconds()
+		}
+	}
+	return status
+}
+
+// SetBreakerConfig installs a fresh circuit breaker; a zero threshold disables it
+func (c *ZenTaoClient) SetBreakerConfig(config BreakerConfig) {
+	if config.FailureThreshold <= 0 {
+		c.breaker.Store(nil)
+		logger.Info("client", "Circuit breaker disabled", nil)
+		return
+	}
+
+	c.breaker.Store(newCircuitBreaker(config))
+	logger.Info("client", "Circuit breaker configured", map[string]interface{}{
+		"failure_threshold": config.FailureThreshold,
+		"cooldown_seconds":  config.Cooldown.Seconds(),
+	})
+}
+
+// BreakerStatus reports the current circuit breaker state
+func (c *ZenTaoClient) BreakerStatus() BreakerStatus {
+	breaker := c.breaker.Load()
+	if breaker == nil {
+		return BreakerStatus{State: BreakerClosed.String()}
+	}
+	return breaker.status()
+}
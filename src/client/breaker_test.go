@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newBreakerTestClient(url string, clock Clock) *ZenTaoClient {
+	client := NewZenTaoClient(url + "/index.php")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0, Clock: clock})
+	client.SetBreakerConfig(BreakerConfig{FailureThreshold: 3, Cooldown: 30 * time.Second, Clock: clock})
+	return client
+}
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	server, hits := flakyServer(100, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := newBreakerTestClient(server.URL, clock)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get("/products"); !errors.Is(err, ErrServerError) {
+			t.Fatalf("Request %d: expected server error, got %v", i, err)
+		}
+	}
+	if state := client.BreakerStatus().State; state != "open" {
+		t.Fatalf("Expected breaker to be open, got %s", state)
+	}
+
+	_, err := client.Get("/products")
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable while open, got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 3 {
+		t.Errorf("Expected open breaker to skip the server, got %d requests", n)
+	}
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	server, hits := flakyServer(3, http.StatusBadGateway, "")
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := newBreakerTestClient(server.URL, clock)
+
+	for i := 0; i < 3; i++ {
+		client.Get("/products")
+	}
+
+	clock.Advance(10 * time.Second)
+	if _, err := client.Get("/products"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable before cooldown, got %v", err)
+	}
+
+	clock.Advance(25 * time.Second)
+	if _, err := client.Get("/products"); err != nil {
+		t.Fatalf("Expected successful probe after cooldown, got %v", err)
+	}
+	status := client.BreakerStatus()
+	if status.State != "closed" || status.ConsecutiveFailures != 0 || status.TimesOpened != 1 {
+		t.Errorf("Unexpected status after successful probe: %+v", status)
+	}
+	if n := atomic.LoadInt32(hits); n != 4 {
+		t.Errorf("Expected 4 requests, got %d", n)
+	}
+}
+
+func TestBreakerFailedProbeReopens(t *testing.T) {
+	clock := newFakeClock()
+	breaker := newCircuitBreaker(BreakerConfig{FailureThreshold: 2, Cooldown: time.Minute, Clock: clock})
+	failure := &TransportError{Method: "GET", Endpoint: "/products", Err: errors.New("connection refused")}
+
+	breaker.record(context.Background(), failure)
+	breaker.record(context.Background(), failure)
+	if breaker.state != BreakerOpen {
+		t.Fatalf("Expected open, got %s", breaker.state)
+	}
+
+	clock.Advance(time.Minute)
+	if err := breaker.allow("GET", "/products"); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	if err := breaker.allow("GET", "/products"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected concurrent request to fail fast during probe, got %v", err)
+	}
+
+	breaker.record(context.Background(), failure)
+	if breaker.state != BreakerOpen || breaker.opened != 2 {
+		t.Errorf("Expected failed probe to reopen, state %s opened %d", breaker.state, breaker.opened)
+	}
+}
+
+func TestBreakerCountsUnreachableTokenEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	clock := newFakeClock()
+	client := NewZenTaoClientWithToken(server.URL+"/index.php", "admin", "secret")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0, Clock: clock})
+	client.SetBreakerConfig(BreakerConfig{FailureThreshold: 3, Cooldown: 30 * time.Second, Clock: clock})
+
+	for i := 0; i < 3; i++ {
+		var transportErr *TransportError
+		if _, err := client.Get("/products"); !errors.As(err, &transportErr) {
+			t.Fatalf("Request %d: expected a transport error, got %v", i, err)
+		}
+	}
+	if state := client.BreakerStatus().State; state != "open" {
+		t.Errorf("Expected failed token fetches to open the breaker, got %s", state)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := newBreakerTestClient(server.URL, clock)
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Get("/products/999"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected not found, got %v", err)
+		}
+	}
+	if status := client.BreakerStatus(); status.State != "closed" || status.ConsecutiveFailures != 0 {
+		t.Errorf("Expected 4xx to leave the breaker closed, got %+v", status)
+	}
+}
+
+func TestBreakerIgnoresCancelledProbe(t *testing.T) {
+	clock := newFakeClock()
+	breaker := newCircuitBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: time.Second, Clock: clock})
+	breaker.record(context.Background(), &TransportError{Err: errors.New("timeout")})
+
+	clock.Advance(time.Second)
+	if err := breaker.allow("GET", "/products"); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	breaker.record(ctx, ctx.Err())
+
+	if err := breaker.allow("GET", "/products"); err != nil {
+		t.Errorf("Expected a new probe after the cancelled one, got %v", err)
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	client := &ZenTaoClient{}
+	client.SetBreakerConfig(BreakerConfig{})
+	if status := client.BreakerStatus(); status.Enabled || status.State != "closed" {
+		t.Errorf("Expected disabled breaker, got %+v", status)
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// CacheConfig controls the read-through cache for GET requests. A zero TTL
+// disables the cache.
+type CacheConfig struct {
+	TTL        time.Duration            // lifetime of cached reads for modules without an override
+	ModuleTTLs map[string]time.Duration // per ZenTao module, e.g. "user"; 0 never caches the module
+	MaxEntries int                      // oldest entries are evicted beyond this, 0 means 1000
+	Clock      Clock                    // nil means the wall clock
+}
+
+// DefaultModuleTTLs keeps slow-changing data (users, departments, module
+// trees) much longer than work items that change during a conversation
+func DefaultModuleTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"user":     10 * time.Minute,
+		"dept":     10 * time.Minute,
+		"company":  10 * time.Minute,
+		"group":    10 * time.Minute,
+		"tree":     10 * time.Minute,
+		"product":  2 * time.Minute,
+		"program":  2 * time.Minute,
+		"project":  2 * time.Minute,
+		"story":    30 * time.Second,
+		"bug":      15 * time.Second,
+		"task":     15 * time.Second,
+		"testtask": 15 * time.Second,
+	}
+}
+
+// DefaultCacheConfig returns the cache settings used when nothing is
+// configured: module TTLs are preset but the cache stays off until TTL is set
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		ModuleTTLs: DefaultModuleTTLs(),
+		MaxEntries: 1000,
+	}
+}
+
+// setModuleTTL parses a duration such as "10m" for module
+func (c *CacheConfig) setModuleTTL(module, ttl string) error {
+	if module == "" {
+		return fmt.Errorf("missing module name")
+	}
+	duration, err := time.ParseDuration(ttl)
+	if err != nil {
+		return err
+	}
+	if c.ModuleTTLs == nil {
+		c.ModuleTTLs = make(map[string]time.Duration)
+	}
+	c.ModuleTTLs[module] = duration
+	return nil
+}
+
+// ttlFor returns how long reads of module are kept
+func (c CacheConfig) ttlFor(module string) time.Duration {
+	if ttl, ok := c.ModuleTTLs[module]; ok {
+		return ttl
+	}
+	return c.TTL
+}
+
+// CacheStats is a snapshot of the cache for zentao_cache_stats
+type CacheStats struct {
+	Enabled       bool           `json:"enabled"`
+	Entries       int            `json:"entries"`
+	Hits          int64          `json:"hits"`
+	Misses        int64          `json:"misses"`
+	Invalidations int64          `json:"invalidations"`
+	Evictions     int64          `json:"evictions"`
+	Modules       map[string]int `json:"modules,omitempty"` // live entries per module
+	DefaultTTL    string         `json:"default_ttl,omitempty"`
+}
+
+// cacheableFunctions are the read-only ZenTao functions. Many legacy actions
+// (delete, close, start, unlink...) are plain GETs, so anything else bypasses
+// the cache and invalidates its module instead.
+var cacheableFunctions = []string{"browse", "view", "all", "index", "get", "ajaxget", "report"}
+
+// cacheRequest identifies a request independent of auth parameters and
+// parameter order
+type cacheRequest struct {
+	module   string
+	function string
+	key      string
+}
+
+// cacheTicket remembers the invalidation state seen by a cache miss
+type cacheTicket struct {
+	epoch      uint64
+	generation uint64
+}
+
+type cacheEntry struct {
+	module  string
+	body    []byte
+	expires time.Time
+}
+
+type responseCache struct {
+	mu          sync.Mutex
+	config      CacheConfig
+	clock       Clock
+	entries     map[string]cacheEntry
+	epoch       uint64            // bumped when everything is cleared
+	generations map[string]uint64 // bumped on invalidation so in-flight reads are not stored
+
+	hits, misses, invalidations, evictions int64
+}
+
+func newResponseCache(config CacheConfig) *responseCache {
+	clock := config.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = 1000
+	}
+	return &responseCache{
+		config:      config,
+		clock:       clock,
+		entries:     make(map[string]cacheEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+// lookup returns a live entry, or on a miss the ticket to pass to store
+func (rc *responseCache) lookup(req cacheRequest) ([]byte, cacheTicket, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if entry, ok := rc.entries[req.key]; ok {
+		if rc.clock.Now().Before(entry.expires) {
+			rc.hits++
+			return bytes.Clone(entry.body), cacheTicket{}, true
+		}
+		delete(rc.entries, req.key)
+	}
+	rc.misses++
+	return nil, cacheTicket{epoch: rc.epoch, generation: rc.generations[req.module]}, false
+}
+
+// store keeps body unless the module was invalidated since lookup
+func (rc *responseCache) store(req cacheRequest, ticket cacheTicket, body []byte) {
+	ttl := rc.config.ttlFor(req.module)
+	if ttl <= 0 {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.epoch != ticket.epoch || rc.generations[req.module] != ticket.generation {
+		return
+	}
+	now := rc.clock.Now()
+	if _, exists := rc.entries[req.key]; !exists && len(rc.entries) >= rc.config.MaxEntries {
+		rc.evict(now)
+	}
+	rc.entries[req.key] = cacheEntry{module: req.module, body: bytes.Clone(body), expires: now.Add(ttl)}
+}
+
+// evict drops expired entries, or the one closest to expiry if none are
+func (rc *responseCache) evict(now time.Time) {
+	oldestKey := ""
+	var oldest time.Time
+	removed := 0
+	for key, entry := range rc.entries {
+		if !now.Before(entry.expires) {
+			delete(rc.entries, key)
+			removed++
+			continue
+		}
+		if oldestKey == "" || entry.expires.Before(oldest) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if removed == 0 && oldestKey != "" {
+		delete(rc.entries, oldestKey)
+		removed = 1
+	}
+	rc.evictions += int64(removed)
+}
+
+// invalidate drops every entry of module, or all entries when module is empty
+func (rc *responseCache) invalidate(module string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	removed := 0
+	for key, entry := range rc.entries {
+		if module == "" || entry.module == module {
+			delete(rc.entries, key)
+			removed++
+		}
+	}
+	if module == "" {
+		rc.epoch++
+	} else {
+		rc.generations[module]++
+	}
+	rc.invalidations++
+	return removed
+}
+
+func (rc *responseCache) stats() CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := rc.clock.Now()
+	modules := make(map[string]int)
+	entries := 0
+	for _, entry := range rc.entries {
+		if now.Before(entry.expires) {
+			modules[entry.module]++
+			entries++
+		}
+	}
+	return CacheStats{
+		Enabled:       true,
+		Entries:       entries,
+		Hits:          rc.hits,
+		Misses:        rc.misses,
+		Invalidations: rc.invalidations,
+		Evictions:     rc.evictions,
+		Modules:       modules,
+		DefaultTTL:    rc.config.TTL.String(),
+	}
+}
+
+// cacheRequestFor normalizes method and path the way buildURL resolves them,
+// dropping auth parameters so the key survives token and session changes
+func (c *ZenTaoClient) cacheRequestFor(method, path string) cacheRequest {
+	resolved, params := c.convertRESTPath(method, path)
+	values := url.Values{}
+	base, rawQuery, _ := strings.Cut(resolved, "?")
+	if query, err := url.ParseQuery(rawQuery); err == nil {
+		for key := range query {
+			values.Set(key, query.Get(key))
+		}
+	}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	for _, key := range []string{"token", "time", "code", "zentaosid", "sid"} {
+		values.Del(key)
+	}
+
+	req := cacheRequest{module: values.Get("m"), function: values.Get("f")}
+	if req.module == "" {
+		// REST-only paths such as /executions/5/stories
+		req.module = strings.SplitN(strings.Trim(base, "/"), "/", 2)[0]
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var key strings.Builder
+	key.WriteString(method + " " + base + "?")
+	for i, name := range keys {
+		if i > 0 {
+			key.WriteByte('&')
+		}
+		key.WriteString(url.QueryEscape(name) + "=" + url.QueryEscape(values.Get(name)))
+	}
+	req.key = key.String()
+	return req
+}
+
+// cacheable reports whether a GET only reads data
+func (req cacheRequest) cacheable() bool {
+	if req.module == "api" {
+		return false
+	}
+	function := strings.ToLower(req.function)
+	if function == "" {
+		return true
+	}
+	for _, prefix := range cacheableFunctions {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// doRequestCached serves reads from the cache and invalidates the module of
+// every successful write
+func (c *ZenTaoClient) doRequestCached(ctx context.Context, cache *responseCache, method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+	req := c.cacheRequestFor(method, path)
+
+	if method != http.MethodGet || !req.cacheable() {
+		resp, err := c.doRequestWithRetry(ctx, method, path, body, headers, 2)
+		if err == nil {
+			removed := cache.invalidate(req.module)
+			logger.Debug("client", "Invalidated cached reads after write", map[string]interface{}{
+				"method":  method,
+				"module":  req.module,
+				"removed": removed,
+			})
+		}
+		return resp, err
+	}
+
+	resp, ticket, ok := cache.lookup(req)
+	if ok {
+		logger.Debug("client", "Serving GET from cache", map[string]interface{}{
+			"path":   path,
+			"module": req.module,
+		})
+		return resp, nil
+	}
+
+	resp, err := c.doRequestShared(ctx, req, method, path, body, headers)
+	if err == nil {
+		cache.store(req, ticket, resp)
+	}
+	return resp, err
+}
+
+// SetCacheConfig installs a fresh response cache; a zero TTL disables it
+func (c *ZenTaoClient) SetCacheConfig(config CacheConfig) {
+	if config.TTL <= 0 {
+		c.cache.Store(nil)
+		return
+	}
+
+	c.cache.Store(newResponseCache(config))
+	logger.Info("client", "Response cache configured", map[string]interface{}{
+		"default_ttl": config.TTL.String(),
+		"module_ttls": len(config.ModuleTTLs),
+		"max_entries": config.MaxEntries,
+	})
+}
+
+// CacheStats reports hit rates and live entries of the response cache
+func (c *ZenTaoClient) CacheStats() CacheStats {
+	cache := c.cache.Load()
+	if cache == nil {
+		return CacheStats{}
+	}
+	return cache.stats()
+}
+
+// ClearCache drops cached reads of module, or everything when module is
+// empty, and returns the number of entries removed
+func (c *ZenTaoClient) ClearCache(module string) (int, error) {
+	cache := c.cache.Load()
+	if cache == nil {
+		return 0, fmt.Errorf("response cache is disabled")
+	}
+	removed := cache.invalidate(module)
+	logger.Info("client", "Cleared response cache", map[string]interface{}{
+		"module":  module,
+		"removed": removed,
+	})
+	return removed, nil
+}
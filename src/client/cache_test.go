@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+// newCachedFakeClient returns a client with the response cache enabled against ztfake
+func newCachedFakeClient(t *testing.T, config CacheConfig) (*ztfake.Server, *ZenTaoClient) {
+	t.Helper()
+
+	fake := ztfake.New(ztfake.Options{})
+	t.Cleanup(fake.Close)
+
+	opts := fake.Options()
+	client := NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, opts.AppKey)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	client.SetCacheConfig(config)
+	return fake, client
+}
+
+func countCalls(fake *ztfake.Server, module, function string) int {
+	n := 0
+	for _, req := range fake.Requests() {
+		if req.Module == module && req.Function == function {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCacheKeyIgnoresAuthAndOrder(t *testing.T) {
+	client := NewZenTaoClient("http://zentao.local/index.php")
+
+	a := client.cacheRequestFor("GET", "/index.php?m=bug&f=browse&t=json&productID=1&pageID=2&token=abc&time=1")
+	b := client.cacheRequestFor("GET", "/index.php?m=bug&f=browse&pageID=2&productID=1&t=json&token=def&time=2")
+	if a.key != b.key {
+		t.Errorf("Expected equal keys, got %q and %q", a.key, b.key)
+	}
+	if a.module != "bug" || !a.cacheable() {
+		t.Errorf("Expected a cacheable bug read, got %+v", a)
+	}
+
+	if c := client.cacheRequestFor("GET", "/products/1/bugs?pageID=3"); c.key == a.key || c.module != "bug" {
+		t.Errorf("Expected a different bug key, got %+v", c)
+	}
+	if req := client.cacheRequestFor("GET", "/index.php?m=bug&f=delete&bugID=1&confirm=yes"); req.cacheable() {
+		t.Error("Expected a GET delete not to be cacheable")
+	}
+	if req := client.cacheRequestFor("GET", "/index.php?m=api&f=getSessionID"); req.cacheable() {
+		t.Error("Expected session calls not to be cacheable")
+	}
+}
+
+func TestCacheServesReadsAndInvalidatesOnWrite(t *testing.T) {
+	fake, client := newCachedFakeClient(t, CacheConfig{TTL: time.Minute})
+	fake.Seed("product", map[string]interface{}{"name": "Widget"})
+	fake.Seed("bug", map[string]interface{}{"title": "Crash", "product": 1})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get("/products"); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if _, err := client.Get("/products/1/bugs"); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+	}
+	if n := countCalls(fake, "product", "all") + countCalls(fake, "product", "browse"); n != 1 {
+		t.Errorf("Expected one upstream product list, got %d", n)
+	}
+
+	// A product write drops product reads but keeps bug reads
+	if _, err := client.Post("/products", map[string]interface{}{"name": "Gadget", "code": "GDG"}); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	fake.ResetRequests()
+	resp, err := client.Get("/products")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(fake.Requests()) != 1 {
+		t.Errorf("Expected product list to be refetched after the write, got %d requests", len(fake.Requests()))
+	}
+	if !strings.Contains(string(resp), "Gadget") {
+		t.Errorf("Expected fresh product list, got %s", resp)
+	}
+	if _, err := client.Get("/products/1/bugs"); err != nil || len(fake.Requests()) != 1 {
+		t.Errorf("Expected bug list still cached, got %d requests (%v)", len(fake.Requests()), err)
+	}
+
+	stats := client.CacheStats()
+	if !stats.Enabled || stats.Hits != 5 || stats.Misses != 3 || stats.Invalidations != 1 || stats.Modules["bug"] != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestCacheModuleTTLs(t *testing.T) {
+	clock := newFakeClock()
+	fake, client := newCachedFakeClient(t, CacheConfig{
+		TTL:        time.Minute,
+		ModuleTTLs: map[string]time.Duration{"bug": 5 * time.Second, "story": 0},
+		Clock:      clock,
+	})
+	fake.Seed("bug", map[string]interface{}{"title": "Crash", "product": 1})
+	fake.Seed("story", map[string]interface{}{"title": "Checkout", "product": 1})
+	fake.Seed("product", map[string]interface{}{"name": "Widget"})
+
+	read := func() {
+		for _, path := range []string{"/bug/1", "/story/1", "/product/1"} {
+			if _, err := client.Get(path); err != nil {
+				t.Fatalf("Get %s failed: %v", path, err)
+			}
+		}
+	}
+
+	read()
+	clock.Advance(10 * time.Second)
+	fake.ResetRequests()
+	read()
+
+	if countCalls(fake, "bug", "view") != 1 {
+		t.Error("Expected the bug to expire after its 5s TTL")
+	}
+	if countCalls(fake, "story", "view") != 1 {
+		t.Error("Expected stories never to be cached")
+	}
+	if countCalls(fake, "product", "view") != 0 {
+		t.Error("Expected the product to be served from cache under the default TTL")
+	}
+}
+
+func TestCacheMutatingGetInvalidates(t *testing.T) {
+	fake, client := newCachedFakeClient(t, CacheConfig{TTL: time.Minute})
+	fake.Seed("bug", map[string]interface{}{"title": "Crash", "product": 1})
+
+	client.Get("/bug/1")
+	if _, err := client.Get("/index.php?m=bug&f=delete&bugID=1&confirm=yes"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := client.Get("/index.php?m=bug&f=delete&bugID=1&confirm=yes"); err == nil {
+		t.Error("Expected the repeated delete to reach ZenTao and fail")
+	}
+	if _, err := client.Get("/bug/1"); err == nil {
+		t.Error("Expected the deleted bug not to be served from cache")
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	_, client := newCachedFakeClient(t, CacheConfig{TTL: time.Minute})
+	client.Get("/products")
+	client.Get("/products/1/bugs")
+
+	if removed, err := client.ClearCache("bug"); err != nil || removed != 1 {
+		t.Errorf("Expected one bug entry removed, got %d (%v)", removed, err)
+	}
+	if removed, _ := client.ClearCache(""); removed != 1 {
+		t.Errorf("Expected the product entry removed, got %d", removed)
+	}
+	if stats := client.CacheStats(); stats.Entries != 0 {
+		t.Errorf("Expected an empty cache, got %+v", stats)
+	}
+
+	disabled := NewZenTaoClient("http://zentao.local/index.php")
+	if _, err := disabled.ClearCache(""); err == nil {
+		t.Error("Expected an error when the cache is disabled")
+	}
+	if disabled.CacheStats().Enabled {
+		t.Error("Expected the cache to be disabled by default")
+	}
+}
+
+func TestCacheMaxEntries(t *testing.T) {
+	fake, client := newCachedFakeClient(t, CacheConfig{TTL: time.Minute, MaxEntries: 2})
+	for i := 1; i <= 3; i++ {
+		fake.Seed("bug", map[string]interface{}{"title": fmt.Sprintf("Bug %d", i)})
+		client.Get(fmt.Sprintf("/bug/%d", i))
+	}
+	if stats := client.CacheStats(); stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("Expected 2 entries after one eviction, got %+v", stats)
+	}
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	fake, client := newCachedFakeClient(t, CacheConfig{TTL: time.Minute})
+	fake.Seed("product", map[string]interface{}{"name": "Widget"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			switch i % 4 {
+			case 0:
+				client.Post("/products", map[string]interface{}{"name": fmt.Sprintf("P%d", i), "code": fmt.Sprintf("P%d", i)})
+			case 1:
+				client.ClearCache("")
+				client.CacheStats()
+			default:
+				if _, err := client.Get("/products"); err != nil {
+					t.Errorf("Get failed: %v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Whatever raced, the next read after the last write is fresh
+	fake.ResetRequests()
+	resp, err := client.Get("/products")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	for i := 0; i < 20; i += 4 {
+		if !strings.Contains(string(resp), fmt.Sprintf(`P%d`, i)) {
+			t.Errorf("Expected product P%d in %s", i, resp)
+		}
+	}
+}
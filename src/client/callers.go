@@ -0,0 +1,379 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// CallerConfig controls per-caller ZenTao credentials on the HTTP transport.
+// When enabled, every MCP session acts as the caller's own ZenTao account
+// instead of the account configured for the server.
+type CallerConfig struct {
+	Bearer      bool          // map "Authorization: Bearer" tokens to accounts from TokensFile
+	Forward     bool          // accept the caller's own ZenTao account and password
+	TokensFile  string        // JSON object of bearer token -> {"account", "password"}, mode 0600
+	IdleTimeout time.Duration // per-session clients unused this long are dropped
+}
+
+// DefaultCallerConfig returns the settings used when nothing is configured:
+// every caller shares the server's credentials
+func DefaultCallerConfig() CallerConfig {
+	return CallerConfig{IdleTimeout: 30 * time.Minute}
+}
+
+// Enabled reports whether callers must bring their own identity
+func (c CallerConfig) Enabled() bool {
+	return c.Bearer || c.Forward
+}
+
+// setAuth parses a comma-separated list of caller auth methods: bearer, forward or none
+func (c *CallerConfig) setAuth(value string) error {
+	c.Bearer, c.Forward = false, false
+	for _, method := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(method)) {
+		case "bearer":
+			c.Bearer = true
+		case "forward":
+			c.Forward = true
+		case "none", "":
+		default:
+			return fmt.Errorf("unknown caller auth method %q (use bearer, forward or none)", method)
+		}
+	}
+	return nil
+}
+
+// Caller is the ZenTao account an HTTP client acts as
+type Caller struct {
+	Account  string `json:"account"`
+	Password string `json:"password"`
+	Source   string `json:"-"` // "bearer" or "forward"
+}
+
+// fingerprint identifies the credentials without keeping them as a map key
+func (c Caller) fingerprint() string {
+	sum := sha256.Sum256([]byte(c.Account + "\x00" + c.Password))
+	return hex.EncodeToString(sum[:8])
+}
+
+// CallerTokens maps bearer tokens to ZenTao accounts
+type CallerTokens map[string]Caller
+
+// LoadCallerTokens reads a tokens file such as
+//
+//	{"3f9c...": {"account": "alice", "password": "..."}}
+//
+// The file holds passwords, so it is refused when group or others can read it.
+func LoadCallerTokens(path string) (CallerTokens, error) {
+	if path == "" {
+		return nil, fmt.Errorf("bearer caller auth needs a tokens file (ZENTAO_CALLER_TOKENS_FILE)")
+	}
+	if err := checkSecretPermissions(path); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read caller tokens file %s: %w", path, err)
+	}
+
+	var tokens CallerTokens
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid caller tokens file %s: %w", path, err)
+	}
+	for token, caller := range tokens {
+		if token == "" || caller.Account == "" || caller.Password == "" {
+			return nil, fmt.Errorf("caller tokens file %s: every entry needs a token, account and password", path)
+		}
+	}
+
+	logger.Info("client", "Loaded caller tokens", map[string]interface{}{
+		"path":   path,
+		"tokens": len(tokens),
+	})
+	return tokens, nil
+}
+
+// Lookup returns the account mapped to token. Every entry is compared in
+// constant time so response timing does not reveal partial matches.
+func (t CallerTokens) Lookup(token string) (Caller, bool) {
+	var found Caller
+	ok := false
+	for candidate, caller := range t {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			found, ok = caller, true
+		}
+	}
+	found.Source = "bearer"
+	return found, ok
+}
+
+type callerContextKey struct{}
+
+type callerBinding struct {
+	session string
+	caller  Caller
+}
+
+// WithCaller records that requests in ctx come from caller within the MCP
+// session sessionID. Pool.ClientFor then hands out that session's own client.
+func WithCaller(ctx context.Context, sessionID string, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, callerBinding{session: sessionID, caller: caller})
+}
+
+// CallerFromContext returns the caller bound by WithCaller
+func CallerFromContext(ctx context.Context) (sessionID string, caller Caller, ok bool) {
+	binding, ok := ctx.Value(callerContextKey{}).(callerBinding)
+	return binding.session, binding.caller, ok
+}
+
+// callerClientKey separates clients by session, instance and credentials, so a
+// session can never reuse a client that logged in as somebody else
+type callerClientKey struct {
+	session     string
+	instance    string
+	fingerprint string
+}
+
+type callerClient struct {
+	client   *ZenTaoClient
+	account  string
+	lastUsed time.Time
+}
+
+// callerClients caches one client per MCP session and instance. Each has its
+// own token, session and response caches but draws on the rate limits and
+// circuit breaker of the instance client; idle ones are evicted.
+type callerClients struct {
+	mu      sync.Mutex
+	clients map[callerClientKey]*callerClient
+	idle    time.Duration
+	now     func() time.Time
+	stop    chan struct{}
+}
+
+// EnableCallers turns on per-caller clients for contexts carrying WithCaller.
+// A background sweep evicts clients idle for longer than cfg.IdleTimeout.
+func (p *Pool) EnableCallers(cfg CallerConfig) {
+	idle := cfg.IdleTimeout
+	if idle <= 0 {
+		idle = DefaultCallerConfig().IdleTimeout
+	}
+	callers := &callerClients{
+		clients: make(map[callerClientKey]*callerClient),
+		idle:    idle,
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+
+	p.mu.Lock()
+	previous := p.callers
+	p.callers = callers
+	p.mu.Unlock()
+	if previous != nil {
+		previous.close()
+	}
+
+	go callers.sweepLoop()
+
+	logger.Info("client", "Per-caller ZenTao credentials enabled", map[string]interface{}{
+		"bearer":       cfg.Bearer,
+		"forward":      cfg.Forward,
+		"idle_timeout": idle.String(),
+	})
+}
+
+// ClientFor returns the client for instance name (empty for the default). When
+// ctx carries a caller, the client belongs to that caller's MCP session.
+func (p *Pool) ClientFor(ctx context.Context, name string) (*ZenTaoClient, error) {
+	shared, err := p.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return shared, nil
+	}
+
+	p.mu.RLock()
+	callers := p.callers
+	p.mu.RUnlock()
+	if callers == nil {
+		return nil, fmt.Errorf("per-caller credentials are not enabled")
+	}
+
+	if name == "" {
+		name = p.defaultName
+	}
+	profile, _ := p.Profile(name)
+	return callers.get(sessionID, profile, caller, p.opts, shared)
+}
+
+// FailedClient returns a client whose every request fails with err. It is
+// bound in place of a caller's client that could not be built, so the caller
+// never falls back to the shared account.
+func FailedClient(err error) *ZenTaoClient {
+	return &ZenTaoClient{
+		Client: newDefaultHTTPClient(),
+		failed: fmt.Errorf("no ZenTao client for this caller: %w", err),
+	}
+}
+
+// EvictSession drops the clients of an MCP session that has ended
+func (p *Pool) EvictSession(sessionID string) {
+	p.mu.RLock()
+	callers := p.callers
+	p.mu.RUnlock()
+	if callers == nil {
+		return
+	}
+	callers.evict(func(key callerClientKey, _ *callerClient) bool {
+		return key.session == sessionID
+	}, "session ended")
+}
+
+// CallerSessions returns the number of per-caller clients currently cached
+func (p *Pool) CallerSessions() int {
+	p.mu.RLock()
+	callers := p.callers
+	p.mu.RUnlock()
+	if callers == nil {
+		return 0
+	}
+	callers.mu.Lock()
+	defer callers.mu.Unlock()
+	return len(callers.clients)
+}
+
+// Close stops the idle sweep and drops every per-caller client
+func (p *Pool) Close() {
+	p.mu.Lock()
+	callers := p.callers
+	p.callers = nil
+	p.mu.Unlock()
+	if callers != nil {
+		callers.close()
+	}
+}
+
+// callerProfile derives the profile a caller's client is built from. App auth
+// has no user identity in ZenTao, so those instances log the caller in with a
+// session instead. Persisted sessions are never shared with callers.
+func callerProfile(profile InstanceProfile, caller Caller) InstanceProfile {
+	profile.Account = caller.Account
+	profile.Password = caller.Password
+	profile.AppCode, profile.AppKey = "", ""
+	profile.AppKeyFile, profile.PasswordFile = "", ""
+	profile.SessionFile = ""
+	if profile.AuthMethod == "app" || profile.AuthMethod == "" {
+		profile.AuthMethod = "session"
+	}
+	return profile
+}
+
+func (cc *callerClients) get(sessionID string, profile InstanceProfile, caller Caller, opts ClientOptions, shared *ZenTaoClient) (*ZenTaoClient, error) {
+	key := callerClientKey{session: sessionID, instance: profile.Name, fingerprint: caller.fingerprint()}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if entry, ok := cc.clients[key]; ok {
+		entry.lastUsed = cc.now()
+		return entry.client, nil
+	}
+
+	c, err := NewClientFromProfile(callerProfile(profile, caller), opts)
+	if err != nil {
+		return nil, fmt.Errorf("instance %q: %w", profile.Name, err)
+	}
+	c.shareLimits(shared)
+	cc.clients[key] = &callerClient{client: c, account: caller.Account, lastUsed: cc.now()}
+
+	logger.Info("client", "Created per-caller client", map[string]interface{}{
+		"instance": profile.Name,
+		"account":  caller.Account,
+		"source":   caller.Source,
+		"session":  sessionID,
+	})
+	return c, nil
+}
+
+// shareLimits makes c draw on the rate limits and circuit breaker of instance,
+// so all callers together stay within the instance's budget and an outage
+// opens the breaker once for everybody
+func (c *ZenTaoClient) shareLimits(instance *ZenTaoClient) {
+	c.limiters.Store(instance.limiters.Load())
+	c.breaker.Store(instance.breaker.Load())
+}
+
+// evict removes the clients matching drop and releases their connections
+func (cc *callerClients) evict(drop func(callerClientKey, *callerClient) bool, reason string) {
+	cc.mu.Lock()
+	var dropped []*callerClient
+	for key, entry := range cc.clients {
+		if drop(key, entry) {
+			dropped = append(dropped, entry)
+			delete(cc.clients, key)
+		}
+	}
+	cc.mu.Unlock()
+
+	for _, entry := range dropped {
+		entry.client.Client.CloseIdleConnections()
+		logger.Debug("client", "Evicted per-caller client", map[string]interface{}{
+			"account": entry.account,
+			"reason":  reason,
+		})
+	}
+}
+
+func (cc *callerClients) sweepIdle() {
+	cutoff := cc.now().Add(-cc.idle)
+	cc.evict(func(_ callerClientKey, entry *callerClient) bool {
+		return entry.lastUsed.Before(cutoff)
+	}, "idle")
+}
+
+func (cc *callerClients) sweepLoop() {
+	interval := cc.idle / 2
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cc.sweepIdle()
+		case <-cc.stop:
+			return
+		}
+	}
+}
+
+func (cc *callerClients) close() {
+	close(cc.stop)
+	cc.evict(func(callerClientKey, *callerClient) bool { return true }, "closed")
+}
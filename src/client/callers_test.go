@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestLoadCallerTokens(t *testing.T) {
+	path := writeSecret(t, "tokens.json", `{"alice-token": {"account": "alice", "password": "pw1"}}`, 0o600)
+	tokens, err := LoadCallerTokens(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	caller, ok := tokens.Lookup("alice-token")
+	if !ok || caller.Account != "alice" || caller.Password != "pw1" || caller.Source != "bearer" {
+		t.Errorf("Unexpected caller: %+v (%v)", caller, ok)
+	}
+	if _, ok := tokens.Lookup("alice-toke"); ok {
+		t.Error("Expected a prefix of a token not to match")
+	}
+
+	if runtime.GOOS != "windows" {
+		if _, err := LoadCallerTokens(writeSecret(t, "tokens.json", `{}`, 0o644)); err == nil {
+			t.Error("Expected a permissions error for a readable tokens file")
+		}
+	}
+	if _, err := LoadCallerTokens(writeSecret(t, "tokens.json", `{"t": {"account": "bob"}}`, 0o600)); err == nil {
+		t.Error("Expected an error for an entry without password")
+	}
+}
+
+func TestCallerConfigFromEnv(t *testing.T) {
+	t.Setenv("ZENTAO_CALLER_AUTH", "bearer, forward")
+	t.Setenv("ZENTAO_CALLER_IDLE_TIMEOUT", "5m")
+
+	opts, err := LoadClientOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !opts.Callers.Bearer || !opts.Callers.Forward || opts.Callers.IdleTimeout != 5*time.Minute {
+		t.Errorf("Unexpected caller config: %+v", opts.Callers)
+	}
+
+	t.Setenv("ZENTAO_CALLER_AUTH", "kerberos")
+	if _, err := LoadClientOptions(); err == nil {
+		t.Error("Expected an error for an unknown caller auth method")
+	}
+}
+
+func newCallerTestPool(t *testing.T) *Pool {
+	t.Helper()
+	profiles := []InstanceProfile{
+		{Name: "default", BaseURL: "http://zentao.example.com/index.php", AuthMethod: "app", AppCode: "mcp", AppKey: "server-key"},
+		{Name: "partner", BaseURL: "http://partner.example.com/index.php", AuthMethod: "token", Account: "bot", Password: "bot-pw"},
+	}
+	pool, err := NewPool(profiles, "default", DefaultClientOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.EnableCallers(CallerConfig{Bearer: true, IdleTimeout: time.Hour})
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestClientForIsolatesCallers(t *testing.T) {
+	pool := newCallerTestPool(t)
+	alice := Caller{Account: "alice", Password: "pw1"}
+	bob := Caller{Account: "bob", Password: "pw2"}
+
+	shared, err := pool.ClientFor(context.Background(), "")
+	if err != nil || shared != pool.Default() {
+		t.Fatalf("Expected the shared client without a caller, got %v", err)
+	}
+
+	aliceClient, _ := pool.ClientFor(WithCaller(context.Background(), "s1", alice), "")
+	again, _ := pool.ClientFor(WithCaller(context.Background(), "s1", alice), "")
+	bobClient, _ := pool.ClientFor(WithCaller(context.Background(), "s1", bob), "")
+	otherSession, _ := pool.ClientFor(WithCaller(context.Background(), "s2", alice), "")
+
+	if aliceClient != again {
+		t.Error("Expected the same client for repeated calls of one session")
+	}
+	if aliceClient == shared || aliceClient == bobClient || aliceClient == otherSession {
+		t.Error("Expected a separate client per caller and session")
+	}
+
+	// App auth carries no user identity, so the caller logs in with a session
+	if aliceClient.authMethod != AuthSession {
+		t.Errorf("Expected session auth for the caller, got %v", aliceClient.authMethod)
+	}
+	if account, password := aliceClient.sessionCredentials(); account != "alice" || password != "pw1" {
+		t.Errorf("Expected alice's credentials, got %s/%s", account, password)
+	}
+	if aliceClient.Key != "" {
+		t.Error("Expected the server's app key not to reach the caller's client")
+	}
+
+	partner, err := pool.ClientFor(WithCaller(context.Background(), "s1", alice), "partner")
+	if err != nil || partner.authMethod != AuthToken {
+		t.Fatalf("Expected a token client for the partner instance, got %v", err)
+	}
+	if pool.CallerSessions() != 4 {
+		t.Errorf("Expected 4 per-caller clients, got %d", pool.CallerSessions())
+	}
+}
+
+func TestCallerClientsShareInstanceLimits(t *testing.T) {
+	opts := DefaultClientOptions()
+	opts.RateLimits = RateLimits{Read: RateLimit{RequestsPerSecond: 0.01, Burst: 1}}
+	pool, err := NewPool([]InstanceProfile{
+		{Name: "default", BaseURL: "http://zentao.example.com/index.php", AuthMethod: "app", AppCode: "mcp", AppKey: "server-key"},
+	}, "default", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.EnableCallers(CallerConfig{Bearer: true, IdleTimeout: time.Hour})
+	t.Cleanup(pool.Close)
+
+	alice, _ := pool.ClientFor(WithCaller(context.Background(), "s1", Caller{Account: "alice", Password: "pw1"}), "")
+	bob, _ := pool.ClientFor(WithCaller(context.Background(), "s2", Caller{Account: "bob", Password: "pw2"}), "")
+
+	release, err := alice.waitForSlot(context.Background(), http.MethodGet, "/products")
+	if err != nil {
+		t.Fatalf("Expected the first request to pass, got %v", err)
+	}
+	release()
+
+	// The single token of the shared bucket is spent, so bob has to wait
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := bob.waitForSlot(ctx, http.MethodGet, "/products"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the second session to wait for the shared budget, got %v", err)
+	}
+
+	if alice.breaker.Load() == nil || alice.breaker.Load() != pool.Default().breaker.Load() || bob.breaker.Load() != alice.breaker.Load() {
+		t.Error("Expected the callers to share the instance's circuit breaker")
+	}
+}
+
+func TestCallerClientsEviction(t *testing.T) {
+	pool := newCallerTestPool(t)
+	now := time.Now()
+	pool.callers.now = func() time.Time { return now }
+
+	pool.ClientFor(WithCaller(context.Background(), "s1", Caller{Account: "alice", Password: "pw1"}), "")
+	pool.ClientFor(WithCaller(context.Background(), "s2", Caller{Account: "bob", Password: "pw2"}), "")
+
+	pool.EvictSession("s1")
+	if pool.CallerSessions() != 1 {
+		t.Fatalf("Expected s1 to be evicted, %d clients left", pool.CallerSessions())
+	}
+
+	now = now.Add(59 * time.Minute)
+	pool.callers.sweepIdle()
+	if pool.CallerSessions() != 1 {
+		t.Fatal("Expected the client to survive before the idle timeout")
+	}
+	now = now.Add(2 * time.Minute)
+	pool.callers.sweepIdle()
+	if pool.CallerSessions() != 0 {
+		t.Error("Expected the idle client to be evicted")
+	}
+}
@@ -14,9 +14,11 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/zentao/mcp-server/logger"
@@ -41,6 +44,7 @@ const (
 	AuthNone AuthMethod = iota
 	AuthApp
 	AuthSession
+	AuthToken // account/password exchanged for a Token header via POST /tokens
 )
 
 type ZenTaoClient struct {
@@ -52,6 +56,28 @@ type ZenTaoClient struct {
 	lastTime      int64
 	timeMutex     sync.Mutex
 
+	// Retry policy for transient failures, nil means DefaultRetryPolicy
+	retry atomic.Pointer[RetryPolicy]
+
+	// Client-side rate limits, nil means unlimited
+	limiters atomic.Pointer[clientLimiters]
+
+	// Connectivity circuit breaker, nil means disabled
+	breaker atomic.Pointer[circuitBreaker]
+
+	// Read-through cache for GET requests, nil means disabled
+	cache atomic.Pointer[responseCache]
+
+	// Identical concurrent GETs share one upstream request (see coalesce.go)
+	inflight    requestGroup
+	coalesceOff atomic.Bool
+
+	// Reject mutating requests (see readonly.go)
+	readOnly atomic.Bool
+
+	// Server clock offset learned from Date headers, applied to app token timestamps
+	skew clockSkew
+
 	// Token caching for app-based auth
 	cachedToken     string
 	cachedTimestamp int64
@@ -62,6 +88,25 @@ type ZenTaoClient struct {
 	sessionName   string
 	sessionID     string
 	sessionMutex  sync.Mutex
+
+	// Automatic session login (see session.go), guarded by sessionMutex
+	sessionAccount  string
+	sessionPassword string
+	sessionFile     string
+	loginMutex      sync.Mutex // serializes logins so only one renews an expired session
+
+	// RESTful API v1 backend (api.php/v1 with Token header)
+	apiMode      APIMode
+	restBaseURL  string
+	restAccount  string
+	restPassword string
+	restToken    string
+	restMutex    sync.Mutex // guards the fields above, never held across a request
+	tokenFetch   sync.Mutex // serializes POST /tokens so only one request fetches a token
+
+	// Set on clients that stand in for a per-caller client that could not be
+	// built; every request fails with it (see callers.go)
+	failed error
 }
 
 func NewZenTaoClient(baseURL string) *ZenTaoClient {
@@ -72,7 +117,7 @@ func NewZenTaoClient(baseURL string) *ZenTaoClient {
 
 	return &ZenTaoClient{
 		BaseURL: baseURL,
-		Client:  &http.Client{},
+		Client:  newDefaultHTTPClient(),
 	}
 }
 
@@ -93,7 +138,7 @@ func NewZenTaoClientWithApp(baseURL, code, key string) *ZenTaoClient {
 		BaseURL:    baseURL,
 		Code:       code,
 		Key:        key,
-		Client:     &http.Client{},
+		Client:     newDefaultHTTPClient(),
 		authMethod: AuthApp,
 	}
 }
@@ -106,7 +151,7 @@ func NewZenTaoClientWithSession(baseURL string) *ZenTaoClient {
 
 	return &ZenTaoClient{
 		BaseURL:    baseURL,
-		Client:     &http.Client{},
+		Client:     newDefaultHTTPClient(),
 		authMethod: AuthSession,
 	}
 }
@@ -139,7 +184,7 @@ func (c *ZenTaoClient) getTimestamp() int64 {
 	c.timeMutex.Lock()
 	defer c.timeMutex.Unlock()
 
-	now := time.Now().Unix()
+	now := c.serverNow().Unix()
 	originalNow := now
 
 	if now <= c.lastTime {
@@ -188,6 +233,21 @@ func (c *ZenTaoClient) convertRESTPath(method, path string) (string, map[string]
 		return path, params
 	}
 
+	// Already in query form, e.g. the session login calls
+	if strings.HasPrefix(path, "?") {
+		return path, params
+	}
+
+	// Filters and paging given as a query string, e.g. /products?status=closed
+	if base, rawQuery, found := strings.Cut(path, "?"); found {
+		path = base
+		if query, err := url.ParseQuery(rawQuery); err == nil {
+			for key := range query {
+				params[key] = query.Get(key)
+			}
+		}
+	}
+
 	// Parse path components
 	var module, function, id, subResource, originalResource string
 	var parts []string
@@ -500,7 +560,7 @@ func (c *ZenTaoClient) getCachedToken() (string, int64) {
 	c.tokenMutex.Lock()
 	defer c.tokenMutex.Unlock()
 
-	currentTime := time.Now().Unix()
+	currentTime := c.serverNow().Unix()
 
 	logger.Debug("client", "Checking token cache", map[string]interface{}{
 		"current_time": currentTime,
@@ -555,7 +615,7 @@ func (c *ZenTaoClient) forceTokenRefresh() {
 	c.tokenMutex.Lock()
 	defer c.tokenMutex.Unlock()
 
-	tokenAge := time.Now().Unix() - c.cachedTimestamp
+	tokenAge := c.serverNow().Unix() - c.cachedTimestamp
 	logger.Info("client", "Forcing token refresh due to expiration", map[string]interface{}{
 		"token_age_seconds": tokenAge,
 		"cache_duration_seconds": tokenCacheDuration,
@@ -577,7 +637,7 @@ func (c *ZenTaoClient) isTokenCloseToExpiry() bool {
 		return true
 	}
 
-	tokenAge := time.Now().Unix() - c.cachedTimestamp
+	tokenAge := c.serverNow().Unix() - c.cachedTimestamp
 	// Consider token close to expiry if it's older than 80% of cache duration
 	isCloseToExpiry := tokenAge > int64(float64(tokenCacheDuration)*0.8)
 
@@ -596,15 +656,22 @@ func (c *ZenTaoClient) isTokenCloseToExpiry() bool {
 
 // GetSessionID retrieves a session from ZenTao
 func (c *ZenTaoClient) GetSessionID() error {
-	c.sessionMutex.Lock()
-	defer c.sessionMutex.Unlock()
+	return c.GetSessionIDCtx(context.Background())
+}
 
+// GetSessionIDCtx retrieves a session from ZenTao, honouring ctx cancellation
+func (c *ZenTaoClient) GetSessionIDCtx(ctx context.Context) error {
+	if target := c.ForContext(ctx); target != c {
+		return target.GetSessionIDCtx(ctx)
+	}
+
+	// sessionMutex must not be held across the request: buildURL takes it too
 	logger.Debug("client", "Getting session ID", map[string]interface{}{
 		"base_url": c.BaseURL,
 	})
 
 	// Make request to get session ID
-	resp, err := c.doRequestSingle("GET", "?m=api&f=getSessionID&t=json", nil, nil)
+	resp, err := c.doRequestSingle(ctx, "GET", "?m=api&f=getSessionID&t=json", nil, nil)
 	if err != nil {
 		logger.Error("client", "Failed to get session ID", err, nil)
 		return fmt.Errorf("failed to get session ID: %w", err)
@@ -636,8 +703,15 @@ func (c *ZenTaoClient) GetSessionID() error {
 		return fmt.Errorf("sessionID not found in response")
 	}
 
+	c.sessionMutex.Lock()
 	c.sessionName = sessionName
 	c.sessionID = sessionID
+	c.sessionMutex.Unlock()
+
+	// The session travels as ?<sessionName>=<id>, so mask that parameter in
+	// logs and cassettes
+	logger.AddRedactedKey(sessionName)
+	addVCRSessionParam(sessionName)
 
 	logger.Info("client", "Session obtained successfully", map[string]interface{}{
 		"session_name": sessionName,
@@ -649,16 +723,26 @@ func (c *ZenTaoClient) GetSessionID() error {
 
 // Login performs user authentication using session
 func (c *ZenTaoClient) Login(account, password string) error {
+	return c.LoginCtx(context.Background(), account, password)
+}
+
+// LoginCtx performs user authentication using session, honouring ctx cancellation
+func (c *ZenTaoClient) LoginCtx(ctx context.Context, account, password string) error {
+	if target := c.ForContext(ctx); target != c {
+		return target.LoginCtx(ctx, account, password)
+	}
+
 	c.sessionMutex.Lock()
-	defer c.sessionMutex.Unlock()
+	hasSession := c.sessionName != "" && c.sessionID != ""
+	c.sessionMutex.Unlock()
 
-	if c.sessionName == "" || c.sessionID == "" {
+	if !hasSession {
 		return fmt.Errorf("session not initialized, call GetSessionID first")
 	}
 
 	logger.Info("client", "Performing session login", map[string]interface{}{
 		"account": account,
-		"has_session": c.sessionName != "",
+		"has_session": hasSession,
 	})
 
 	// Prepare login data
@@ -668,7 +752,7 @@ func (c *ZenTaoClient) Login(account, password string) error {
 	}
 
 	// Make login request with session
-	resp, err := c.doRequestSingle("POST", "?m=user&f=login", loginData, nil)
+	resp, err := c.doRequestSingle(ctx, "POST", "?m=user&f=login", loginData, nil)
 	if err != nil {
 		logger.Error("client", "Login request failed", err, map[string]interface{}{
 			"account": account,
@@ -728,6 +812,8 @@ func (c *ZenTaoClient) IsAuthenticated() bool {
 		hasSession := c.sessionName != "" && c.sessionID != ""
 		c.sessionMutex.Unlock()
 		return hasSession
+	case AuthToken:
+		return c.hasToken()
 	default:
 		return false
 	}
@@ -823,13 +909,34 @@ func (c *ZenTaoClient) buildURL(path string, params map[string]string) string {
 }
 
 func (c *ZenTaoClient) DoRequest(method, path string, body interface{}, headers map[string]string) ([]byte, error) {
-	return c.doRequestWithRetry(method, path, body, headers, 2)
+	return c.DoRequestContext(context.Background(), method, path, body, headers)
 }
 
-func (c *ZenTaoClient) doRequestWithRetry(method, path string, body interface{}, headers map[string]string, maxRetries int) ([]byte, error) {
+// DoRequestContext performs a request bound to ctx. Cancelling ctx aborts the
+// in-flight HTTP call and stops any pending retries.
+func (c *ZenTaoClient) DoRequestContext(ctx context.Context, method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+	if target := c.ForContext(ctx); target != c {
+		return target.DoRequestContext(ctx, method, path, body, headers)
+	}
+	if c.failed != nil {
+		return nil, c.failed
+	}
+	if err := c.checkReadOnly(method, path); err != nil {
+		return nil, err
+	}
+	if cache := c.cache.Load(); cache != nil {
+		return c.doRequestCached(ctx, cache, method, path, body, headers)
+	}
+	if method != http.MethodGet || c.coalesceOff.Load() {
+		return c.doRequestWithRetry(ctx, method, path, body, headers, 2)
+	}
+	return c.doRequestShared(ctx, c.cacheRequestFor(method, path), method, path, body, headers)
+}
+
+func (c *ZenTaoClient) doRequestWithRetry(ctx context.Context, method, path string, body interface{}, headers map[string]string, maxRetries int) ([]byte, error) {
 	// Log token cache state at start of request
 	c.tokenMutex.Lock()
-	currentTime := time.Now().Unix()
+	currentTime := c.serverNow().Unix()
 	tokenAge := currentTime - c.cachedTimestamp
 	hasToken := c.cachedToken != ""
 	cachedTimestamp := c.cachedTimestamp
@@ -858,22 +965,27 @@ func (c *ZenTaoClient) doRequestWithRetry(method, path string, body interface{},
 		"raw_age_calculation": fmt.Sprintf("%d - %d = %d", currentTime, cachedTimestamp, tokenAge),
 	})
 
-	var lastErr error
-	var responseBody []byte
+	policy := c.retryPolicy()
+	clock := policy.clock()
+	startTime := clock.Now()
+	replayable := isReplayable(ctx, method)
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 {
-			logger.Info("client", "Retrying request after token refresh", map[string]interface{}{
-				"attempt": attempt,
-				"max_retries": maxRetries,
+	authRetries := 0
+	transientRetries := 0
+	sessionRenewed := false
+
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			logger.Warn("client", "Request cancelled before attempt", map[string]interface{}{
+				"attempt": attempt + 1,
 				"method": method,
 				"path": path,
+				"reason": err.Error(),
 			})
-			// Force token refresh on retry
-			c.forceTokenRefresh()
-			// Small delay before retry
-			time.Sleep(100 * time.Millisecond)
-		} else if shouldUseFreshToken {
+			return nil, err
+		}
+
+		if attempt == 0 && shouldUseFreshToken {
 			// Proactively refresh token for write operations or if close to expiry
 			logger.Info("client", "Proactively refreshing token before request", map[string]interface{}{
 				"method": method,
@@ -890,60 +1002,139 @@ func (c *ZenTaoClient) doRequestWithRetry(method, path string, body interface{},
 			c.forceTokenRefresh()
 		}
 
-		resp, err := c.doRequestSingle(method, path, body, headers)
-		if err != nil {
-			lastErr = err
-			continue
+		if attempt == 0 {
+			if err := c.ensureSession(ctx); err != nil {
+				logger.Error("client", "Automatic session login failed", err, map[string]interface{}{
+					"method": method,
+					"path": path,
+				})
+				return nil, fmt.Errorf("automatic session login failed: %w", err)
+			}
 		}
 
-		responseBody = resp
-
-		// Check if response indicates token expiration
-		if c.isTokenExpired(responseBody) {
-			if attempt < maxRetries {
-				logger.Warn("client", "Token expired, will retry with fresh token", map[string]interface{}{
+		sessionUsed := c.currentSessionID()
+		responseBody, err := c.doRequestSingle(ctx, method, path, body, headers)
+		if err == nil {
+			// Check if response indicates token expiration
+			if !c.isTokenExpired(responseBody) {
+				logger.Debug("client", "Request completed successfully", map[string]interface{}{
 					"attempt": attempt + 1,
-					"max_retries": maxRetries,
 					"method": method,
 					"path": path,
+					"response_length": len(responseBody),
 				})
-				continue
-			} else {
-				logger.Error("client", "Token expired after maximum retries", nil, map[string]interface{}{
-					"attempts": maxRetries + 1,
+				return responseBody, nil
+			}
+			err = &APIError{
+				Kind:     ErrAuthExpired,
+				Method:   method,
+				Endpoint: path,
+				Message:  "token expired",
+			}
+			if c.usesTokenHeader(method, path) {
+				c.invalidateRESTToken()
+			}
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		var delay time.Duration
+		switch {
+		case errors.Is(err, ErrAuthExpired) && c.canRenewSession() && !c.useREST(method, path):
+			// The PHP session expired; log in again once and replay transparently
+			if sessionRenewed {
+				logger.Error("client", "Session still rejected after re-login", err, map[string]interface{}{
 					"method": method,
 					"path": path,
 				})
-				return nil, fmt.Errorf("token expired after %d attempts", maxRetries+1)
+				return nil, err
 			}
-		} else {
-			// Response received but no token expiration detected
-			logger.Debug("client", "Response received, token still valid", map[string]interface{}{
+			sessionRenewed = true
+			logger.Warn("client", "Session expired, logging in again", map[string]interface{}{
+				"method": method,
+				"path": path,
+			})
+			if loginErr := c.renewSession(ctx, sessionUsed); loginErr != nil {
+				logger.Error("client", "Session re-login failed", loginErr, nil)
+				return nil, fmt.Errorf("session expired and re-login failed: %w", loginErr)
+			}
+			continue
+
+		case errors.Is(err, ErrAuthExpired):
+			// The server rejected the credentials, so replaying is safe for any method
+			if authRetries >= maxRetries {
+				logger.Error("client", "Token expired after maximum retries", err, map[string]interface{}{
+					"attempts": attempt + 1,
+					"method": method,
+					"path": path,
+				})
+				if c.authMethod == AuthApp {
+					// A drifting clock makes every app token look expired
+					return nil, c.skew.explain(err)
+				}
+				return nil, err
+			}
+			authRetries++
+			logger.Warn("client", "Token expired, will retry with fresh token", map[string]interface{}{
 				"attempt": attempt + 1,
+				"max_retries": maxRetries,
 				"method": method,
 				"path": path,
-				"response_length": len(responseBody),
-				"response_preview": func() string {
-					previewLen := 100
-					if len(responseBody) < previewLen {
-						previewLen = len(responseBody)
-					}
-					return string(responseBody)[:previewLen]
-				}(),
 			})
+			c.forceTokenRefresh()
+			delay = 100 * time.Millisecond
+
+		case isTransient(err):
+			if !replayable {
+				logger.Warn("client", "Transient failure on non-idempotent request, not retrying", map[string]interface{}{
+					"method": method,
+					"path": path,
+					"error": err.Error(),
+				})
+				return nil, err
+			}
+			if transientRetries >= policy.MaxRetries {
+				logger.Error("client", "Transient failure after maximum retries", err, map[string]interface{}{
+					"attempts": attempt + 1,
+					"method": method,
+					"path": path,
+				})
+				return nil, err
+			}
+			delay = policy.backoff(transientRetries, retryAfterOf(err))
+			elapsed := clock.Now().Sub(startTime)
+			if policy.MaxElapsed > 0 && elapsed+delay > policy.MaxElapsed {
+				logger.Error("client", "Retry budget exhausted", err, map[string]interface{}{
+					"elapsed_ms": elapsed.Milliseconds(),
+					"next_delay_ms": delay.Milliseconds(),
+					"max_elapsed_ms": policy.MaxElapsed.Milliseconds(),
+					"method": method,
+					"path": path,
+				})
+				return nil, err
+			}
+			transientRetries++
+			logger.Warn("client", "Transient failure, retrying with backoff", map[string]interface{}{
+				"attempt": attempt + 1,
+				"retry": transientRetries,
+				"max_retries": policy.MaxRetries,
+				"delay_ms": delay.Milliseconds(),
+				"method": method,
+				"path": path,
+				"error": err.Error(),
+			})
+
+		default:
+			// The server gave a definitive answer
+			return nil, err
 		}
 
-		// Success - log the successful response and return
-		logger.Debug("client", "Request completed successfully", map[string]interface{}{
-			"attempt": attempt + 1,
-			"method": method,
-			"path": path,
-			"response_length": len(responseBody),
-		})
-		return responseBody, nil
+		if err := clock.Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
 	}
-
-	return nil, lastErr
 }
 
 func (c *ZenTaoClient) isTokenExpired(responseBody []byte) bool {
@@ -1001,6 +1192,19 @@ func (c *ZenTaoClient) isTokenExpired(responseBody []byte) bool {
 	return false
 }
 
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // Helper function to get map keys for logging
 func getMapKeys(m map[string]interface{}) []string {
 	keys := make([]string, 0, len(m))
@@ -1010,7 +1214,10 @@ func getMapKeys(m map[string]interface{}) []string {
 	return keys
 }
 
-func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+func (c *ZenTaoClient) doRequestSingle(ctx context.Context, method, path string, body interface{}, headers map[string]string) (_ []byte, reqErr error) {
+	if c.failed != nil {
+		return nil, c.failed
+	}
 	startTime := time.Now()
 
 	logger.Debug("client", "Starting HTTP request", map[string]interface{}{
@@ -1041,18 +1248,56 @@ func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, he
 		})
 	}
 
-	// Convert REST path to ZenTao query format
-	logger.Debug("client", "Converting REST path to ZenTao format", map[string]interface{}{
-		"original_path": path,
-		"method": method,
-	})
+	// Wait for rate limit budget before signing the request so tokens stay fresh
+	release, err := c.waitForSlot(ctx, method, path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
-	queryPath, pathParams := c.convertRESTPath(method, path)
-	requestURL := c.buildURL(queryPath, pathParams)
+	// Fail fast while ZenTao is known to be unreachable
+	if breaker := c.breaker.Load(); breaker != nil {
+		if err := breaker.allow(method, path); err != nil {
+			logger.Warn("client", "ZenTao unavailable, failing fast", map[string]interface{}{
+				"method": method,
+				"path": path,
+				"error": err.Error(),
+			})
+			return nil, err
+		}
+		defer func() { breaker.record(ctx, reqErr) }()
+	}
+
+	var requestURL, restToken string
+	useREST := c.useREST(method, path)
+	useToken := c.usesTokenHeader(method, path)
+	if useToken {
+		token, err := c.getRESTToken(ctx)
+		if err != nil {
+			logger.Error("client", "Failed to obtain API token", err, map[string]interface{}{
+				"method": method,
+				"path": path,
+			})
+			return nil, err
+		}
+		restToken = token
+	}
+	if useREST {
+		requestURL = c.restURL(path)
+	} else {
+		// Convert REST path to ZenTao query format
+		logger.Debug("client", "Converting REST path to ZenTao format", map[string]interface{}{
+			"original_path": path,
+			"method": method,
+		})
+
+		queryPath, pathParams := c.convertRESTPath(method, path)
+		requestURL = c.buildURL(queryPath, pathParams)
+	}
 
 	logger.LogRequest("client", method, requestURL, headers, body)
 
-	req, err := http.NewRequest(method, requestURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
 	if err != nil {
 		logger.Error("client", "Failed to create HTTP request", err, map[string]interface{}{
 			"method": method,
@@ -1062,6 +1307,9 @@ func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, he
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if restToken != "" {
+		req.Header.Set("Token", restToken)
+	}
 
 	for key, value := range headers {
 		req.Header.Set(key, value)
@@ -1073,6 +1321,7 @@ func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, he
 		"headers_set": len(headers) + 1, // +1 for Content-Type
 	})
 
+	sentAt := time.Now()
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		duration := time.Since(startTime)
@@ -1081,10 +1330,13 @@ func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, he
 			"url": requestURL,
 			"duration_ms": duration.Milliseconds(),
 		})
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, &TransportError{Method: method, Endpoint: path, Err: err}
 	}
 	defer resp.Body.Close()
 
+	// Every response tells us the server's clock, which app tokens depend on
+	c.skew.observe(resp.Header.Get("Date"), sentAt, time.Now())
+
 	responseBody, err := io.ReadAll(resp.Body)
 	duration := time.Since(startTime)
 
@@ -1095,7 +1347,7 @@ func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, he
 			"status_code": resp.StatusCode,
 			"duration_ms": duration.Milliseconds(),
 		})
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, &TransportError{Method: method, Endpoint: path, Err: fmt.Errorf("failed to read response: %w", err)}
 	}
 
 	logger.LogResponse("client", resp.StatusCode, responseBody, duration)
@@ -1109,35 +1361,72 @@ func (c *ZenTaoClient) doRequestSingle(method, path string, body interface{}, he
 		"content_type": resp.Header.Get("Content-Type"),
 	})
 
+	if apiErr := classifyResponse(method, path, resp.StatusCode, resp.Header.Get("Content-Type"), responseBody); apiErr != nil {
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if useToken && errors.Is(apiErr, ErrAuthExpired) {
+			// Next attempt logs in again via POST /tokens
+			c.invalidateRESTToken()
+		}
+		logger.Warn("client", "ZenTao API returned an error", map[string]interface{}{
+			"method": method,
+			"path": path,
+			"status_code": apiErr.StatusCode,
+			"kind": apiErr.Kind.Error(),
+			"errcode": apiErr.ErrCode,
+			"message": apiErr.Message,
+		})
+		return nil, apiErr
+	}
+
 	return responseBody, nil
 }
 
 func (c *ZenTaoClient) Get(path string) ([]byte, error) {
+	return c.GetCtx(context.Background(), path)
+}
+
+func (c *ZenTaoClient) Post(path string, body interface{}) ([]byte, error) {
+	return c.PostCtx(context.Background(), path, body)
+}
+
+func (c *ZenTaoClient) Put(path string, body interface{}) ([]byte, error) {
+	return c.PutCtx(context.Background(), path, body)
+}
+
+func (c *ZenTaoClient) Delete(path string) ([]byte, error) {
+	return c.DeleteCtx(context.Background(), path)
+}
+
+// GetCtx issues a GET request bound to ctx
+func (c *ZenTaoClient) GetCtx(ctx context.Context, path string) ([]byte, error) {
 	logger.Debug("client", "GET request", map[string]interface{}{
 		"path": path,
 	})
-	return c.DoRequest(http.MethodGet, path, nil, nil)
+	return c.DoRequestContext(ctx, http.MethodGet, path, nil, nil)
 }
 
-func (c *ZenTaoClient) Post(path string, body interface{}) ([]byte, error) {
+// PostCtx issues a POST request bound to ctx
+func (c *ZenTaoClient) PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
 	logger.Debug("client", "POST request", map[string]interface{}{
 		"path": path,
 		"has_body": body != nil,
 	})
-	return c.DoRequest(http.MethodPost, path, body, nil)
+	return c.DoRequestContext(ctx, http.MethodPost, path, body, nil)
 }
 
-func (c *ZenTaoClient) Put(path string, body interface{}) ([]byte, error) {
+// PutCtx issues a PUT request bound to ctx
+func (c *ZenTaoClient) PutCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
 	logger.Debug("client", "PUT request", map[string]interface{}{
 		"path": path,
 		"has_body": body != nil,
 	})
-	return c.DoRequest(http.MethodPut, path, body, nil)
+	return c.DoRequestContext(ctx, http.MethodPut, path, body, nil)
 }
 
-func (c *ZenTaoClient) Delete(path string) ([]byte, error) {
+// DeleteCtx issues a DELETE request bound to ctx
+func (c *ZenTaoClient) DeleteCtx(ctx context.Context, path string) ([]byte, error) {
 	logger.Debug("client", "DELETE request", map[string]interface{}{
 		"path": path,
 	})
-	return c.DoRequest(http.MethodDelete, path, nil, nil)
+	return c.DoRequestContext(ctx, http.MethodDelete, path, nil, nil)
 }
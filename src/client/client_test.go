@@ -13,7 +13,9 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -788,3 +790,55 @@ func TestTokenCacheConstants(t *testing.T) {
 		t.Errorf("Expected tokenCacheDuration to be %d, got %d", expectedDuration, tokenCacheDuration)
 	}
 }
+
+// TestDoRequestContextCancellation verifies that a cancelled context aborts a hanging request
+func TestDoRequestContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewZenTaoClient(server.URL + "/api.php")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetCtx(ctx, "/products")
+	if err == nil {
+		t.Fatal("Expected error from cancelled request")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected request to abort promptly, took %v", elapsed)
+	}
+}
+
+// TestDoRequestContextAlreadyCancelled verifies that no request is sent for a dead context
+func TestDoRequestContextAlreadyCancelled(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/api.php")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.PostCtx(ctx, "/products", map[string]interface{}{"name": "x"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if hits != 0 {
+		t.Errorf("Expected no upstream requests, got %d", hits)
+	}
+}
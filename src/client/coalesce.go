@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// CoalesceStats reports how many identical concurrent GETs were collapsed
+type CoalesceStats struct {
+	Enabled      bool  `json:"enabled"`
+	Upstream     int64 `json:"upstream"`     // shared calls actually sent to ZenTao
+	Deduplicated int64 `json:"deduplicated"` // callers served by another caller's request
+	InFlight     int   `json:"in_flight"`
+}
+
+// inflightCall is one upstream GET shared by every caller asking for the same key
+type inflightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	body    []byte
+	err     error
+	waiters int // callers still waiting; the request is cancelled when all leave
+	joined  int // callers that joined an existing request
+}
+
+// requestGroup collapses identical in-flight GETs into a single request,
+// like the token cache does for tokens. The zero value is ready to use.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+
+	upstream, deduplicated int64
+}
+
+// do runs fetch once per key at a time. The shared request is detached from
+// the first caller's cancellation so one caller giving up does not fail the
+// others; it is cancelled once every caller's ctx is done.
+func (g *requestGroup) do(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, bool, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*inflightCall)
+	}
+	call, shared := g.calls[key]
+	if shared {
+		call.waiters++
+		call.joined++
+		g.deduplicated++
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &inflightCall{done: make(chan struct{}), cancel: cancel, waiters: 1}
+		g.calls[key] = call
+		g.upstream++
+
+		go func() {
+			defer cancel()
+			body, err := fetch(callCtx)
+
+			g.mu.Lock()
+			call.body, call.err = body, err
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			joined := call.joined
+			g.mu.Unlock()
+			close(call.done)
+
+			if joined > 0 {
+				logger.Debug("client", "Shared GET response with concurrent callers", map[string]interface{}{
+					"key":    key,
+					"joined": joined,
+				})
+			}
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		// Callers may hold on to the slice; give each its own copy
+		return bytes.Clone(call.body), shared, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody wants the answer any more; let a later caller start afresh
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+func (g *requestGroup) stats() CoalesceStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return CoalesceStats{
+		Upstream:     g.upstream,
+		Deduplicated: g.deduplicated,
+		InFlight:     len(g.calls),
+	}
+}
+
+// doRequestShared sends read-only GETs through the request group so
+// identical concurrent reads hit ZenTao once; everything else goes straight
+// to doRequestWithRetry
+func (c *ZenTaoClient) doRequestShared(ctx context.Context, req cacheRequest, method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+	if c.coalesceOff.Load() || method != http.MethodGet || len(headers) > 0 || !req.cacheable() {
+		return c.doRequestWithRetry(ctx, method, path, body, headers, 2)
+	}
+
+	resp, shared, err := c.inflight.do(ctx, req.key, func(ctx context.Context) ([]byte, error) {
+		return c.doRequestWithRetry(ctx, method, path, body, headers, 2)
+	})
+	if shared {
+		logger.Debug("client", "Joined identical in-flight GET", map[string]interface{}{
+			"path": path,
+		})
+	}
+	return resp, err
+}
+
+// SetCoalescing turns collapsing of identical concurrent GETs on or off (on by default)
+func (c *ZenTaoClient) SetCoalescing(enabled bool) {
+	c.coalesceOff.Store(!enabled)
+}
+
+// CoalesceStats reports how many GETs were deduplicated
+func (c *ZenTaoClient) CoalesceStats() CoalesceStats {
+	stats := c.inflight.stats()
+	stats.Enabled = !c.coalesceOff.Load()
+	return stats
+}
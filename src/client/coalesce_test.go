@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowServer answers once release is closed and counts requests and cancellations
+type slowServer struct {
+	*httptest.Server
+	release   chan struct{}
+	calls     atomic.Int32
+	cancelled atomic.Int32
+}
+
+func newSlowServer(t *testing.T) *slowServer {
+	s := &slowServer{release: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls.Add(1)
+		select {
+		case <-s.release:
+			w.Write([]byte(`{"status":"success","data":"{\"id\":1}"}`))
+		case <-r.Context().Done():
+			s.cancelled.Add(1)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// waitFor polls cond, failing the test after a second
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCoalesceIdenticalGets(t *testing.T) {
+	server := newSlowServer(t)
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.Get("/product/1")
+			results[i], errs[i] = string(resp), err
+		}(i)
+	}
+
+	waitFor(t, "callers to join", func() bool { return client.CoalesceStats().Deduplicated == callers-1 })
+	close(server.release)
+	wg.Wait()
+
+	if n := server.calls.Load(); n != 1 {
+		t.Errorf("Expected one upstream request, got %d", n)
+	}
+	for i := range results {
+		if errs[i] != nil || results[i] != results[0] {
+			t.Errorf("Caller %d: got %q (%v)", i, results[i], errs[i])
+		}
+	}
+	stats := client.CoalesceStats()
+	if !stats.Enabled || stats.Upstream != 1 || stats.InFlight != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	// Later calls start a fresh request
+	if _, err := client.Get("/product/1"); err != nil || server.calls.Load() != 2 {
+		t.Errorf("Expected a new upstream request once the first finished, got %d (%v)", server.calls.Load(), err)
+	}
+}
+
+func TestCoalesceCancellation(t *testing.T) {
+	server := newSlowServer(t)
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	// The first caller giving up does not fail the one that joined it
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetCtx(leaderCtx, "/product/1")
+		leaderErr <- err
+	}()
+	waitFor(t, "the request to start", func() bool { return server.calls.Load() == 1 })
+
+	followerResp := make(chan error, 1)
+	go func() {
+		_, err := client.GetCtx(context.Background(), "/product/1")
+		followerResp <- err
+	}()
+	waitFor(t, "the follower to join", func() bool { return client.CoalesceStats().Deduplicated == 1 })
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the leader to see its cancellation, got %v", err)
+	}
+	close(server.release)
+	if err := <-followerResp; err != nil {
+		t.Errorf("Expected the follower to get the response, got %v", err)
+	}
+
+	// When every caller gives up the upstream request is aborted
+	other := newSlowServer(t)
+	client = NewZenTaoClient(other.URL + "/index.php")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		client.GetCtx(ctx, "/product/1")
+		close(done)
+	}()
+	waitFor(t, "the request to start", func() bool { return other.calls.Load() == 1 })
+	cancel()
+	<-done
+	waitFor(t, "the upstream request to be cancelled", func() bool { return other.cancelled.Load() == 1 })
+}
+
+func TestCoalesceOnlyReads(t *testing.T) {
+	server := newSlowServer(t)
+	close(server.release)
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Get("/index.php?m=bug&f=delete&bugID=1&confirm=yes")
+		}()
+	}
+	wg.Wait()
+	if n := server.calls.Load(); n != 4 {
+		t.Errorf("Expected every mutating GET to reach ZenTao, got %d", n)
+	}
+
+	client.SetCoalescing(false)
+	if client.CoalesceStats().Enabled {
+		t.Error("Expected coalescing to be disabled")
+	}
+}
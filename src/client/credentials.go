@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// credentialHelperTimeout bounds how long an external credential helper may run
+const credentialHelperTimeout = 10 * time.Second
+
+// CredentialSources lists where secrets are looked up when the app key or
+// password is not given directly. For each profile the first source that
+// yields a secret wins:
+//
+//  1. the value itself (ZENTAO_APP_KEY, ZENTAO_PASSWORD or the config file)
+//  2. a secret file (ZENTAO_APP_KEY_FILE, ZENTAO_PASSWORD_FILE)
+//  3. a git-credential style helper (ZENTAO_CREDENTIAL_HELPER)
+//  4. a .netrc style file keyed by host (ZENTAO_NETRC_FILE)
+type CredentialSources struct {
+	AppKeyFile   string // app key of the default instance, mode 0600 or stricter
+	PasswordFile string // password of the default instance, mode 0600 or stricter
+	Helper       string // command run as "<helper> get" with the git credential protocol
+	NetrcFile    string // machine/login/password entries keyed by ZenTao host
+}
+
+// credential is a username/secret pair found by a helper or netrc lookup
+type credential struct {
+	Username string
+	Secret   string
+	Source   string
+}
+
+// readSecretFile reads a secret from path, refusing files that other users
+// can read. Surrounding whitespace, such as a trailing newline, is dropped.
+func readSecretFile(path string) (string, error) {
+	if err := checkSecretPermissions(path); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("secret file %s is empty", path)
+	}
+	return secret, nil
+}
+
+// checkSecretPermissions rejects files that group or others can access, like ssh does for keys
+func checkSecretPermissions(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("secret file %s has permissions %04o, it must not be accessible by group or others (chmod 600)", path, info.Mode().Perm())
+	}
+	return nil
+}
+
+// runCredentialHelper asks helper for the credentials of baseURL using the
+// git credential protocol: key=value lines on stdin, terminated by a blank line
+func runCredentialHelper(helper, baseURL, username string) (credential, error) {
+	fields := strings.Fields(helper)
+	if len(fields) == 0 {
+		return credential{}, nil
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return credential{}, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+
+	var input bytes.Buffer
+	fmt.Fprintf(&input, "protocol=%s\nhost=%s\n", parsed.Scheme, parsed.Host)
+	if path := strings.TrimPrefix(parsed.Path, "/"); path != "" {
+		fmt.Fprintf(&input, "path=%s\n", path)
+	}
+	if username != "" {
+		fmt.Fprintf(&input, "username=%s\n", username)
+	}
+	input.WriteString("\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialHelperTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, fields[0], append(fields[1:], "get")...)
+	cmd.Stdin = &input
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return credential{}, fmt.Errorf("credential helper %q failed: %w", fields[0], err)
+	}
+
+	found := credential{Source: "helper"}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			found.Username = value
+		case "password":
+			found.Secret = value
+		}
+	}
+	return found, nil
+}
+
+// lookupNetrc finds the entry for baseURL's host in a .netrc style file. With
+// a username, only entries with that login (or none) match.
+func lookupNetrc(path, baseURL, username string) (credential, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return credential{}, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+
+	if err := checkSecretPermissions(path); err != nil {
+		return credential{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return credential{}, fmt.Errorf("failed to read netrc file %s: %w", path, err)
+	}
+
+	type entry struct {
+		machine, login, password string
+	}
+	var entries []entry
+	var current *entry
+	tokens := strings.Fields(string(data))
+	for i := 0; i < len(tokens); i++ {
+		next := func() string {
+			if i+1 < len(tokens) {
+				i++
+				return tokens[i]
+			}
+			return ""
+		}
+		switch tokens[i] {
+		case "machine":
+			entries = append(entries, entry{machine: next()})
+			current = &entries[len(entries)-1]
+		case "default":
+			entries = append(entries, entry{})
+			current = &entries[len(entries)-1]
+		case "login":
+			if current != nil {
+				current.login = next()
+			}
+		case "password":
+			if current != nil {
+				current.password = next()
+			}
+		case "account", "port":
+			next()
+		}
+	}
+
+	// host:port beats a bare hostname, which beats the default entry
+	var match *entry
+	for _, machine := range []string{parsed.Host, parsed.Hostname(), ""} {
+		for i := range entries {
+			e := &entries[i]
+			if e.machine != machine || (username != "" && e.login != "" && e.login != username) {
+				continue
+			}
+			match = e
+			break
+		}
+		if match != nil {
+			break
+		}
+	}
+	if match == nil {
+		return credential{}, nil
+	}
+	return credential{Username: match.login, Secret: match.password, Source: "netrc"}, nil
+}
+
+// resolveCredentials fills in the profile's app key or password from the
+// configured sources, in the order documented on CredentialSources
+func (o ClientOptions) resolveCredentials(profile *InstanceProfile) error {
+	// App auth keeps its code/key pair in the username/secret slots
+	user, secret, secretFile := &profile.Account, &profile.Password, profile.PasswordFile
+	if profile.AuthMethod == "app" {
+		user, secret, secretFile = &profile.AppCode, &profile.AppKey, profile.AppKeyFile
+	}
+
+	source := "value"
+	switch {
+	case *secret != "":
+	case secretFile != "":
+		value, err := readSecretFile(secretFile)
+		if err != nil {
+			return err
+		}
+		*secret = value
+		source = "file"
+	default:
+		lookups := []func() (credential, error){
+			func() (credential, error) {
+				if o.Credentials.Helper == "" {
+					return credential{}, nil
+				}
+				return runCredentialHelper(o.Credentials.Helper, profile.BaseURL, *user)
+			},
+			func() (credential, error) {
+				if o.Credentials.NetrcFile == "" {
+					return credential{}, nil
+				}
+				return lookupNetrc(o.Credentials.NetrcFile, profile.BaseURL, *user)
+			},
+		}
+		source = ""
+		for _, lookup := range lookups {
+			found, err := lookup()
+			if err != nil {
+				return err
+			}
+			if found.Secret == "" {
+				continue
+			}
+			if *user == "" {
+				*user = found.Username
+			}
+			*secret = found.Secret
+			source = found.Source
+			break
+		}
+	}
+
+	if source != "" {
+		logger.Debug("client", "Resolved instance credentials", map[string]interface{}{
+			"instance":    profile.Name,
+			"auth_method": profile.AuthMethod,
+			"source":      source,
+		})
+	}
+	return nil
+}
+
+// UpdateCredentials swaps in the credentials of profile, for example after
+// they were re-read on SIGHUP. Cached tokens and sessions of the old
+// credentials are dropped so the next request authenticates afresh.
+func (c *ZenTaoClient) UpdateCredentials(profile InstanceProfile) {
+	switch c.authMethod {
+	case AuthApp:
+		c.tokenMutex.Lock()
+		c.Code = profile.AppCode
+		c.Key = profile.AppKey
+		c.cachedToken = ""
+		c.cachedTimestamp = 0
+		c.tokenMutex.Unlock()
+	case AuthSession:
+		account, password := c.sessionCredentials()
+		if account == profile.Account && password == profile.Password {
+			return
+		}
+		c.sessionMutex.Lock()
+		c.sessionAccount = profile.Account
+		c.sessionPassword = profile.Password
+		c.sessionName = ""
+		c.sessionID = ""
+		c.sessionMutex.Unlock()
+	}
+	if c.authMethod == AuthToken || c.GetAPIMode() == APIModeREST {
+		c.SetRESTCredentials(profile.Account, profile.Password)
+	}
+
+	logger.Info("client", "Credentials reloaded", map[string]interface{}{
+		"instance":     profile.Name,
+		"has_app_key":  profile.AppKey != "",
+		"has_password": profile.Password != "",
+	})
+}
+
+// ReloadCredentials applies freshly resolved profiles to the existing clients.
+// Instances that were added or removed need a restart and are only logged.
+func (p *Pool) ReloadCredentials(profiles []InstanceProfile) {
+	for _, profile := range profiles {
+		c, ok := p.clients[profile.Name]
+		if !ok {
+			logger.Warn("client", "New instance ignored until restart", map[string]interface{}{
+				"instance": profile.Name,
+			})
+			continue
+		}
+		c.UpdateCredentials(profile)
+
+		p.mu.Lock()
+		p.profiles[profile.Name] = profile
+		p.mu.Unlock()
+	}
+}
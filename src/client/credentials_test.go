@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeSecret(t *testing.T, name, content string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadSecretFile(t *testing.T) {
+	secret, err := readSecretFile(writeSecret(t, "key", "s3cret\n", 0o600))
+	if err != nil || secret != "s3cret" {
+		t.Errorf("Expected s3cret, got %q (%v)", secret, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		_, err := readSecretFile(writeSecret(t, "key", "s3cret", 0o644))
+		if err == nil || !strings.Contains(err.Error(), "chmod 600") {
+			t.Errorf("Expected a permissions error, got %v", err)
+		}
+	}
+
+	if _, err := readSecretFile(writeSecret(t, "key", "\n", 0o600)); err == nil {
+		t.Error("Expected an error for an empty secret file")
+	}
+}
+
+func TestLookupNetrc(t *testing.T) {
+	path := writeSecret(t, "netrc", `
+machine other.example.com login bob password other
+machine zentao.example.com login reader password readpw
+machine zentao.example.com:8080 login admin password adminpw
+default login guest password guestpw
+`, 0o600)
+
+	tests := []struct {
+		baseURL, username, wantUser, wantSecret string
+	}{
+		{"http://zentao.example.com/index.php", "", "reader", "readpw"},
+		{"http://zentao.example.com:8080/index.php", "", "admin", "adminpw"},
+		{"http://zentao.example.com/index.php", "guest", "guest", "guestpw"},
+		{"http://zentao.example.com/index.php", "admin", "", ""},
+		{"http://unknown.example.com/index.php", "", "guest", "guestpw"},
+	}
+	for _, test := range tests {
+		found, err := lookupNetrc(path, test.baseURL, test.username)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if found.Username != test.wantUser || found.Secret != test.wantSecret {
+			t.Errorf("lookupNetrc(%s, %q) = %s/%s, expected %s/%s",
+				test.baseURL, test.username, found.Username, found.Secret, test.wantUser, test.wantSecret)
+		}
+	}
+}
+
+func TestCredentialHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helper script needs a POSIX shell")
+	}
+
+	// The helper echoes the host it was asked about back as the password
+	helper := writeSecret(t, "helper.sh", `#!/bin/sh
+[ "$1" = get ] || exit 1
+while read line; do
+  [ -z "$line" ] && break
+  case "$line" in host=*) host="${line#host=}" ;; esac
+done
+echo "username=helper-user"
+echo "password=pw-for-$host"
+`, 0o700)
+
+	found, err := runCredentialHelper(helper, "https://zentao.example.com/index.php", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if found.Username != "helper-user" || found.Secret != "pw-for-zentao.example.com" {
+		t.Errorf("Unexpected helper result: %+v", found)
+	}
+
+	if _, err := runCredentialHelper(helper+" extra", "https://zentao.example.com", ""); err == nil {
+		t.Error("Expected an error when the helper exits non-zero")
+	}
+}
+
+func TestResolveCredentialsPriority(t *testing.T) {
+	netrc := writeSecret(t, "netrc", "machine zentao.example.com login admin password from-netrc\n", 0o600)
+	keyFile := writeSecret(t, "key", "from-file\n", 0o600)
+	opts := ClientOptions{Credentials: CredentialSources{NetrcFile: netrc}}
+
+	profile := InstanceProfile{Name: "a", BaseURL: "http://zentao.example.com/index.php", AuthMethod: "app", AppKey: "from-value", AppKeyFile: keyFile}
+	if err := opts.resolveCredentials(&profile); err != nil || profile.AppKey != "from-value" {
+		t.Errorf("Expected the explicit value to win, got %q (%v)", profile.AppKey, err)
+	}
+
+	profile = InstanceProfile{Name: "b", BaseURL: "http://zentao.example.com/index.php", AuthMethod: "app", AppKeyFile: keyFile}
+	if err := opts.resolveCredentials(&profile); err != nil || profile.AppKey != "from-file" {
+		t.Errorf("Expected the secret file to win over netrc, got %q (%v)", profile.AppKey, err)
+	}
+
+	profile = InstanceProfile{Name: "c", BaseURL: "http://zentao.example.com/index.php", AuthMethod: "session"}
+	if err := opts.resolveCredentials(&profile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if profile.Account != "admin" || profile.Password != "from-netrc" {
+		t.Errorf("Expected account and password from netrc, got %s/%s", profile.Account, profile.Password)
+	}
+}
+
+func TestResolveInstancesReadsKeyFile(t *testing.T) {
+	t.Setenv("ZENTAO_BASE_URL", "http://zentao.example.com/index.php")
+	t.Setenv("ZENTAO_AUTH_METHOD", "app")
+	t.Setenv("ZENTAO_APP_CODE", "mcp")
+	t.Setenv("ZENTAO_APP_KEY", "")
+	t.Setenv("ZENTAO_APP_KEY_FILE", writeSecret(t, "key", "rotated\n", 0o600))
+
+	opts, err := LoadClientOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	profiles, _, err := opts.ResolveInstances()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if profiles[0].AppKey != "rotated" {
+		t.Errorf("Expected the app key from ZENTAO_APP_KEY_FILE, got %q", profiles[0].AppKey)
+	}
+}
+
+func TestUpdateCredentialsDropsCachedToken(t *testing.T) {
+	client := NewZenTaoClientWithApp("http://zentao.example.com/index.php", "mcp", "old")
+	oldToken, _ := client.getCachedToken()
+
+	client.UpdateCredentials(InstanceProfile{Name: "default", AppCode: "mcp", AppKey: "new"})
+	newToken, _ := client.getCachedToken()
+
+	if client.Key != "new" || newToken == oldToken {
+		t.Errorf("Expected a token signed with the new key, key=%s", client.Key)
+	}
+}
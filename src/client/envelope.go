@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// ErrInvalidEnvelope is returned when a t=json response looks like ZenTao's
+// envelope but cannot be trusted: failed status, checksum mismatch or broken data
+var ErrInvalidEnvelope = errors.New("invalid response envelope")
+
+// UnwrapEnvelope returns the payload of a legacy ZenTao JSON view.
+//
+// Legacy views answer {"status":"success","data":"<json string>","md5":"..."}
+// where data is JSON encoded a second time and md5 is its checksum. The
+// nested JSON is returned as is. Bodies that are not such an envelope, like
+// REST API responses and create/edit results, are returned unchanged.
+func UnwrapEnvelope(body []byte) (json.RawMessage, error) {
+	var envelope struct {
+		Status *string          `json:"status"`
+		Data   *json.RawMessage `json:"data"`
+		MD5    string           `json:"md5"`
+	}
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' || json.Unmarshal(trimmed, &envelope) != nil {
+		return json.RawMessage(body), nil
+	}
+	if envelope.Status == nil || envelope.Data == nil {
+		return json.RawMessage(trimmed), nil
+	}
+
+	if status := *envelope.Status; status != "success" {
+		return nil, fmt.Errorf("%w: status %q", ErrInvalidEnvelope, status)
+	}
+
+	raw := bytes.TrimSpace(*envelope.Data)
+	if len(raw) == 0 || raw[0] != '"' {
+		// data is already an object or array (newer builds, getSessionID)
+		return json.RawMessage(raw), nil
+	}
+
+	var data string
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+
+	if envelope.MD5 != "" {
+		sum := md5.Sum([]byte(data))
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), envelope.MD5) {
+			logger.Warn("client", "Response envelope checksum mismatch", map[string]interface{}{
+				"expected_md5": envelope.MD5,
+				"data_length":  len(data),
+			})
+			return nil, fmt.Errorf("%w: md5 mismatch, response was truncated or altered", ErrInvalidEnvelope)
+		}
+	}
+
+	if !json.Valid([]byte(data)) {
+		// Some views put a plain message in data; hand it over as a JSON string
+		return json.RawMessage(raw), nil
+	}
+	return json.RawMessage(data), nil
+}
+
+// DecodeResponse unwraps body with UnwrapEnvelope and unmarshals the payload
+// into out. A *json.RawMessage out receives the payload untouched.
+func DecodeResponse(body []byte, out interface{}) error {
+	payload, err := UnwrapEnvelope(body)
+	if err != nil {
+		return err
+	}
+	if raw, ok := out.(*json.RawMessage); ok {
+		*raw = payload
+		return nil
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetJSON performs a GET and decodes the unwrapped payload into out
+func (c *ZenTaoClient) GetJSON(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.GetCtx(ctx, path)
+	if err != nil {
+		return err
+	}
+	if err := DecodeResponse(resp, out); err != nil {
+		logger.Error("client", "Failed to decode response envelope", err, map[string]interface{}{
+			"path": path,
+		})
+		return err
+	}
+	return nil
+}
+
+// PostJSON performs a POST for views that read data but require a form post,
+// and decodes the unwrapped payload into out
+func (c *ZenTaoClient) PostJSON(ctx context.Context, path string, body interface{}, out interface{}) error {
+	resp, err := c.PostCtx(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	if err := DecodeResponse(resp, out); err != nil {
+		logger.Error("client", "Failed to decode response envelope", err, map[string]interface{}{
+			"path": path,
+		})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func legacyEnvelope(data string) string {
+	sum := md5.Sum([]byte(data))
+	encoded, _ := json.Marshal(data)
+	return fmt.Sprintf(`{"status":"success","data":%s,"md5":"%s"}`, encoded, hex.EncodeToString(sum[:]))
+}
+
+func TestUnwrapEnvelope(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{"double encoded", legacyEnvelope(`{"product":{"id":1}}`), `{"product":{"id":1}}`, false},
+		{"without md5", `{"status":"success","data":"[1,2]"}`, `[1,2]`, false},
+		{"object data", `{"status":"success","data":{"sessionID":"abc"}}`, `{"sessionID":"abc"}`, false},
+		{"plain message", `{"status":"success","data":"Saved"}`, `"Saved"`, false},
+		{"not an envelope", `{"id":3,"result":"success"}`, `{"id":3,"result":"success"}`, false},
+		{"rest array", `[{"id":1}]`, `[{"id":1}]`, false},
+		{"md5 mismatch", `{"status":"success","data":"{\"id\":1}","md5":"0123"}`, "", true},
+		{"failed status", `{"status":"failed","data":"{}"}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := UnwrapEnvelope([]byte(test.body))
+			if test.wantErr {
+				if !errors.Is(err, ErrInvalidEnvelope) {
+					t.Errorf("Expected ErrInvalidEnvelope, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("Expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGetJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(legacyEnvelope(`{"title":"Bug list","bugs":[{"id":7,"title":"Crash"}]}`)))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+
+	var page struct {
+		Bugs []struct {
+			ID    int    `json:"id"`
+			Title string `json:"title"`
+		} `json:"bugs"`
+	}
+	if err := client.GetJSON(context.Background(), "/products/1/bugs", &page); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(page.Bugs) != 1 || page.Bugs[0].ID != 7 || page.Bugs[0].Title != "Crash" {
+		t.Errorf("Expected decoded bug list, got %+v", page)
+	}
+
+	var raw json.RawMessage
+	if err := client.GetJSON(context.Background(), "/products/1/bugs", &raw); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(raw) != `{"title":"Bug list","bugs":[{"id":7,"title":"Crash"}]}` {
+		t.Errorf("Expected payload untouched in RawMessage, got %s", raw)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel error kinds. An *APIError unwraps to exactly one of these, so callers
+// can branch with errors.Is(err, client.ErrNotFound) and friends.
+var (
+	ErrAuthExpired      = errors.New("authentication expired")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNotFound         = errors.New("not found")
+	ErrValidationFailed = errors.New("validation failed")
+	ErrServerError      = errors.New("server error")
+	ErrRequestFailed    = errors.New("request failed")
+)
+
+// APIError describes a ZenTao call that reached the server but did not succeed,
+// either because of the HTTP status or because the response envelope says so.
+type APIError struct {
+	Kind       error  // one of the Err* sentinels above
+	StatusCode int    // HTTP status code
+	Method     string // HTTP method
+	Endpoint   string // path as requested by the caller, without auth params
+	Result     string // ZenTao "result" field (e.g. "fail")
+	Status     string // ZenTao "status" field (e.g. "failed")
+	Message    string // human readable reason extracted from the response
+	ErrCode    int    // ZenTao "errcode" field, 0 if absent
+
+	RetryAfter time.Duration // parsed Retry-After header, 0 if absent
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("ZenTao ")
+	b.WriteString(e.Kind.Error())
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " on %s %s", e.Method, e.Endpoint)
+	}
+
+	var details []string
+	if e.StatusCode != 0 {
+		details = append(details, fmt.Sprintf("HTTP %d", e.StatusCode))
+	}
+	if e.ErrCode != 0 {
+		details = append(details, fmt.Sprintf("errcode %d", e.ErrCode))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// TransportError is returned when no HTTP response was received at all
+// (connection refused or reset, DNS failure, timeout, truncated body).
+type TransportError struct {
+	Method   string
+	Endpoint string
+	Err      error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("failed to execute request %s %s: %v", e.Method, e.Endpoint, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// classifyResponse inspects an HTTP response and returns an *APIError if it
+// represents a failure, or nil if the body can be handed to the caller.
+func classifyResponse(method, endpoint string, statusCode int, contentType string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+	}
+
+	var envelope map[string]interface{}
+	isJSON := json.Unmarshal(body, &envelope) == nil
+	if isJSON {
+		apiErr.Result, _ = envelope["result"].(string)
+		apiErr.Status, _ = envelope["status"].(string)
+		apiErr.ErrCode = envelopeErrCode(envelope)
+		apiErr.Message = envelopeMessage(envelope)
+	}
+
+	// HTTP level failures win over anything the body says
+	if statusCode >= 400 {
+		apiErr.Kind = kindForStatus(statusCode)
+		if apiErr.Message == "" && !isJSON {
+			apiErr.Message = http.StatusText(statusCode)
+		}
+		return apiErr
+	}
+
+	if !isJSON {
+		// ZenTao answers unauthenticated requests with its HTML login page
+		if isLoginPage(contentType, body) {
+			apiErr.Kind = ErrAuthExpired
+			apiErr.Message = "ZenTao redirected to the login page"
+			return apiErr
+		}
+		return nil
+	}
+
+	if locate, ok := envelope["locate"].(string); ok && strings.Contains(locate, "user-login") {
+		apiErr.Kind = ErrAuthExpired
+		return apiErr
+	}
+
+	failed := isFailure(apiErr.Result) || isFailure(apiErr.Status)
+	if apiErr.ErrCode == 0 && !failed {
+		return nil
+	}
+
+	switch {
+	case apiErr.ErrCode == 401 || apiErr.ErrCode == 405:
+		apiErr.Kind = ErrAuthExpired
+	case apiErr.ErrCode != 0 && apiErr.ErrCode >= 400:
+		apiErr.Kind = kindForStatus(apiErr.ErrCode)
+	default:
+		apiErr.Kind = kindForMessage(apiErr.Message)
+	}
+	return apiErr
+}
+
+func kindForStatus(code int) error {
+	switch {
+	case code == http.StatusUnauthorized:
+		return ErrAuthExpired
+	case code == http.StatusForbidden:
+		return ErrPermissionDenied
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity || code == http.StatusConflict:
+		return ErrValidationFailed
+	case code >= 500:
+		return ErrServerError
+	default:
+		return ErrRequestFailed
+	}
+}
+
+// kindForMessage maps a failed envelope without an errcode to an error kind
+// based on the wording ZenTao uses (English and Chinese builds).
+func kindForMessage(message string) error {
+	msg := strings.ToLower(message)
+	switch {
+	case strings.Contains(msg, "token") || strings.Contains(msg, "expired") || strings.Contains(msg, "login") || strings.Contains(msg, "登录"):
+		return ErrAuthExpired
+	case strings.Contains(msg, "permission") || strings.Contains(msg, "denied") || strings.Contains(msg, "权限"):
+		return ErrPermissionDenied
+	case strings.Contains(msg, "not found") || strings.Contains(msg, "not exist") || strings.Contains(msg, "不存在"):
+		return ErrNotFound
+	default:
+		return ErrValidationFailed
+	}
+}
+
+func isFailure(value string) bool {
+	switch strings.ToLower(value) {
+	case "fail", "failed", "error":
+		return true
+	}
+	return false
+}
+
+func isLoginPage(contentType string, body []byte) bool {
+	if !strings.Contains(contentType, "html") && !strings.HasPrefix(strings.TrimSpace(string(body)), "<") {
+		return false
+	}
+	page := string(body)
+	return strings.Contains(page, "user-login") || strings.Contains(page, "m=user&f=login") || strings.Contains(page, "loginPanel")
+}
+
+func envelopeErrCode(envelope map[string]interface{}) int {
+	switch v := envelope["errcode"].(type) {
+	case float64:
+		return int(v)
+	case string:
+		code, _ := strconv.Atoi(v)
+		return code
+	}
+	return 0
+}
+
+// envelopeMessage extracts a readable reason from the fields ZenTao uses for it.
+// Validation failures carry a map of field name to messages, which is flattened.
+func envelopeMessage(envelope map[string]interface{}) string {
+	for _, field := range []string{"message", "errmsg", "error", "reason"} {
+		if msg := flattenMessage(envelope[field]); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+func flattenMessage(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if msg := flattenMessage(item); msg != "" {
+				parts = append(parts, msg)
+			}
+		}
+		return strings.Join(parts, "; ")
+	case map[string]interface{}:
+		keys := getMapKeys(v)
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			if msg := flattenMessage(v[k]); msg != "" {
+				parts = append(parts, k+": "+msg)
+			}
+		}
+		return strings.Join(parts, "; ")
+	}
+	return ""
+}
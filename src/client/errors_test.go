@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClassifyResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		body        string
+		expected    error // nil means the response is a success
+		message     string
+	}{
+		{"Success envelope", 200, "application/json", `{"status": "success", "data": {"id": 1}}`, nil, ""},
+		{"Plain JSON list", 200, "application/json", `[{"id": 1}]`, nil, ""},
+		{"Non-login HTML", 200, "text/html", `<html><body>report</body></html>`, nil, ""},
+		{"HTTP 401", 401, "application/json", `{"error": "Unauthorized"}`, ErrAuthExpired, "Unauthorized"},
+		{"HTTP 403", 403, "application/json", `{"error": "Forbidden"}`, ErrPermissionDenied, "Forbidden"},
+		{"HTTP 404 without body", 404, "text/plain", ``, ErrNotFound, "Not Found"},
+		{"HTTP 400", 400, "application/json", `{"message": "name is required"}`, ErrValidationFailed, "name is required"},
+		{"HTTP 502", 502, "text/html", `<html>Bad Gateway</html>`, ErrServerError, "Bad Gateway"},
+		{"Login page", 200, "text/html; charset=utf-8", `<html><form id="loginPanel" action="/user-login.html"></form></html>`, ErrAuthExpired, "login page"},
+		{"Locate to login", 200, "application/json", `{"result": "fail", "locate": "/zentao/user-login.html"}`, ErrAuthExpired, ""},
+		{"Errcode 405", 200, "application/json", `{"errcode": 405, "errmsg": "Token expired"}`, ErrAuthExpired, "Token expired"},
+		{"Errcode 403", 200, "application/json", `{"errcode": 403, "errmsg": "No access"}`, ErrPermissionDenied, "No access"},
+		{"Result fail with field errors", 200, "application/json", `{"result": "fail", "message": {"title": ["Title is required"], "pri": "Bad priority"}}`, ErrValidationFailed, "pri: Bad priority; title: Title is required"},
+		{"Status failed permission", 200, "application/json", `{"status": "failed", "reason": "Access denied"}`, ErrPermissionDenied, "Access denied"},
+		{"Status fail not found", 200, "application/json", `{"status": "fail", "message": "Bug does not exist"}`, ErrNotFound, "Bug does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := classifyResponse("GET", "/bugs/1", tt.statusCode, tt.contentType, []byte(tt.body))
+			if tt.expected == nil {
+				if apiErr != nil {
+					t.Fatalf("Expected success, got %v", apiErr)
+				}
+				return
+			}
+			if apiErr == nil {
+				t.Fatalf("Expected %v, got success", tt.expected)
+			}
+			if !errors.Is(apiErr, tt.expected) {
+				t.Errorf("Expected kind %v, got %v", tt.expected, apiErr.Kind)
+			}
+			if !strings.Contains(apiErr.Message, tt.message) {
+				t.Errorf("Expected message to contain %q, got %q", tt.message, apiErr.Message)
+			}
+			if apiErr.Endpoint != "/bugs/1" || apiErr.StatusCode != tt.statusCode {
+				t.Errorf("Expected endpoint and status to be recorded, got %+v", apiErr)
+			}
+		})
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &APIError{
+		Kind:       ErrPermissionDenied,
+		StatusCode: 200,
+		Method:     "POST",
+		Endpoint:   "/products/1/bugs",
+		ErrCode:    403,
+		Message:    "No access",
+	}
+
+	expected := "ZenTao permission denied on POST /products/1/bugs (HTTP 200, errcode 403): No access"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestDoRequestReturnsAPIError(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status": "fail", "message": "Product not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/api.php")
+
+	resp, err := client.Get("/products/999")
+	if resp != nil {
+		t.Errorf("Expected no body for failed request, got %s", resp)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", apiErr.Kind)
+	}
+	if hits != 1 {
+		t.Errorf("Expected definitive errors not to be retried, got %d requests", hits)
+	}
+}
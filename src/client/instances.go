@@ -0,0 +1,358 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// DefaultInstanceName is the profile built from the single-instance ZENTAO_* variables
+const DefaultInstanceName = "default"
+
+// InstanceProfile describes one ZenTao installation served by this process
+type InstanceProfile struct {
+	Name        string
+	BaseURL     string
+	AuthMethod  string // "app", "session" or "token"
+	AppCode     string
+	AppKey      string
+	Account     string // session login and REST token account
+	Password    string
+	APIMode     string // "legacy" or "rest"
+	RESTURL     string
+	SessionFile string
+
+	AppKeyFile   string // read when AppKey is empty (see CredentialSources)
+	PasswordFile string // read when Password is empty
+}
+
+type fileInstance struct {
+	BaseURL     string `json:"base_url"`
+	AuthMethod  string `json:"auth_method"`
+	AppCode     string `json:"app_code"`
+	AppKey      string `json:"app_key"`
+	Account     string `json:"account"`
+	Password    string `json:"password"`
+	APIMode     string `json:"api_mode"`
+	RESTURL     string `json:"rest_url"`
+	SessionFile string `json:"session_file"`
+
+	AppKeyFile   string `json:"app_key_file"`
+	PasswordFile string `json:"password_file"`
+}
+
+var instanceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// instanceEnvPrefix maps a profile name to its variable prefix: "qa-lab" -> "ZENTAO_QA_LAB_"
+func instanceEnvPrefix(name string) string {
+	return "ZENTAO_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// setInstance adds or replaces a named profile in o.Instances
+func (o *ClientOptions) setInstance(profile InstanceProfile) {
+	for i := range o.Instances {
+		if o.Instances[i].Name == profile.Name {
+			o.Instances[i] = profile
+			return
+		}
+	}
+	o.Instances = append(o.Instances, profile)
+}
+
+func (o *ClientOptions) instance(name string) InstanceProfile {
+	for _, profile := range o.Instances {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return InstanceProfile{Name: name}
+}
+
+// loadInstancesEnv reads ZENTAO_INSTANCES=eng,partner and the matching
+// ZENTAO_<NAME>_BASE_URL, _AUTH_METHOD, _APP_CODE, ... variables
+func (o *ClientOptions) loadInstancesEnv() {
+	if v := os.Getenv("ZENTAO_DEFAULT_INSTANCE"); v != "" {
+		o.DefaultInstance = v
+	}
+
+	list := os.Getenv("ZENTAO_INSTANCES")
+	if list == "" {
+		return
+	}
+
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		profile := o.instance(name)
+		prefix := instanceEnvPrefix(name)
+
+		fields := map[string]*string{
+			"BASE_URL":     &profile.BaseURL,
+			"AUTH_METHOD":  &profile.AuthMethod,
+			"APP_CODE":     &profile.AppCode,
+			"APP_KEY":      &profile.AppKey,
+			"ACCOUNT":      &profile.Account,
+			"PASSWORD":     &profile.Password,
+			"API_MODE":     &profile.APIMode,
+			"REST_URL":     &profile.RESTURL,
+			"SESSION_FILE": &profile.SessionFile,
+
+			"APP_KEY_FILE":  &profile.AppKeyFile,
+			"PASSWORD_FILE": &profile.PasswordFile,
+		}
+		for suffix, field := range fields {
+			if v := os.Getenv(prefix + suffix); v != "" {
+				*field = v
+			}
+		}
+		o.setInstance(profile)
+	}
+}
+
+// ResolveInstances returns every configured profile plus the name of the default one.
+// The classic ZENTAO_BASE_URL variables form the "default" profile when they are set
+// or when no named profiles exist, so single-instance setups keep working unchanged.
+func (o ClientOptions) ResolveInstances() ([]InstanceProfile, string, error) {
+	profiles := append([]InstanceProfile(nil), o.Instances...)
+
+	baseURL := os.Getenv("ZENTAO_BASE_URL")
+	if baseURL != "" || len(profiles) == 0 {
+		if baseURL == "" {
+			baseURL = "http://localhost:8080"
+			logger.Warn("client", "ZENTAO_BASE_URL not set, using default", map[string]interface{}{
+				"default_url": baseURL,
+			})
+		}
+		legacy := InstanceProfile{
+			Name:        DefaultInstanceName,
+			BaseURL:     baseURL,
+			AuthMethod:  os.Getenv("ZENTAO_AUTH_METHOD"),
+			AppCode:     os.Getenv("ZENTAO_APP_CODE"),
+			AppKey:      os.Getenv("ZENTAO_APP_KEY"),
+			Account:     o.Session.Account,
+			Password:    o.Session.Password,
+			APIMode:     os.Getenv("ZENTAO_API_MODE"),
+			RESTURL:     os.Getenv("ZENTAO_REST_URL"),
+			SessionFile: o.Session.File,
+
+			AppKeyFile:   o.Credentials.AppKeyFile,
+			PasswordFile: o.Credentials.PasswordFile,
+		}
+
+		replaced := false
+		for _, profile := range profiles {
+			if profile.Name == DefaultInstanceName {
+				replaced = true
+			}
+		}
+		if replaced {
+			logger.Warn("client", "Named profile \"default\" overrides ZENTAO_BASE_URL settings", nil)
+		} else {
+			profiles = append(profiles, legacy)
+		}
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	for i := range profiles {
+		profile := &profiles[i]
+		if !instanceNamePattern.MatchString(profile.Name) {
+			return nil, "", fmt.Errorf("invalid instance name %q (use lowercase letters, digits, - and _)", profile.Name)
+		}
+		if i > 0 && profiles[i-1].Name == profile.Name {
+			return nil, "", fmt.Errorf("instance %q is defined twice", profile.Name)
+		}
+		if profile.BaseURL == "" {
+			return nil, "", fmt.Errorf("instance %q has no base URL", profile.Name)
+		}
+		if profile.AuthMethod == "" {
+			profile.AuthMethod = "app"
+		}
+		if _, err := ParseAPIMode(profile.APIMode); err != nil {
+			return nil, "", fmt.Errorf("instance %q: %w", profile.Name, err)
+		}
+		if err := o.resolveCredentials(profile); err != nil {
+			return nil, "", fmt.Errorf("instance %q: %w", profile.Name, err)
+		}
+	}
+
+	defaultName := o.DefaultInstance
+	if defaultName == "" {
+		defaultName = profiles[0].Name
+		for _, profile := range profiles {
+			if profile.Name == DefaultInstanceName {
+				defaultName = DefaultInstanceName
+			}
+		}
+	}
+	found := false
+	for _, profile := range profiles {
+		if profile.Name == defaultName {
+			found = true
+		}
+	}
+	if !found {
+		return nil, "", fmt.Errorf("default instance %q is not configured", defaultName)
+	}
+
+	return profiles, defaultName, nil
+}
+
+// NewClientFromProfile builds a client for one instance. Credentials never leak
+// between profiles: the session and REST accounts come from the profile only.
+func NewClientFromProfile(profile InstanceProfile, opts ClientOptions) (*ZenTaoClient, error) {
+	var c *ZenTaoClient
+
+	switch profile.AuthMethod {
+	case "app":
+		c = NewZenTaoClientWithApp(profile.BaseURL, profile.AppCode, profile.AppKey)
+	case "session":
+		c = NewZenTaoClientWithSession(profile.BaseURL)
+	case "token":
+		c = NewZenTaoClientWithToken(profile.BaseURL, profile.Account, profile.Password)
+	default:
+		logger.Warn("client", "Unknown auth method, defaulting to app-based", map[string]interface{}{
+			"instance":        profile.Name,
+			"provided_method": profile.AuthMethod,
+			"default_method":  "app",
+		})
+		c = NewZenTaoClientWithApp(profile.BaseURL, profile.AppCode, profile.AppKey)
+	}
+
+	apiMode, err := ParseAPIMode(profile.APIMode)
+	if err != nil {
+		return nil, err
+	}
+	if apiMode == APIModeREST || profile.AuthMethod == "token" {
+		// POST /tokens lives under the REST root in both cases
+		c.SetRESTCredentials(profile.Account, profile.Password)
+		c.SetAPIMode(apiMode, profile.RESTURL)
+	}
+
+	opts.Session = SessionOptions{
+		Account:  profile.Account,
+		Password: profile.Password,
+		File:     profile.SessionFile,
+	}
+	if err := c.ApplyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Pool holds one client per configured instance
+type Pool struct {
+	mu          sync.RWMutex // guards profiles, which change on credential reload, and callers
+	clients     map[string]*ZenTaoClient
+	profiles    map[string]InstanceProfile
+	names       []string
+	defaultName string
+
+	opts    ClientOptions  // used to build per-caller clients
+	callers *callerClients // per-session clients, nil until EnableCallers (see callers.go)
+}
+
+// NewPool builds a client for every profile
+func NewPool(profiles []InstanceProfile, defaultName string, opts ClientOptions) (*Pool, error) {
+	pool := &Pool{
+		clients:     make(map[string]*ZenTaoClient, len(profiles)),
+		profiles:    make(map[string]InstanceProfile, len(profiles)),
+		defaultName: defaultName,
+		opts:        opts,
+	}
+
+	for _, profile := range profiles {
+		c, err := NewClientFromProfile(profile, opts)
+		if err != nil {
+			return nil, fmt.Errorf("instance %q: %w", profile.Name, err)
+		}
+		pool.clients[profile.Name] = c
+		pool.profiles[profile.Name] = profile
+		pool.names = append(pool.names, profile.Name)
+	}
+	sort.Strings(pool.names)
+
+	if _, ok := pool.clients[defaultName]; !ok {
+		return nil, fmt.Errorf("default instance %q is not configured", defaultName)
+	}
+
+	logger.Info("client", "Instance pool ready", map[string]interface{}{
+		"instances":        pool.names,
+		"default_instance": defaultName,
+	})
+
+	return pool, nil
+}
+
+// Get returns the client for name; an empty name selects the default instance
+func (p *Pool) Get(name string) (*ZenTaoClient, error) {
+	if name == "" {
+		name = p.defaultName
+	}
+	c, ok := p.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown ZenTao instance %q (configured: %s)", name, strings.Join(p.names, ", "))
+	}
+	return c, nil
+}
+
+// Default returns the client used when a tool call names no instance
+func (p *Pool) Default() *ZenTaoClient {
+	return p.clients[p.defaultName]
+}
+
+// DefaultName returns the name of the default instance
+func (p *Pool) DefaultName() string {
+	return p.defaultName
+}
+
+// Names returns the configured instance names in sorted order
+func (p *Pool) Names() []string {
+	return append([]string(nil), p.names...)
+}
+
+// Profile returns the configuration of a named instance
+func (p *Pool) Profile(name string) (InstanceProfile, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	profile, ok := p.profiles[name]
+	return profile, ok
+}
+
+type clientContextKey struct{}
+
+// WithClient binds c to ctx so requests made through any ZenTaoClient with
+// this context are served by c. Tool middleware uses it to route calls to
+// the instance selected by the caller.
+func WithClient(ctx context.Context, c *ZenTaoClient) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, c)
+}
+
+// ForContext returns the client bound to ctx by WithClient, or c itself
+func (c *ZenTaoClient) ForContext(ctx context.Context) *ZenTaoClient {
+	if bound, ok := ctx.Value(clientContextKey{}).(*ZenTaoClient); ok && bound != nil {
+		return bound
+	}
+	return c
+}
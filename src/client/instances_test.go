@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolveInstancesLegacyOnly(t *testing.T) {
+	t.Setenv("ZENTAO_BASE_URL", "http://zentao.example.com/index.php")
+	t.Setenv("ZENTAO_AUTH_METHOD", "session")
+	t.Setenv("ZENTAO_ACCOUNT", "admin")
+
+	opts, err := LoadClientOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	profiles, defaultName, err := opts.ResolveInstances()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(profiles) != 1 || defaultName != DefaultInstanceName {
+		t.Fatalf("Expected a single default profile, got %+v (default %s)", profiles, defaultName)
+	}
+	if profiles[0].AuthMethod != "session" || profiles[0].Account != "admin" {
+		t.Errorf("Expected legacy variables in default profile, got %+v", profiles[0])
+	}
+}
+
+func TestResolveInstancesFromFileAndEnv(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "zentao.json")
+	config := `{
+		"default_instance": "eng",
+		"instances": {
+			"eng": {"base_url": "http://eng.example.com/index.php", "app_code": "ENG", "app_key": "k1"},
+			"partner": {"base_url": "http://file.example.com/index.php", "auth_method": "session"}
+		}
+	}`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv("ZENTAO_CONFIG_FILE", configPath)
+	t.Setenv("ZENTAO_BASE_URL", "")
+	t.Setenv("ZENTAO_INSTANCES", "partner,qa-lab")
+	t.Setenv("ZENTAO_PARTNER_BASE_URL", "http://partner.example.com/index.php")
+	t.Setenv("ZENTAO_PARTNER_ACCOUNT", "outsourcer")
+	t.Setenv("ZENTAO_QA_LAB_BASE_URL", "http://qa.example.com/index.php")
+
+	opts, err := LoadClientOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	profiles, defaultName, err := opts.ResolveInstances()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if defaultName != "eng" {
+		t.Errorf("Expected default instance eng, got %s", defaultName)
+	}
+	if len(profiles) != 3 {
+		t.Fatalf("Expected eng, partner and qa-lab without a legacy profile, got %+v", profiles)
+	}
+
+	partner := profiles[1]
+	if partner.Name != "partner" || partner.BaseURL != "http://partner.example.com/index.php" {
+		t.Errorf("Expected env to override file base URL, got %+v", partner)
+	}
+	if partner.AuthMethod != "session" || partner.Account != "outsourcer" {
+		t.Errorf("Expected file and env fields to merge, got %+v", partner)
+	}
+	if profiles[2].Name != "qa-lab" || profiles[2].AuthMethod != "app" {
+		t.Errorf("Expected qa-lab with default auth method, got %+v", profiles[2])
+	}
+}
+
+func TestResolveInstancesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ClientOptions
+		baseURL string
+	}{
+		{"missing base url", ClientOptions{Instances: []InstanceProfile{{Name: "eng"}}}, ""},
+		{"bad name", ClientOptions{Instances: []InstanceProfile{{Name: "Eng Team", BaseURL: "http://x"}}}, ""},
+		{"bad api mode", ClientOptions{Instances: []InstanceProfile{{Name: "eng", BaseURL: "http://x", APIMode: "soap"}}}, ""},
+		{"unknown default", ClientOptions{Instances: []InstanceProfile{{Name: "eng", BaseURL: "http://x"}}, DefaultInstance: "qa"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("ZENTAO_BASE_URL", test.baseURL)
+			if _, _, err := test.opts.ResolveInstances(); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestPoolRoutesByContext(t *testing.T) {
+	var engHits, partnerHits int32
+	eng := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&engHits, 1)
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer eng.Close()
+	partner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&partnerHits, 1)
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer partner.Close()
+
+	profiles := []InstanceProfile{
+		{Name: "eng", BaseURL: eng.URL + "/index.php", AuthMethod: "app"},
+		{Name: "partner", BaseURL: partner.URL + "/index.php", AuthMethod: "app"},
+	}
+	pool, err := NewPool(profiles, "eng", DefaultClientOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := pool.Get("missing"); err == nil {
+		t.Error("Expected error for unknown instance")
+	}
+
+	partnerClient, _ := pool.Get("partner")
+	ctx := WithClient(context.Background(), partnerClient)
+
+	// Handlers hold the default client; the context decides where the call goes
+	if _, err := pool.Default().GetCtx(ctx, "/products"); err != nil {
+		t.Fatalf("Routed request failed: %v", err)
+	}
+	if _, err := pool.Default().GetCtx(context.Background(), "/products"); err != nil {
+		t.Fatalf("Default request failed: %v", err)
+	}
+
+	if atomic.LoadInt32(&partnerHits) != 1 || atomic.LoadInt32(&engHits) != 1 {
+		t.Errorf("Expected one request per instance, got eng=%d partner=%d", engHits, partnerHits)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// RateLimit caps traffic toward ZenTao. Zero values disable the respective limit.
+type RateLimit struct {
+	RequestsPerSecond float64 // sustained rate of the token bucket
+	Burst             int     // bucket size, defaults to 1 when a rate is set
+	MaxConcurrent     int     // requests in flight at the same time
+}
+
+// RateLimits holds separate budgets so bulk reads cannot starve writes and vice versa
+type RateLimits struct {
+	Read  RateLimit
+	Write RateLimit
+}
+
+// tokenBucket is a classic token bucket that hands out reservations
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	clock  Clock
+}
+
+func newTokenBucket(rate float64, burst int, clock Clock) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+		clock:  clock,
+	}
+}
+
+// reserve takes one token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refund returns a token whose reservation was abandoned
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// requestLimiter combines a token bucket with a concurrency semaphore
+type requestLimiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+	clock  Clock
+}
+
+func newRequestLimiter(limit RateLimit, clock Clock) *requestLimiter {
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	l := &requestLimiter{clock: clock}
+	if limit.RequestsPerSecond > 0 {
+		l.bucket = newTokenBucket(limit.RequestsPerSecond, limit.Burst, clock)
+	}
+	if limit.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return l
+}
+
+// acquire blocks until the request may proceed. The returned release func must
+// be called once the response has been read; waited reports the queue time.
+func (l *requestLimiter) acquire(ctx context.Context) (release func(), waited time.Duration, err error) {
+	start := time.Now()
+
+	if l.bucket != nil {
+		if delay := l.bucket.reserve(); delay > 0 {
+			if err := l.clock.Sleep(ctx, delay); err != nil {
+				l.bucket.refund()
+				return nil, time.Since(start), err
+			}
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, time.Since(start), nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, time.Since(start), ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.slots })
+	}, time.Since(start), nil
+}
+
+// clientLimiters is swapped atomically on the client when limits change
+type clientLimiters struct {
+	limits RateLimits
+	read   *requestLimiter
+	write  *requestLimiter
+}
+
+// SetRateLimits configures client-side throttling. Writes (POST/PUT/DELETE)
+// draw from their own budget, everything else from the read budget.
+func (c *ZenTaoClient) SetRateLimits(limits RateLimits) {
+	c.limiters.Store(&clientLimiters{
+		limits: limits,
+		read:   newRequestLimiter(limits.Read, nil),
+		write:  newRequestLimiter(limits.Write, nil),
+	})
+
+	logger.Info("client", "Rate limits configured", map[string]interface{}{
+		"read_rps":             limits.Read.RequestsPerSecond,
+		"read_burst":           limits.Read.Burst,
+		"read_max_concurrent":  limits.Read.MaxConcurrent,
+		"write_rps":            limits.Write.RequestsPerSecond,
+		"write_burst":          limits.Write.Burst,
+		"write_max_concurrent": limits.Write.MaxConcurrent,
+	})
+}
+
+// waitForSlot applies the configured rate limits to a single upstream request
+func (c *ZenTaoClient) waitForSlot(ctx context.Context, method, path string) (func(), error) {
+	limiters := c.limiters.Load()
+	if limiters == nil {
+		return func() {}, nil
+	}
+
+	limiter, budget := limiters.read, "read"
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+		limiter, budget = limiters.write, "write"
+	}
+
+	release, waited, err := limiter.acquire(ctx)
+	if err != nil {
+		logger.Warn("client", "Gave up waiting for rate limiter", map[string]interface{}{
+			"method":        method,
+			"path":          path,
+			"budget":        budget,
+			"queue_wait_ms": waited.Milliseconds(),
+			"reason":        err.Error(),
+		})
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"method":        method,
+		"path":          path,
+		"budget":        budget,
+		"queue_wait_ms": waited.Milliseconds(),
+	}
+	if waited >= 100*time.Millisecond {
+		logger.Info("client", "Request was throttled by client-side rate limit", fields)
+	} else {
+		logger.Debug("client", "Rate limiter slot acquired", fields)
+	}
+
+	return release, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	clock := newFakeClock()
+	bucket := newTokenBucket(2, 2, clock)
+
+	// Burst is available immediately
+	for i := 0; i < 2; i++ {
+		if delay := bucket.reserve(); delay != 0 {
+			t.Errorf("Expected no delay within burst, got %v", delay)
+		}
+	}
+
+	// Third request must wait for half a second at 2 rps
+	if delay := bucket.reserve(); delay != 500*time.Millisecond {
+		t.Errorf("Expected 500ms delay, got %v", delay)
+	}
+
+	// After a full second the debt is paid and one token has been earned
+	clock.Advance(time.Second)
+	if delay := bucket.reserve(); delay != 0 {
+		t.Errorf("Expected refilled token, got delay %v", delay)
+	}
+}
+
+func TestRequestLimiterRateWait(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRequestLimiter(RateLimit{RequestsPerSecond: 1, Burst: 1}, clock)
+
+	for i := 0; i < 3; i++ {
+		release, _, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		release()
+	}
+
+	sleeps := clock.Sleeps()
+	if len(sleeps) != 2 {
+		t.Fatalf("Expected two throttled requests, got sleeps %v", sleeps)
+	}
+	for _, d := range sleeps {
+		if d != time.Second {
+			t.Errorf("Expected 1s waits at 1 rps, got %v", sleeps)
+		}
+	}
+}
+
+func TestRequestLimiterConcurrencyCancel(t *testing.T) {
+	limiter := newRequestLimiter(RateLimit{MaxConcurrent: 1}, nil)
+
+	release, _, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := limiter.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected queued request to give up with the context, got %v", err)
+	}
+
+	release()
+	release() // releasing twice must not free a second slot
+
+	release2, _, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected slot after release, got %v", err)
+	}
+	release2()
+}
+
+func TestClientMaxConcurrent(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRateLimits(RateLimits{Read: RateLimit{MaxConcurrent: 2}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get("/products"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, saw %d", max)
+	}
+}
+
+func TestClientSeparateWriteBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRateLimits(RateLimits{
+		Read:  RateLimit{MaxConcurrent: 1},
+		Write: RateLimit{MaxConcurrent: 1},
+	})
+
+	// Exhaust the read budget
+	limiters := client.limiters.Load()
+	release, _, err := limiters.read.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := client.PostCtx(ctx, "/products", map[string]interface{}{"name": "x"}); err != nil {
+		t.Errorf("Expected write to use its own budget, got %v", err)
+	}
+}
@@ -0,0 +1,686 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// ClientOptions controls the HTTP transport used to talk to ZenTao.
+// Values are resolved from defaults, then the config file, then env vars.
+type ClientOptions struct {
+	RequestTimeout      time.Duration // whole request including body read, 0 disables
+	DialTimeout         time.Duration // TCP connect timeout
+	ProxyURL            string        // explicit proxy, empty falls back to HTTP(S)_PROXY
+	CAFile              string        // PEM bundle appended to the system roots
+	ClientCertFile      string        // PEM client certificate for mTLS
+	ClientKeyFile       string        // PEM private key for mTLS
+	InsecureSkipVerify  bool          // lab use only
+	MaxIdleConnsPerHost int
+
+	Retry      RetryPolicy   // backoff for transient failures
+	RateLimits RateLimits    // client-side throttling toward ZenTao
+	Breaker    BreakerConfig // fail fast while ZenTao is down
+
+	Session SessionOptions // credentials for automatic session login
+
+	Instances       []InstanceProfile // named ZenTao instances (see instances.go)
+	DefaultInstance string            // instance used when a tool call names none
+
+	VCR VCRConfig // record or replay ZenTao traffic (see vcr.go)
+
+	Cache        CacheConfig // read-through cache for GET requests (see cache.go)
+	CoalesceGets bool        // share one upstream call between identical concurrent GETs
+
+	ReadOnly bool // reject every request that could change ZenTao (see readonly.go)
+
+	ClockSkew ClockSkewConfig // compensate app token timestamps for server clock offset (see skew.go)
+
+	Credentials CredentialSources // secret files, credential helper and netrc (see credentials.go)
+
+	Callers CallerConfig // per-caller credentials on the HTTP transport (see callers.go)
+}
+
+// fileOptions mirrors ClientOptions in the JSON config file, with durations as strings ("30s")
+type fileOptions struct {
+	RequestTimeout      string `json:"request_timeout"`
+	DialTimeout         string `json:"dial_timeout"`
+	ProxyURL            string `json:"proxy_url"`
+	CAFile              string `json:"ca_file"`
+	ClientCertFile      string `json:"client_cert_file"`
+	ClientKeyFile       string `json:"client_key_file"`
+	InsecureSkipVerify  *bool  `json:"insecure_skip_verify"`
+	MaxIdleConnsPerHost int    `json:"max_idle_conns_per_host"`
+
+	Retry *struct {
+		MaxRetries     *int     `json:"max_retries"`
+		InitialBackoff string   `json:"initial_backoff"`
+		MaxBackoff     string   `json:"max_backoff"`
+		Multiplier     float64  `json:"multiplier"`
+		Jitter         *float64 `json:"jitter"`
+		MaxElapsed     string   `json:"max_elapsed"`
+	} `json:"retry"`
+
+	RateLimit *struct {
+		Read  *fileRateLimit `json:"read"`
+		Write *fileRateLimit `json:"write"`
+	} `json:"rate_limit"`
+
+	CircuitBreaker *struct {
+		FailureThreshold *int   `json:"failure_threshold"`
+		Cooldown         string `json:"cooldown"`
+	} `json:"circuit_breaker"`
+
+	Session *struct {
+		Account  string `json:"account"`
+		Password string `json:"password"`
+		File     string `json:"file"`
+	} `json:"session"`
+
+	Instances       map[string]fileInstance `json:"instances"`
+	DefaultInstance string                  `json:"default_instance"`
+
+	VCR string `json:"vcr"`
+
+	CoalesceGets *bool `json:"coalesce_gets"`
+
+	ReadOnly *bool `json:"read_only"`
+
+	Cache *struct {
+		TTL        string            `json:"ttl"`
+		Modules    map[string]string `json:"modules"`
+		MaxEntries int               `json:"max_entries"`
+	} `json:"cache"`
+
+	ClockSkew *struct {
+		Compensate *bool  `json:"compensate"`
+		Threshold  string `json:"threshold"`
+	} `json:"clock_skew"`
+
+	Credentials *struct {
+		AppKeyFile   string `json:"app_key_file"`
+		PasswordFile string `json:"password_file"`
+		Helper       string `json:"helper"`
+		NetrcFile    string `json:"netrc_file"`
+	} `json:"credentials"`
+
+	Callers *struct {
+		Auth        string `json:"auth"`
+		TokensFile  string `json:"tokens_file"`
+		IdleTimeout string `json:"idle_timeout"`
+	} `json:"callers"`
+}
+
+type fileRateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+	MaxConcurrent     int     `json:"max_concurrent"`
+}
+
+func (f *fileRateLimit) apply(limit *RateLimit) {
+	if f == nil {
+		return
+	}
+	limit.RequestsPerSecond = f.RequestsPerSecond
+	limit.Burst = f.Burst
+	limit.MaxConcurrent = f.MaxConcurrent
+}
+
+// DefaultClientOptions returns the transport settings used when nothing is configured
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		RequestTimeout:      60 * time.Second,
+		DialTimeout:         10 * time.Second,
+		MaxIdleConnsPerHost: 10,
+		Retry:               DefaultRetryPolicy(),
+		Breaker:             DefaultBreakerConfig(),
+		Cache:               DefaultCacheConfig(),
+		CoalesceGets:        true,
+		ClockSkew:           DefaultClockSkewConfig(),
+		Callers:             DefaultCallerConfig(),
+	}
+}
+
+// LoadClientOptions resolves transport options from ZENTAO_CONFIG_FILE (if set)
+// and the ZENTAO_HTTP_* style environment variables, which take precedence.
+func LoadClientOptions() (ClientOptions, error) {
+	opts := DefaultClientOptions()
+
+	if path := os.Getenv("ZENTAO_CONFIG_FILE"); path != "" {
+		if err := opts.loadFile(path); err != nil {
+			return opts, err
+		}
+	}
+
+	if err := opts.loadEnv(); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func (o *ClientOptions) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var file fileOptions
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if file.RequestTimeout != "" {
+		if o.RequestTimeout, err = time.ParseDuration(file.RequestTimeout); err != nil {
+			return fmt.Errorf("invalid request_timeout in %s: %w", path, err)
+		}
+	}
+	if file.DialTimeout != "" {
+		if o.DialTimeout, err = time.ParseDuration(file.DialTimeout); err != nil {
+			return fmt.Errorf("invalid dial_timeout in %s: %w", path, err)
+		}
+	}
+	if file.ProxyURL != "" {
+		o.ProxyURL = file.ProxyURL
+	}
+	if file.CAFile != "" {
+		o.CAFile = file.CAFile
+	}
+	if file.ClientCertFile != "" {
+		o.ClientCertFile = file.ClientCertFile
+	}
+	if file.ClientKeyFile != "" {
+		o.ClientKeyFile = file.ClientKeyFile
+	}
+	if file.InsecureSkipVerify != nil {
+		o.InsecureSkipVerify = *file.InsecureSkipVerify
+	}
+	if file.MaxIdleConnsPerHost > 0 {
+		o.MaxIdleConnsPerHost = file.MaxIdleConnsPerHost
+	}
+
+	if retry := file.Retry; retry != nil {
+		if retry.MaxRetries != nil {
+			o.Retry.MaxRetries = *retry.MaxRetries
+		}
+		if retry.InitialBackoff != "" {
+			if o.Retry.InitialBackoff, err = time.ParseDuration(retry.InitialBackoff); err != nil {
+				return fmt.Errorf("invalid retry.initial_backoff in %s: %w", path, err)
+			}
+		}
+		if retry.MaxBackoff != "" {
+			if o.Retry.MaxBackoff, err = time.ParseDuration(retry.MaxBackoff); err != nil {
+				return fmt.Errorf("invalid retry.max_backoff in %s: %w", path, err)
+			}
+		}
+		if retry.Multiplier > 0 {
+			o.Retry.Multiplier = retry.Multiplier
+		}
+		if retry.Jitter != nil {
+			o.Retry.Jitter = *retry.Jitter
+		}
+		if retry.MaxElapsed != "" {
+			if o.Retry.MaxElapsed, err = time.ParseDuration(retry.MaxElapsed); err != nil {
+				return fmt.Errorf("invalid retry.max_elapsed in %s: %w", path, err)
+			}
+		}
+	}
+
+	if file.RateLimit != nil {
+		file.RateLimit.Read.apply(&o.RateLimits.Read)
+		file.RateLimit.Write.apply(&o.RateLimits.Write)
+	}
+
+	if breaker := file.CircuitBreaker; breaker != nil {
+		if breaker.FailureThreshold != nil {
+			o.Breaker.FailureThreshold = *breaker.FailureThreshold
+		}
+		if breaker.Cooldown != "" {
+			if o.Breaker.Cooldown, err = time.ParseDuration(breaker.Cooldown); err != nil {
+				return fmt.Errorf("invalid circuit_breaker.cooldown in %s: %w", path, err)
+			}
+		}
+	}
+
+	if session := file.Session; session != nil {
+		if session.Account != "" {
+			o.Session.Account = session.Account
+		}
+		if session.Password != "" {
+			o.Session.Password = session.Password
+		}
+		if session.File != "" {
+			o.Session.File = session.File
+		}
+	}
+
+	for name, instance := range file.Instances {
+		o.setInstance(InstanceProfile{
+			Name:        name,
+			BaseURL:     instance.BaseURL,
+			AuthMethod:  instance.AuthMethod,
+			AppCode:     instance.AppCode,
+			AppKey:      instance.AppKey,
+			Account:     instance.Account,
+			Password:    instance.Password,
+			APIMode:     instance.APIMode,
+			RESTURL:     instance.RESTURL,
+			SessionFile: instance.SessionFile,
+
+			AppKeyFile:   instance.AppKeyFile,
+			PasswordFile: instance.PasswordFile,
+		})
+	}
+	if file.DefaultInstance != "" {
+		o.DefaultInstance = file.DefaultInstance
+	}
+	if file.VCR != "" {
+		if o.VCR, err = ParseVCR(file.VCR); err != nil {
+			return fmt.Errorf("invalid vcr in %s: %w", path, err)
+		}
+	}
+
+	if file.CoalesceGets != nil {
+		o.CoalesceGets = *file.CoalesceGets
+	}
+	if file.ReadOnly != nil {
+		o.ReadOnly = *file.ReadOnly
+	}
+	if cache := file.Cache; cache != nil {
+		if cache.TTL != "" {
+			if o.Cache.TTL, err = time.ParseDuration(cache.TTL); err != nil {
+				return fmt.Errorf("invalid cache.ttl in %s: %w", path, err)
+			}
+		}
+		for module, ttl := range cache.Modules {
+			if err := o.Cache.setModuleTTL(module, ttl); err != nil {
+				return fmt.Errorf("invalid cache.modules.%s in %s: %w", module, path, err)
+			}
+		}
+		if cache.MaxEntries > 0 {
+			o.Cache.MaxEntries = cache.MaxEntries
+		}
+	}
+
+	if skew := file.ClockSkew; skew != nil {
+		if skew.Compensate != nil {
+			o.ClockSkew.Disabled = !*skew.Compensate
+		}
+		if skew.Threshold != "" {
+			if o.ClockSkew.Threshold, err = time.ParseDuration(skew.Threshold); err != nil {
+				return fmt.Errorf("invalid clock_skew.threshold in %s: %w", path, err)
+			}
+		}
+	}
+
+	if creds := file.Credentials; creds != nil {
+		if creds.AppKeyFile != "" {
+			o.Credentials.AppKeyFile = creds.AppKeyFile
+		}
+		if creds.PasswordFile != "" {
+			o.Credentials.PasswordFile = creds.PasswordFile
+		}
+		if creds.Helper != "" {
+			o.Credentials.Helper = creds.Helper
+		}
+		if creds.NetrcFile != "" {
+			o.Credentials.NetrcFile = creds.NetrcFile
+		}
+	}
+
+	if callers := file.Callers; callers != nil {
+		if callers.Auth != "" {
+			if err := o.Callers.setAuth(callers.Auth); err != nil {
+				return fmt.Errorf("invalid callers.auth in %s: %w", path, err)
+			}
+		}
+		if callers.TokensFile != "" {
+			o.Callers.TokensFile = callers.TokensFile
+		}
+		if callers.IdleTimeout != "" {
+			if o.Callers.IdleTimeout, err = time.ParseDuration(callers.IdleTimeout); err != nil {
+				return fmt.Errorf("invalid callers.idle_timeout in %s: %w", path, err)
+			}
+		}
+	}
+
+	logger.Debug("client", "Loaded transport options from config file", map[string]interface{}{
+		"path": path,
+	})
+
+	return nil
+}
+
+func (o *ClientOptions) loadEnv() error {
+	var err error
+
+	if v := os.Getenv("ZENTAO_HTTP_TIMEOUT"); v != "" {
+		if o.RequestTimeout, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_HTTP_TIMEOUT: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_DIAL_TIMEOUT"); v != "" {
+		if o.DialTimeout, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_DIAL_TIMEOUT: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_PROXY_URL"); v != "" {
+		o.ProxyURL = v
+	}
+	if v := os.Getenv("ZENTAO_CA_FILE"); v != "" {
+		o.CAFile = v
+	}
+	if v := os.Getenv("ZENTAO_CLIENT_CERT"); v != "" {
+		o.ClientCertFile = v
+	}
+	if v := os.Getenv("ZENTAO_CLIENT_KEY"); v != "" {
+		o.ClientKeyFile = v
+	}
+	if v := os.Getenv("ZENTAO_INSECURE_SKIP_VERIFY"); v != "" {
+		if o.InsecureSkipVerify, err = strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_INSECURE_SKIP_VERIFY: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_MAX_IDLE_CONNS_PER_HOST"); v != "" {
+		if o.MaxIdleConnsPerHost, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_MAX_IDLE_CONNS_PER_HOST: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ZENTAO_RETRY_MAX"); v != "" {
+		if o.Retry.MaxRetries, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_RETRY_MAX: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_RETRY_INITIAL_BACKOFF"); v != "" {
+		if o.Retry.InitialBackoff, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_RETRY_INITIAL_BACKOFF: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_RETRY_MAX_BACKOFF"); v != "" {
+		if o.Retry.MaxBackoff, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_RETRY_MAX_BACKOFF: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_RETRY_MAX_ELAPSED"); v != "" {
+		if o.Retry.MaxElapsed, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_RETRY_MAX_ELAPSED: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ZENTAO_BREAKER_THRESHOLD"); v != "" {
+		if o.Breaker.FailureThreshold, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_BREAKER_THRESHOLD: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_BREAKER_COOLDOWN"); v != "" {
+		if o.Breaker.Cooldown, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_BREAKER_COOLDOWN: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ZENTAO_ACCOUNT"); v != "" {
+		o.Session.Account = v
+	}
+	if v := os.Getenv("ZENTAO_PASSWORD"); v != "" {
+		o.Session.Password = v
+	}
+	if v := os.Getenv("ZENTAO_SESSION_FILE"); v != "" {
+		o.Session.File = v
+	}
+	if v := os.Getenv("ZENTAO_APP_KEY_FILE"); v != "" {
+		o.Credentials.AppKeyFile = v
+	}
+	if v := os.Getenv("ZENTAO_PASSWORD_FILE"); v != "" {
+		o.Credentials.PasswordFile = v
+	}
+	if v := os.Getenv("ZENTAO_CREDENTIAL_HELPER"); v != "" {
+		o.Credentials.Helper = v
+	}
+	if v := os.Getenv("ZENTAO_NETRC_FILE"); v != "" {
+		o.Credentials.NetrcFile = v
+	}
+
+	o.loadInstancesEnv()
+
+	if v := os.Getenv("ZENTAO_VCR"); v != "" {
+		if o.VCR, err = ParseVCR(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_VCR: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ZENTAO_COALESCE_GETS"); v != "" {
+		if o.CoalesceGets, err = strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_COALESCE_GETS: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_READ_ONLY"); v != "" {
+		if o.ReadOnly, err = strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_READ_ONLY: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_CACHE_TTL"); v != "" {
+		if o.Cache.TTL, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_CACHE_TTL: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_CACHE_MODULE_TTLS"); v != "" {
+		// user=10m,tree=10m,bug=5s
+		for _, pair := range strings.Split(v, ",") {
+			module, ttl, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			if err := o.Cache.setModuleTTL(strings.TrimSpace(module), strings.TrimSpace(ttl)); err != nil {
+				return fmt.Errorf("invalid ZENTAO_CACHE_MODULE_TTLS entry %q: %w", pair, err)
+			}
+		}
+	}
+	if v := os.Getenv("ZENTAO_CACHE_MAX_ENTRIES"); v != "" {
+		if o.Cache.MaxEntries, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_CACHE_MAX_ENTRIES: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ZENTAO_CLOCK_SKEW_COMPENSATION"); v != "" {
+		compensate, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid ZENTAO_CLOCK_SKEW_COMPENSATION: %w", err)
+		}
+		o.ClockSkew.Disabled = !compensate
+	}
+	if v := os.Getenv("ZENTAO_CLOCK_SKEW_THRESHOLD"); v != "" {
+		if o.ClockSkew.Threshold, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_CLOCK_SKEW_THRESHOLD: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ZENTAO_CALLER_AUTH"); v != "" {
+		if err := o.Callers.setAuth(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_CALLER_AUTH: %w", err)
+		}
+	}
+	if v := os.Getenv("ZENTAO_CALLER_TOKENS_FILE"); v != "" {
+		o.Callers.TokensFile = v
+	}
+	if v := os.Getenv("ZENTAO_CALLER_IDLE_TIMEOUT"); v != "" {
+		if o.Callers.IdleTimeout, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid ZENTAO_CALLER_IDLE_TIMEOUT: %w", err)
+		}
+	}
+
+	if err := loadRateLimitEnv("ZENTAO_", &o.RateLimits.Read); err != nil {
+		return err
+	}
+	if err := loadRateLimitEnv("ZENTAO_WRITE_", &o.RateLimits.Write); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// loadRateLimitEnv reads <prefix>RATE_LIMIT_RPS, <prefix>RATE_LIMIT_BURST and <prefix>MAX_CONCURRENT
+func loadRateLimitEnv(prefix string, limit *RateLimit) error {
+	var err error
+
+	if v := os.Getenv(prefix + "RATE_LIMIT_RPS"); v != "" {
+		if limit.RequestsPerSecond, err = strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("invalid %sRATE_LIMIT_RPS: %w", prefix, err)
+		}
+	}
+	if v := os.Getenv(prefix + "RATE_LIMIT_BURST"); v != "" {
+		if limit.Burst, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid %sRATE_LIMIT_BURST: %w", prefix, err)
+		}
+	}
+	if v := os.Getenv(prefix + "MAX_CONCURRENT"); v != "" {
+		if limit.MaxConcurrent, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid %sMAX_CONCURRENT: %w", prefix, err)
+		}
+	}
+
+	return nil
+}
+
+// NewHTTPClient builds an *http.Client honouring the options
+func (o ClientOptions) NewHTTPClient() (*http.Client, error) {
+	tlsConfig, err := o.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if o.ProxyURL != "" {
+		proxyURL, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", o.ProxyURL, err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   o.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.DialContext = dialer.DialContext
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   o.RequestTimeout,
+	}, nil
+}
+
+func (o ClientOptions) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", o.CAFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", o.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if o.ClientCertFile != "" || o.ClientKeyFile != "" {
+		if o.ClientCertFile == "" || o.ClientKeyFile == "" {
+			return nil, fmt.Errorf("both client certificate and key must be set for mTLS")
+		}
+		cert, err := tls.LoadX509KeyPair(o.ClientCertFile, o.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// newDefaultHTTPClient is used by the constructors; the defaults cannot fail to build
+func newDefaultHTTPClient() *http.Client {
+	httpClient, err := DefaultClientOptions().NewHTTPClient()
+	if err != nil {
+		return &http.Client{}
+	}
+	return httpClient
+}
+
+// ApplyOptions replaces the client's HTTP transport with one built from opts
+func (c *ZenTaoClient) ApplyOptions(opts ClientOptions) error {
+	httpClient, err := opts.NewHTTPClient()
+	if err != nil {
+		logger.Error("client", "Failed to build HTTP transport", err, nil)
+		return err
+	}
+
+	c.Client = httpClient
+	if err := c.UseVCR(opts.VCR); err != nil {
+		return err
+	}
+	c.SetRetryPolicy(opts.Retry)
+	c.SetRateLimits(opts.RateLimits)
+	c.SetBreakerConfig(opts.Breaker)
+	c.SetCacheConfig(opts.Cache)
+	c.SetCoalescing(opts.CoalesceGets)
+	c.SetReadOnly(opts.ReadOnly)
+	c.SetClockSkewConfig(opts.ClockSkew)
+	if opts.Session != (SessionOptions{}) {
+		c.SetSessionOptions(opts.Session)
+	}
+
+	logger.Info("client", "Applied HTTP transport options", map[string]interface{}{
+		"request_timeout":         opts.RequestTimeout.String(),
+		"dial_timeout":            opts.DialTimeout.String(),
+		"has_proxy":               opts.ProxyURL != "",
+		"has_ca_file":             opts.CAFile != "",
+		"has_client_cert":         opts.ClientCertFile != "",
+		"insecure_skip_verify":    opts.InsecureSkipVerify,
+		"max_idle_conns_per_host": opts.MaxIdleConnsPerHost,
+		"retry_max":               opts.Retry.MaxRetries,
+		"retry_max_elapsed":       opts.Retry.MaxElapsed.String(),
+		"vcr_mode":                opts.VCR.Mode.String(),
+		"cache_ttl":               opts.Cache.TTL.String(),
+		"coalesce_gets":           opts.CoalesceGets,
+		"read_only":               opts.ReadOnly,
+		"clock_skew_compensation": !opts.ClockSkew.Disabled,
+	})
+
+	if opts.InsecureSkipVerify {
+		logger.Warn("client", "TLS certificate verification is disabled", nil)
+	}
+
+	return nil
+}
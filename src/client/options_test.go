@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDefaultClientOptions(t *testing.T) {
+	opts := DefaultClientOptions()
+
+	if opts.RequestTimeout <= 0 {
+		t.Error("Expected a default request timeout")
+	}
+	if opts.DialTimeout <= 0 {
+		t.Error("Expected a default dial timeout")
+	}
+	if opts.MaxIdleConnsPerHost <= 0 {
+		t.Error("Expected a default idle connection limit")
+	}
+	if !opts.CoalesceGets || opts.Cache.TTL != 0 {
+		t.Error("Expected GET coalescing on and the response cache off by default")
+	}
+
+	client := NewZenTaoClient("http://test.com")
+	if client.Client.Timeout != opts.RequestTimeout {
+		t.Errorf("Expected constructor to use default timeout %v, got %v", opts.RequestTimeout, client.Client.Timeout)
+	}
+}
+
+func TestLoadClientOptionsFromFileAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "zentao.json")
+	config := `{"request_timeout": "45s", "dial_timeout": "3s", "proxy_url": "http://file-proxy:3128", "max_idle_conns_per_host": 4, "circuit_breaker": {"failure_threshold": 8, "cooldown": "1m"}, "cache": {"ttl": "1m", "modules": {"bug": "5s"}}}`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv("ZENTAO_CONFIG_FILE", configPath)
+	t.Setenv("ZENTAO_PROXY_URL", "http://env-proxy:3128")
+	t.Setenv("ZENTAO_INSECURE_SKIP_VERIFY", "true")
+	t.Setenv("ZENTAO_BREAKER_COOLDOWN", "10s")
+	t.Setenv("ZENTAO_VCR", "replay:/tmp/cassette.json")
+	t.Setenv("ZENTAO_CACHE_MODULE_TTLS", "user=1h, tree=0s")
+
+	opts, err := LoadClientOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if opts.RequestTimeout != 45*time.Second {
+		t.Errorf("Expected request timeout from file, got %v", opts.RequestTimeout)
+	}
+	if opts.DialTimeout != 3*time.Second {
+		t.Errorf("Expected dial timeout from file, got %v", opts.DialTimeout)
+	}
+	if opts.MaxIdleConnsPerHost != 4 {
+		t.Errorf("Expected max idle conns from file, got %d", opts.MaxIdleConnsPerHost)
+	}
+	if opts.ProxyURL != "http://env-proxy:3128" {
+		t.Errorf("Expected env proxy to override file, got %s", opts.ProxyURL)
+	}
+	if !opts.InsecureSkipVerify {
+		t.Error("Expected InsecureSkipVerify from env")
+	}
+	if opts.Breaker.FailureThreshold != 8 || opts.Breaker.Cooldown != 10*time.Second {
+		t.Errorf("Expected breaker threshold from file and cooldown from env, got %+v", opts.Breaker)
+	}
+	if opts.VCR != (VCRConfig{Mode: VCRReplay, Path: "/tmp/cassette.json"}) {
+		t.Errorf("Expected VCR replay from env, got %+v", opts.VCR)
+	}
+	if opts.Cache.TTL != time.Minute || opts.Cache.ModuleTTLs["bug"] != 5*time.Second ||
+		opts.Cache.ModuleTTLs["user"] != time.Hour || opts.Cache.ModuleTTLs["tree"] != 0 ||
+		opts.Cache.ModuleTTLs["task"] != 15*time.Second {
+		t.Errorf("Expected cache TTLs from defaults, file and env, got %+v", opts.Cache)
+	}
+}
+
+func TestLoadClientOptionsInvalidEnv(t *testing.T) {
+	t.Setenv("ZENTAO_HTTP_TIMEOUT", "soon")
+
+	if _, err := LoadClientOptions(); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+}
+
+func TestNewHTTPClientWithCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+
+	// Without the CA the self-signed server must be rejected
+	httpClient, err := DefaultClientOptions().NewHTTPClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := httpClient.Get(server.URL); err == nil {
+		t.Error("Expected TLS verification failure without custom CA")
+	}
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA: %v", err)
+	}
+
+	opts := DefaultClientOptions()
+	opts.CAFile = caPath
+	httpClient, err = opts.NewHTTPClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected request to succeed with custom CA, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHTTPClientInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ClientOptions
+	}{
+		{"Missing CA file", ClientOptions{CAFile: "/nonexistent/ca.pem"}},
+		{"Cert without key", ClientOptions{ClientCertFile: "/tmp/cert.pem"}},
+		{"Bad proxy URL", ClientOptions{ProxyURL: "://bad"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.opts.NewHTTPClient(); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
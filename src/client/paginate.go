@@ -0,0 +1,276 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+const (
+	// DefaultPageSize is the recPerPage used when walking every page
+	DefaultPageSize = 100
+
+	// MaxPages stops a walk against a server that never reports the last page
+	MaxPages = 200
+)
+
+// Pager is ZenTao's paging block. Legacy views send it as "pager" with
+// recTotal/recPerPage/pageID; the REST API sends total/limit/page at the top.
+type Pager struct {
+	RecTotal   int `json:"recTotal"`
+	RecPerPage int `json:"recPerPage"`
+	PageID     int `json:"pageID"`
+}
+
+// PageTotal returns the number of pages, or 0 when the pager is unknown
+func (p Pager) PageTotal() int {
+	if p.RecPerPage <= 0 {
+		return 0
+	}
+	return (p.RecTotal + p.RecPerPage - 1) / p.RecPerPage
+}
+
+// Page is one decoded page of a list view
+type Page struct {
+	Key      string            // payload field holding the list, e.g. "bugs"
+	Items    []json.RawMessage // records on this page, in server order
+	Pager    Pager
+	HasPager bool // false when the view sent no paging information
+}
+
+// PageFetcher loads page pageID (1-based) with perPage records per page
+type PageFetcher func(ctx context.Context, pageID, perPage int) (Page, error)
+
+// ParsePage finds the record list and the pager in a decoded payload (see
+// UnwrapEnvelope). Lists are either arrays or objects keyed by record ID.
+func ParsePage(payload []byte) (Page, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		var items []json.RawMessage
+		if json.Unmarshal(payload, &items) == nil {
+			return Page{Items: items}, nil
+		}
+		return Page{}, fmt.Errorf("failed to parse list response: %w", err)
+	}
+
+	var page Page
+	if raw, ok := fields["pager"]; ok {
+		page.Pager, page.HasPager = parsePager(raw)
+	} else if raw, ok := fields["total"]; ok {
+		// REST API: {"page":1,"limit":20,"total":35,"bugs":[...]}
+		page.Pager.RecTotal, page.HasPager = flexInt(raw)
+		page.Pager.RecPerPage, _ = flexInt(fields["limit"])
+		page.Pager.PageID, _ = flexInt(fields["page"])
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	found := false
+	for _, key := range keys {
+		switch key {
+		case "pager", "title", "page", "limit", "total":
+			continue
+		}
+		items, ok := listItems(fields[key])
+		if !ok {
+			continue
+		}
+		// Prefer a non-empty list when a view carries several (e.g. modules and bugs)
+		if !found || (len(page.Items) == 0 && len(items) > 0) || len(items) > len(page.Items) {
+			page.Key, page.Items, found = key, items, true
+		}
+	}
+	if !found {
+		return Page{}, fmt.Errorf("no record list found in response")
+	}
+	return page, nil
+}
+
+// listItems accepts [{...}] and {"12":{...},"13":{...}}
+func listItems(raw json.RawMessage) ([]json.RawMessage, bool) {
+	var items []json.RawMessage
+	if json.Unmarshal(raw, &items) == nil {
+		for _, item := range items {
+			if len(item) == 0 || item[0] != '{' {
+				return nil, false
+			}
+		}
+		return items, true
+	}
+
+	var byID map[string]json.RawMessage
+	if json.Unmarshal(raw, &byID) != nil || len(byID) == 0 {
+		return nil, false
+	}
+	ids := make([]int, 0, len(byID))
+	for key, item := range byID {
+		id, err := strconv.Atoi(key)
+		if err != nil || len(item) == 0 || item[0] != '{' {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	items = make([]json.RawMessage, len(ids))
+	for i, id := range ids {
+		items[i] = byID[strconv.Itoa(id)]
+	}
+	return items, true
+}
+
+func parsePager(raw json.RawMessage) (Pager, bool) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) != nil {
+		return Pager{}, false
+	}
+	total, ok := flexInt(fields["recTotal"])
+	perPage, _ := flexInt(fields["recPerPage"])
+	pageID, _ := flexInt(fields["pageID"])
+	return Pager{RecTotal: total, RecPerPage: perPage, PageID: pageID}, ok
+}
+
+// flexInt reads numbers ZenTao sends either as 35 or "35"
+func flexInt(raw json.RawMessage) (int, bool) {
+	if len(raw) == 0 {
+		return 0, false
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			return 0, false
+		}
+		n = json.Number(s)
+	}
+	value, err := strconv.Atoi(n.String())
+	return value, err == nil
+}
+
+// Pages walks pages starting at 1 until the pager says there are no more, a
+// page comes back empty or short, or MaxPages is reached. Stop early by
+// breaking out of the range loop. A page the server clamped (ZenTao answers
+// pageID beyond the end with the last page) is not yielded twice.
+func Pages(ctx context.Context, perPage int, fetch PageFetcher) iter.Seq2[Page, error] {
+	if perPage <= 0 {
+		perPage = DefaultPageSize
+	}
+
+	return func(yield func(Page, error) bool) {
+		for pageID := 1; pageID <= MaxPages; pageID++ {
+			if err := ctx.Err(); err != nil {
+				yield(Page{}, err)
+				return
+			}
+
+			page, err := fetch(ctx, pageID, perPage)
+			if err != nil {
+				yield(Page{}, err)
+				return
+			}
+
+			if page.HasPager && page.Pager.PageID > 0 && page.Pager.PageID != pageID {
+				logger.Debug("client", "Server clamped the page number, stopping", map[string]interface{}{
+					"requested_page": pageID,
+					"returned_page":  page.Pager.PageID,
+				})
+				return
+			}
+			if len(page.Items) == 0 {
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+
+			if page.HasPager && page.Pager.RecPerPage > 0 {
+				if pageID >= page.Pager.PageTotal() {
+					return
+				}
+			} else if len(page.Items) < perPage {
+				return
+			}
+		}
+
+		logger.Warn("client", "Pagination stopped at the page limit", map[string]interface{}{
+			"max_pages": MaxPages,
+			"per_page":  perPage,
+		})
+	}
+}
+
+// Paginate walks every page of a list endpoint. params are added to path
+// together with the paging parameters: recPerPage/pageID for legacy views,
+// limit/page in REST mode.
+//
+//	for page, err := range c.Paginate(ctx, "/products/1/bugs", nil) {
+//		if err != nil {
+//			return err
+//		}
+//		all = append(all, page.Items...)
+//	}
+func (c *ZenTaoClient) Paginate(ctx context.Context, path string, params map[string]string) iter.Seq2[Page, error] {
+	perPageParam, pageParam := "recPerPage", "pageID"
+	if c.ForContext(ctx).useREST("GET", path) {
+		perPageParam, pageParam = "limit", "page"
+	}
+
+	perPage := DefaultPageSize
+	if n, err := strconv.Atoi(params[perPageParam]); err == nil && n > 0 {
+		perPage = n
+	}
+
+	return Pages(ctx, perPage, func(ctx context.Context, pageID, perPage int) (Page, error) {
+		query := make(map[string]string, len(params)+2)
+		for key, value := range params {
+			query[key] = value
+		}
+		query[perPageParam] = strconv.Itoa(perPage)
+		query[pageParam] = strconv.Itoa(pageID)
+
+		var payload json.RawMessage
+		if err := c.GetJSON(ctx, withQuery(path, query), &payload); err != nil {
+			return Page{}, err
+		}
+		return ParsePage(payload)
+	})
+}
+
+// withQuery appends params to path, which may already carry a query string
+func withQuery(path string, params map[string]string) string {
+	if len(params) == 0 {
+		return path
+	}
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + values.Encode()
+}
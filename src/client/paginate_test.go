@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+func TestParsePage(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		wantKey   string
+		wantItems int
+		wantPager Pager
+	}{
+		{"legacy view", `{"title":"Bugs","bugs":[{"id":1},{"id":2}],"pager":{"recTotal":"5","recPerPage":2,"pageID":"1"}}`,
+			"bugs", 2, Pager{RecTotal: 5, RecPerPage: 2, PageID: 1}},
+		{"keyed by id", `{"stories":{"12":{"id":12},"3":{"id":3}},"pager":{"recTotal":2,"recPerPage":20,"pageID":1}}`,
+			"stories", 2, Pager{RecTotal: 2, RecPerPage: 20, PageID: 1}},
+		{"rest api", `{"page":2,"limit":10,"total":15,"products":[{"id":11}]}`,
+			"products", 1, Pager{RecTotal: 15, RecPerPage: 10, PageID: 2}},
+		{"prefers filled list", `{"modules":[],"tasks":[{"id":4}]}`, "tasks", 1, Pager{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, err := ParsePage([]byte(test.payload))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if page.Key != test.wantKey || len(page.Items) != test.wantItems || page.Pager != test.wantPager {
+				t.Errorf("Got key %q, %d items, pager %+v", page.Key, len(page.Items), page.Pager)
+			}
+		})
+	}
+
+	page, _ := ParsePage([]byte(`{"stories":{"12":{"id":12},"3":{"id":3}}}`))
+	if string(page.Items[0]) != `{"id":3}` {
+		t.Errorf("Expected records keyed by ID in ID order, got %s", page.Items[0])
+	}
+
+	if _, err := ParsePage([]byte(`{"title":"Nothing here"}`)); err == nil {
+		t.Error("Expected an error for a payload without a list")
+	}
+}
+
+func TestPagesStopsAtClampedPage(t *testing.T) {
+	fetches := 0
+	fetch := func(ctx context.Context, pageID, perPage int) (Page, error) {
+		fetches++
+		// A server that ignores recTotal and keeps returning the last page
+		served := pageID
+		if served > 2 {
+			served = 2
+		}
+		return Page{Items: make([]json.RawMessage, perPage), HasPager: true, Pager: Pager{PageID: served}}, nil
+	}
+
+	pages := 0
+	for _, err := range Pages(context.Background(), 10, fetch) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		pages++
+	}
+	if pages != 2 || fetches != 3 {
+		t.Errorf("Expected 2 pages from 3 fetches, got %d pages from %d fetches", pages, fetches)
+	}
+}
+
+func TestPagesStopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	fetch := func(ctx context.Context, pageID, perPage int) (Page, error) {
+		if pageID == 2 {
+			return Page{}, boom
+		}
+		return Page{Items: make([]json.RawMessage, perPage)}, nil
+	}
+
+	var got []error
+	for _, err := range Pages(context.Background(), 5, fetch) {
+		got = append(got, err)
+	}
+	if len(got) != 2 || got[0] != nil || !errors.Is(got[1], boom) {
+		t.Errorf("Expected one page then the error, got %v", got)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+	for i := 1; i <= 7; i++ {
+		fake.Seed("bug", map[string]interface{}{"title": fmt.Sprintf("Bug %d", i), "product": 1})
+	}
+	fake.Seed("bug", map[string]interface{}{"title": "Other product", "product": 2})
+
+	opts := fake.Options()
+	client := NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, opts.AppKey)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	var ids []string
+	pages := 0
+	for page, err := range client.Paginate(context.Background(), "/products/1/bugs", map[string]string{"recPerPage": "3"}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		pages++
+		if page.Key != "bugs" || page.Pager.RecTotal != 7 {
+			t.Errorf("Unexpected page %q with pager %+v", page.Key, page.Pager)
+		}
+		for _, item := range page.Items {
+			ids = append(ids, string(item))
+		}
+	}
+	if pages != 3 || len(ids) != 7 {
+		t.Errorf("Expected 7 bugs over 3 pages, got %d bugs over %d pages", len(ids), pages)
+	}
+
+	// Breaking out of the loop stops fetching
+	fake.ResetRequests()
+	for range client.Paginate(context.Background(), "/products/1/bugs", map[string]string{"recPerPage": "3"}) {
+		break
+	}
+	if n := len(fake.Requests()); n != 1 {
+		t.Errorf("Expected a single request after break, got %d", n)
+	}
+}
+
+func TestConvertRESTPathQueryString(t *testing.T) {
+	client := NewZenTaoClient("http://zentao.local/index.php")
+	path, params := client.convertRESTPath("GET", "/products/1/bugs?recPerPage=50&pageID=2")
+	if params["recPerPage"] != "50" || params["pageID"] != "2" {
+		t.Errorf("Expected paging params from the query string, got %v", params)
+	}
+	if path != "?m=bug&f=browse" || params["product"] != "1" {
+		t.Errorf("Expected the bug browse view for product 1, got %s %v", path, params)
+	}
+}
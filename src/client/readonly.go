@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// ErrReadOnly is returned for requests that could change ZenTao while the
+// client is in read-only mode
+var ErrReadOnly = errors.New("read-only mode")
+
+// mutatingWords are the verbs of ZenTao functions that change data. Many of
+// them are reachable with GET (m=kanban&f=deleteSpace), so the HTTP method
+// alone is not enough.
+var mutatingWords = map[string]bool{
+	"activate": true, "add": true, "approve": true, "archive": true, "assign": true,
+	"audit": true, "batch": true, "bind": true, "cancel": true, "change": true,
+	"close": true, "collect": true, "communicate": true, "confirm": true,
+	"copy": true, "create": true, "crop": true, "delete": true, "deny": true,
+	"destroy": true, "disable": true, "edit": true, "enable": true, "execute": true,
+	"finalize": true, "finish": true, "fix": true, "forget": true, "hide": true,
+	"import": true, "init": true, "install": true, "link": true, "lock": true,
+	"manage": true, "merge": true, "move": true, "pause": true, "publish": true,
+	"reboot": true, "refresh": true, "remove": true, "reset": true, "resolve": true,
+	"restart": true, "restore": true, "resume": true, "review": true, "run": true,
+	"save": true, "send": true, "set": true, "sort": true,
+	"split": true, "start": true, "submit": true, "suspend": true, "sync": true,
+	"unbind": true, "unlink": true, "unlock": true, "unpublish": true, "update": true,
+	"upgrade": true, "upload": true,
+}
+
+// MutatingWord reports whether word is a verb that changes ZenTao data, such
+// as "delete" or "Edit"
+func MutatingWord(word string) bool {
+	return mutatingWords[strings.ToLower(word)]
+}
+
+// mutatingFunction reports whether the ZenTao function name (the f= parameter)
+// changes data. The name is split into camelCase words, so "deleteSpace",
+// "ajaxSaveTemplate" and "promptPublish" all match.
+func mutatingFunction(name string) bool {
+	var word strings.Builder
+	check := func() bool {
+		mutating := MutatingWord(word.String())
+		word.Reset()
+		return mutating
+	}
+	for _, r := range name {
+		if unicode.IsUpper(r) && word.Len() > 0 && check() {
+			return true
+		}
+		word.WriteRune(r)
+	}
+	return check()
+}
+
+// readPostFunctions are read views outside cacheableFunctions that take their
+// filters as a POST form
+var readPostFunctions = map[string]bool{
+	"execution.cfd":    true,
+	"ai.roleTemplates": true,
+}
+
+// readFunction reports whether the legacy function only reads data, even
+// when called with POST (e.g. bug.report with chart filters)
+func readFunction(module, function string) bool {
+	if function == "" || mutatingFunction(function) {
+		return false
+	}
+	return cacheRequest{module: module, function: function}.cacheable() || readPostFunctions[module+"."+function]
+}
+
+// checkReadOnly rejects requests that could change ZenTao: GETs of mutating
+// legacy functions, POSTs of anything but read functions, and every PUT and
+// DELETE
+func (c *ZenTaoClient) checkReadOnly(method, path string) error {
+	if !c.readOnly.Load() {
+		return nil
+	}
+
+	_, rawQuery, _ := strings.Cut(path, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	module, function := query.Get("m"), query.Get("f")
+
+	var reason string
+	switch {
+	case function != "" && mutatingFunction(function):
+		reason = fmt.Sprintf("%s.%s changes data", module, function)
+	case method == http.MethodGet || method == http.MethodHead:
+		// reads
+	case method == http.MethodPost && readFunction(module, function):
+		// read views that take their filters as a form
+	default:
+		reason = method + " requests are not allowed"
+	}
+	if reason == "" {
+		return nil
+	}
+
+	logger.Warn("client", "Blocked request in read-only mode", map[string]interface{}{
+		"method": method,
+		"path":   path,
+		"reason": reason,
+	})
+	return fmt.Errorf("%w: %s", ErrReadOnly, reason)
+}
+
+// SetReadOnly makes the client refuse every request that could change ZenTao
+func (c *ZenTaoClient) SetReadOnly(readOnly bool) {
+	c.readOnly.Store(readOnly)
+}
+
+// ReadOnly reports whether the client is in read-only mode
+func (c *ZenTaoClient) ReadOnly() bool {
+	return c.readOnly.Load()
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMutatingFunction(t *testing.T) {
+	for name, want := range map[string]bool{
+		"browse":           false,
+		"view":             false,
+		"ajaxGetDropMenu":  false,
+		"export":           false,
+		"delete":           true,
+		"deleteSpace":      true,
+		"ajaxSaveTemplate": true,
+		"batchEdit":        true,
+		"promptPublish":    true,
+		"linkStory":        true,
+	} {
+		if got := mutatingFunction(name); got != want {
+			t.Errorf("mutatingFunction(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"status":"success","data":"{}"}`))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetReadOnly(true)
+
+	if _, err := client.Get("/index.php?m=bug&f=browse&t=json&productID=1"); err != nil {
+		t.Fatalf("Expected reads to pass, got %v", err)
+	}
+	if _, err := client.Post("/index.php?m=bug&f=report&t=json&productID=1", map[string]string{"chartType": "pie"}); err != nil {
+		t.Fatalf("Expected POSTs of read views to pass, got %v", err)
+	}
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/index.php?m=kanban&f=deleteSpace&t=json&spaceID=1"},
+		{http.MethodPost, "/index.php?m=bug&f=batchEdit&t=json"},
+		{http.MethodPost, "/products"},
+		{http.MethodPut, "/products/1"},
+		{http.MethodDelete, "/products/1"},
+	} {
+		if _, err := client.DoRequest(tc.method, tc.path, nil, nil); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s %s: expected ErrReadOnly, got %v", tc.method, tc.path, err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected only the reads to reach the server, got %d requests", calls.Load())
+	}
+
+	client.SetReadOnly(false)
+	if _, err := client.Post("/index.php?m=bug&f=create&t=json", map[string]string{}); errors.Is(err, ErrReadOnly) {
+		t.Error("Expected writes to pass once read-only mode is off")
+	}
+}
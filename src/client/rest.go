@@ -0,0 +1,363 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// APIMode selects which ZenTao backend a client talks to
+type APIMode int
+
+const (
+	// APIModeLegacy converts every path to ?m=module&f=function web routes
+	APIModeLegacy APIMode = iota
+	// APIModeREST sends documented endpoints to api.php/v1 and falls back to legacy routes otherwise
+	APIModeREST
+)
+
+func (m APIMode) String() string {
+	if m == APIModeREST {
+		return "rest"
+	}
+	return "legacy"
+}
+
+// ParseAPIMode parses the ZENTAO_API_MODE value; empty means legacy
+func ParseAPIMode(value string) (APIMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "legacy":
+		return APIModeLegacy, nil
+	case "rest":
+		return APIModeREST, nil
+	default:
+		return APIModeLegacy, fmt.Errorf("unknown API mode %q (expected rest or legacy)", value)
+	}
+}
+
+// restRoutes lists the endpoints documented for the RESTful API v1 (see api_doc.txt).
+// ":id" matches a single path segment.
+var restRoutes = []string{
+	"POST /tokens",
+	"GET /user",
+	"GET /users",
+	"POST /users",
+	"GET /users/:id",
+	"PUT /users/:id",
+	"DELETE /users/:id",
+	"GET /programs",
+	"POST /programs",
+	"GET /programs/:id",
+	"PUT /programs/:id",
+	"DELETE /programs/:id",
+	"GET /products",
+	"POST /products",
+	"GET /products/:id",
+	"PUT /product/:id",
+	"DELETE /product/:id",
+	"GET /products/:id/stories",
+	"GET /products/:id/bugs",
+	"POST /products/:id/bugs",
+	"GET /products/:id/testcases",
+	"POST /products/:id/testcases",
+	"GET /products/:id/plans",
+	"POST /products/:id/plans",
+	"GET /products/:id/releases",
+	"POST /products/:id/linkBugs",
+	"GET /projects",
+	"POST /projects",
+	"GET /projects/:id",
+	"PUT /projects/:id",
+	"DELETE /projects/:id",
+	"GET /projects/:id/executions",
+	"POST /projects/:id/executions",
+	"GET /projects/:id/stories",
+	"GET /projects/:id/builds",
+	"POST /projects/:id/builds",
+	"GET /projects/:id/testtasks",
+	"GET /projects/:id/releases",
+	"GET /executions/:id",
+	"PUT /executions/:id",
+	"DELETE /executions/:id",
+	"GET /executions/:id/stories",
+	"GET /executions/:id/tasks",
+	"POST /executions/:id/tasks",
+	"GET /executions/:id/builds",
+	"POST /stories",
+	"GET /stories/:id",
+	"PUT /stories/:id",
+	"DELETE /stories/:id",
+	"POST /stories/:id/change",
+	"GET /tasks/:id",
+	"PUT /tasks/:id",
+	"DELETE /tasks/:id",
+	"GET /bugs/:id",
+	"PUT /bugs/:id",
+	"DELETE /bugs/:id",
+	"GET /testcases/:id",
+	"PUT /testcases/:id",
+	"DELETE /testcases/:id",
+	"GET /productplans/:id",
+	"PUT /productplans/:id",
+	"DELETE /productsplan/:id",
+	"POST /productplans/:id/linkstories",
+	"POST /productplans/:id/unlinkstories",
+	"POST /productplans/:id/unlinkbugs",
+	"GET /builds/:id",
+	"PUT /builds/:id",
+	"DELETE /builds/:id",
+	"GET /testtasks",
+	"GET /testtasks/:id",
+	"GET /feedbacks",
+	"POST /feedbacks",
+	"GET /feedbacks/:id",
+	"PUT /feedbacks/:id",
+	"DELETE /feedbacks/:id",
+	"POST /feedbacks/:id/assign",
+	"POST /feedbacks/:id/close",
+	"GET /tickets",
+	"POST /tickets",
+	"GET /tickets/:id",
+	"PUT /tickets/:id",
+	"DELETE /tickets/:id",
+}
+
+// isRESTRoute reports whether method+path (query string ignored) is a documented REST endpoint
+func isRESTRoute(method, path string) bool {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, route := range restRoutes {
+		routeMethod, routePath, _ := strings.Cut(route, " ")
+		if routeMethod != method {
+			continue
+		}
+		routeSegments := strings.Split(strings.Trim(routePath, "/"), "/")
+		if len(routeSegments) != len(segments) {
+			continue
+		}
+
+		matched := true
+		for i, seg := range routeSegments {
+			if seg == ":id" {
+				if segments[i] == "" {
+					matched = false
+					break
+				}
+				continue
+			}
+			if !strings.EqualFold(seg, segments[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// deriveRESTBaseURL turns a legacy entry point such as http://host/zentao/index.php
+// into the matching REST root http://host/zentao/api.php/v1
+func deriveRESTBaseURL(baseURL string) string {
+	base := strings.TrimRight(baseURL, "/")
+	if strings.HasSuffix(base, ".php") {
+		if i := strings.LastIndex(base, "/"); i >= 0 {
+			base = base[:i]
+		}
+	}
+	return base + "/api.php/v1"
+}
+
+// SetAPIMode switches between the legacy web routes and the RESTful API v1.
+// restBaseURL may be empty, in which case it is derived from BaseURL.
+func (c *ZenTaoClient) SetAPIMode(mode APIMode, restBaseURL string) {
+	if restBaseURL == "" {
+		restBaseURL = deriveRESTBaseURL(c.BaseURL)
+	}
+
+	c.restMutex.Lock()
+	c.apiMode = mode
+	c.restBaseURL = strings.TrimRight(restBaseURL, "/")
+	c.restMutex.Unlock()
+
+	logger.Info("client", "API mode configured", map[string]interface{}{
+		"api_mode":      mode.String(),
+		"rest_base_url": restBaseURL,
+	})
+}
+
+// GetAPIMode returns the backend currently used for documented endpoints
+func (c *ZenTaoClient) GetAPIMode() APIMode {
+	c.restMutex.Lock()
+	defer c.restMutex.Unlock()
+	return c.apiMode
+}
+
+// SetRESTCredentials sets the account used to obtain a token from POST /tokens
+func (c *ZenTaoClient) SetRESTCredentials(account, password string) {
+	c.restMutex.Lock()
+	defer c.restMutex.Unlock()
+
+	c.restAccount = account
+	c.restPassword = password
+	c.restToken = ""
+
+	logger.Info("client", "REST API credentials set", map[string]interface{}{
+		"account":      account,
+		"has_password": password != "",
+	})
+}
+
+// useREST reports whether the request should go to api.php/v1
+func (c *ZenTaoClient) useREST(method, path string) bool {
+	if c.GetAPIMode() != APIModeREST {
+		return false
+	}
+	return isRESTRoute(method, path)
+}
+
+// restURL builds the api.php/v1 URL for a REST path
+func (c *ZenTaoClient) restURL(path string) string {
+	c.restMutex.Lock()
+	base := c.restBaseURL
+	c.restMutex.Unlock()
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return base + path
+}
+
+// getRESTToken returns the cached token, logging in via POST /tokens when
+// needed. Concurrent callers share one fetch; restMutex is not held during it,
+// so requests that do not need a token are never stalled by a slow login.
+func (c *ZenTaoClient) getRESTToken(ctx context.Context) (string, error) {
+	c.restMutex.Lock()
+	token := c.restToken
+	c.restMutex.Unlock()
+	if token != "" {
+		return token, nil
+	}
+
+	c.tokenFetch.Lock()
+	defer c.tokenFetch.Unlock()
+
+	// Another request may have fetched a token while we were waiting
+	c.restMutex.Lock()
+	if c.restBaseURL == "" {
+		c.restBaseURL = deriveRESTBaseURL(c.BaseURL)
+	}
+	token, restBaseURL, account, password := c.restToken, c.restBaseURL, c.restAccount, c.restPassword
+	c.restMutex.Unlock()
+	if token != "" {
+		return token, nil
+	}
+
+	if account == "" || password == "" {
+		if c.authMethod == AuthToken {
+			return "", fmt.Errorf("token authentication requires ZENTAO_ACCOUNT and ZENTAO_PASSWORD")
+		}
+		return "", fmt.Errorf("REST API mode requires ZENTAO_ACCOUNT and ZENTAO_PASSWORD")
+	}
+
+	token, err := c.fetchRESTToken(ctx, restBaseURL, account, password)
+	if err != nil {
+		return "", err
+	}
+
+	c.restMutex.Lock()
+	c.restToken = token
+	c.restMutex.Unlock()
+	return token, nil
+}
+
+// invalidateRESTToken drops the cached token so the next request logs in again
+func (c *ZenTaoClient) invalidateRESTToken() {
+	c.restMutex.Lock()
+	c.restToken = ""
+	c.restMutex.Unlock()
+
+	logger.Info("client", "REST token invalidated", nil)
+}
+
+// fetchRESTToken exchanges account/password for a token. It talks to the
+// server directly so it never recurses through the retry machinery.
+func (c *ZenTaoClient) fetchRESTToken(ctx context.Context, restBaseURL, account, password string) (string, error) {
+	payload, err := json.Marshal(map[string]string{
+		"account":  account,
+		"password": password,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, restBaseURL+"/tokens", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	logger.Debug("client", "Requesting REST API token", map[string]interface{}{
+		"account": account,
+	})
+
+	// Network failures are *TransportError like any other request, so the
+	// circuit breaker and retry policy treat a failed token fetch as ZenTao
+	// being unreachable
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return "", &TransportError{Method: http.MethodPost, Endpoint: "/tokens", Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", &TransportError{Method: http.MethodPost, Endpoint: "/tokens", Err: fmt.Errorf("failed to read response: %w", err)}
+	}
+
+	if apiErr := classifyResponse(http.MethodPost, "/tokens", resp.StatusCode, resp.Header.Get("Content-Type"), body); apiErr != nil {
+		return "", apiErr
+	}
+
+	var tokenResp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil || tokenResp.Token == "" {
+		return "", &APIError{
+			Kind:       ErrAuthExpired,
+			StatusCode: resp.StatusCode,
+			Method:     http.MethodPost,
+			Endpoint:   "/tokens",
+			Message:    "no token in response",
+		}
+	}
+
+	logger.Info("client", "Obtained REST API token", map[string]interface{}{
+		"account":      account,
+		"token_length": len(tokenResp.Token),
+	})
+
+	return tokenResp.Token, nil
+}
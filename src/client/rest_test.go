@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseAPIMode(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected APIMode
+		wantErr  bool
+	}{
+		{"", APIModeLegacy, false},
+		{"legacy", APIModeLegacy, false},
+		{"REST", APIModeREST, false},
+		{"graphql", APIModeLegacy, true},
+	}
+
+	for _, tt := range tests {
+		mode, err := ParseAPIMode(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAPIMode(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if mode != tt.expected {
+			t.Errorf("ParseAPIMode(%q) = %v, expected %v", tt.value, mode, tt.expected)
+		}
+	}
+}
+
+func TestIsRESTRoute(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{"GET", "/products", true},
+		{"GET", "/products/12", true},
+		{"GET", "/projects/3/stories?limit=5", true},
+		{"POST", "/products/1/bugs", true},
+		{"PUT", "/bugs/9", true},
+		{"GET", "/product/12", false}, // only PUT/DELETE are documented for the singular form
+		{"POST", "/bugs/9", false},    // no such endpoint
+		{"GET", "/kanban/1/cards", false},
+		{"GET", "index.php?m=bug&f=browse", false},
+	}
+
+	for _, tt := range tests {
+		if result := isRESTRoute(tt.method, tt.path); result != tt.expected {
+			t.Errorf("isRESTRoute(%s, %s) = %v, expected %v", tt.method, tt.path, result, tt.expected)
+		}
+	}
+}
+
+func TestDeriveRESTBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"http://host/zentao/index.php": "http://host/zentao/api.php/v1",
+		"http://host/api.php":          "http://host/api.php/v1",
+		"http://host/zentao/":          "http://host/zentao/api.php/v1",
+	}
+
+	for input, expected := range tests {
+		if result := deriveRESTBaseURL(input); result != expected {
+			t.Errorf("deriveRESTBaseURL(%s) = %s, expected %s", input, result, expected)
+		}
+	}
+}
+
+func TestRESTModeRequests(t *testing.T) {
+	var tokenRequests, legacyRequests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api.php/v1/tokens":
+			atomic.AddInt32(&tokenRequests, 1)
+			var creds map[string]string
+			json.NewDecoder(r.Body).Decode(&creds)
+			if creds["account"] != "admin" || creds["password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "Unauthorized"}`))
+				return
+			}
+			w.Write([]byte(`{"token": "rest-token-1"}`))
+		case "/api.php/v1/products/7":
+			if r.Header.Get("Token") != "rest-token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "Unauthorized"}`))
+				return
+			}
+			w.Write([]byte(`{"id": 7, "name": "Widget"}`))
+		case "/index.php":
+			atomic.AddInt32(&legacyRequests, 1)
+			if r.URL.Query().Get("m") != "kanban" {
+				t.Errorf("Expected legacy kanban route, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"status": "success", "data": "{}"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRESTCredentials("admin", "secret")
+	client.SetAPIMode(APIModeREST, "")
+
+	resp, err := client.Get("/products/7")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(resp) != `{"id": 7, "name": "Widget"}` {
+		t.Errorf("Unexpected response: %s", resp)
+	}
+
+	// Second call reuses the cached token
+	if _, err := client.Get("/products/7"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&tokenRequests); n != 1 {
+		t.Errorf("Expected one token request, got %d", n)
+	}
+
+	// Undocumented endpoints fall back to legacy routes
+	if _, err := client.Get("/kanban/3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&legacyRequests); n != 1 {
+		t.Errorf("Expected one legacy request, got %d", n)
+	}
+}
+
+func TestRESTModeRefreshesTokenOn401(t *testing.T) {
+	var tokenRequests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api.php/v1/tokens":
+			n := atomic.AddInt32(&tokenRequests, 1)
+			json.NewEncoder(w).Encode(map[string]string{"token": map[int32]string{1: "stale", 2: "fresh"}[n]})
+		case "/api.php/v1/bugs/1":
+			if r.Header.Get("Token") != "fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "Unauthorized"}`))
+				return
+			}
+			w.Write([]byte(`{"id": 1}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/api.php")
+	client.SetRESTCredentials("admin", "secret")
+	client.SetAPIMode(APIModeREST, "")
+
+	if _, err := client.Get("/bugs/1"); err != nil {
+		t.Fatalf("Expected request to succeed after token refresh, got %v", err)
+	}
+	if n := atomic.LoadInt32(&tokenRequests); n != 2 {
+		t.Errorf("Expected two token requests, got %d", n)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock abstracts time so retry, rate limiting and breaker logic can be tested without sleeping
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d or until ctx is done, whichever comes first
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleepContext(ctx, d)
+}
+
+// RetryPolicy controls how transient failures (transport errors, 429, 502, 503, 504)
+// are retried. Expired credentials are handled separately and always retried once refreshed.
+type RetryPolicy struct {
+	MaxRetries     int           // retries after the first attempt, 0 disables
+	InitialBackoff time.Duration // delay before the first retry
+	MaxBackoff     time.Duration // cap for a single delay
+	Multiplier     float64       // growth factor between retries
+	Jitter         float64       // 0..1, fraction of the delay that is randomized
+	MaxElapsed     time.Duration // give up once this much time has passed, 0 means no limit
+	Clock          Clock         // nil means the wall clock
+
+	random func() float64 // overridable in tests
+}
+
+// DefaultRetryPolicy returns the policy used when nothing is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxElapsed:     30 * time.Second,
+	}
+}
+
+func (p RetryPolicy) clock() Clock {
+	if p.Clock == nil {
+		return realClock{}
+	}
+	return p.Clock
+}
+
+// backoff returns the delay before retry number n (0-based). A server supplied
+// Retry-After wins when it is longer than the computed delay.
+func (p RetryPolicy) backoff(n int, retryAfter time.Duration) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(n))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		random := p.random
+		if random == nil {
+			random = rand.Float64
+		}
+		// Spread delay over [delay*(1-jitter), delay*(1+jitter)]
+		delay = delay * (1 - p.Jitter + 2*p.Jitter*random())
+	}
+
+	result := time.Duration(delay)
+	if retryAfter > result {
+		result = retryAfter
+	}
+	return result
+}
+
+// SetRetryPolicy replaces the retry policy used for subsequent requests
+func (c *ZenTaoClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry.Store(&policy)
+}
+
+func (c *ZenTaoClient) retryPolicy() RetryPolicy {
+	if policy := c.retry.Load(); policy != nil {
+		return *policy
+	}
+	return DefaultRetryPolicy()
+}
+
+type retrySafeKey struct{}
+
+// WithRetrySafe marks requests made with the returned context as safe to replay
+// even when the HTTP method is not idempotent (e.g. a POST that only queries data).
+func WithRetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+// isReplayable reports whether a request may be sent again after a transient failure
+func isReplayable(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	safe, _ := ctx.Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+// isTransient reports whether err is worth retrying with backoff
+func isTransient(err error) bool {
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfterOf returns the server supplied Retry-After delay carried by err, if any
+func retryAfterOf(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: delta seconds and HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock advances instantly on Sleep and records every delay
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// flakyServer fails the first n requests with status, then succeeds
+func flakyServer(n int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= n {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`<html>unavailable</html>`))
+			return
+		}
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	return server, &hits
+}
+
+func testPolicy(clock Clock) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		MaxElapsed:     10 * time.Second,
+		Clock:          clock,
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 500 * time.Millisecond, Multiplier: 2}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond}
+	for n, want := range expected {
+		if got := policy.backoff(n, 0); got != want {
+			t.Errorf("backoff(%d) = %v, expected %v", n, got, want)
+		}
+	}
+
+	if got := policy.backoff(0, 3*time.Second); got != 3*time.Second {
+		t.Errorf("Expected Retry-After to win, got %v", got)
+	}
+
+	policy.Jitter = 0.5
+	policy.random = func() float64 { return 0 }
+	if got := policy.backoff(0, 0); got != 50*time.Millisecond {
+		t.Errorf("Expected lower jitter bound 50ms, got %v", got)
+	}
+	policy.random = func() float64 { return 1 }
+	if got := policy.backoff(0, 0); got != 150*time.Millisecond {
+		t.Errorf("Expected upper jitter bound 150ms, got %v", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Thu, 01 Jan 2026 00:00:30 GMT": 30 * time.Second,
+	}
+	for value, expected := range tests {
+		if got := parseRetryAfter(value, now); got != expected {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", value, got, expected)
+		}
+	}
+}
+
+func TestRetryTransientGet(t *testing.T) {
+	server, hits := flakyServer(2, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(testPolicy(clock))
+
+	if _, err := client.Get("/products"); err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 3 {
+		t.Errorf("Expected 3 requests, got %d", n)
+	}
+
+	sleeps := clock.Sleeps()
+	if len(sleeps) != 2 || sleeps[0] != 100*time.Millisecond || sleeps[1] != 200*time.Millisecond {
+		t.Errorf("Expected exponential backoff [100ms 200ms], got %v", sleeps)
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	server, _ := flakyServer(1, http.StatusTooManyRequests, "2")
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(testPolicy(clock))
+
+	if _, err := client.Get("/products"); err != nil {
+		t.Fatalf("Expected success after retry, got %v", err)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 1 || sleeps[0] != 2*time.Second {
+		t.Errorf("Expected a single 2s delay from Retry-After, got %v", sleeps)
+	}
+}
+
+func TestRetryStopsAtMaxRetries(t *testing.T) {
+	server, hits := flakyServer(100, http.StatusBadGateway, "")
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(testPolicy(clock))
+
+	_, err := client.Get("/products")
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("Expected ErrServerError, got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 4 {
+		t.Errorf("Expected 1 attempt + 3 retries, got %d", n)
+	}
+}
+
+func TestRetryStopsAtMaxElapsed(t *testing.T) {
+	server, hits := flakyServer(100, http.StatusGatewayTimeout, "")
+	defer server.Close()
+
+	clock := newFakeClock()
+	policy := testPolicy(clock)
+	policy.MaxRetries = 10
+	policy.MaxElapsed = 250 * time.Millisecond
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(policy)
+
+	if _, err := client.Get("/products"); err == nil {
+		t.Fatal("Expected error once the retry budget is exhausted")
+	}
+	// 100ms + 200ms would exceed 250ms, so only one retry fits
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("Expected 2 requests within the elapsed budget, got %d", n)
+	}
+}
+
+func TestRetrySkipsNonIdempotentPost(t *testing.T) {
+	server, hits := flakyServer(1, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewZenTaoClient(server.URL + "/index.php")
+	client.SetRetryPolicy(testPolicy(clock))
+
+	if _, err := client.Post("/products", map[string]interface{}{"name": "x"}); err == nil {
+		t.Fatal("Expected POST failure not to be replayed")
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("Expected a single POST, got %d", n)
+	}
+
+	// A POST explicitly marked safe may be replayed
+	if _, err := client.PostCtx(WithRetrySafe(context.Background()), "/products", nil); err != nil {
+		t.Fatalf("Expected marked POST to succeed, got %v", err)
+	}
+}
+
+func TestRetryTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close() // connection refused from now on
+
+	clock := newFakeClock()
+	client := NewZenTaoClient(url + "/index.php")
+	client.SetRetryPolicy(testPolicy(clock))
+
+	_, err := client.Get("/products")
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("Expected *TransportError, got %T: %v", err, err)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 3 {
+		t.Errorf("Expected 3 backoff sleeps for connection errors, got %v", sleeps)
+	}
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	server, hits := flakyServer(100, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	client := NewZenTaoClient(server.URL + "/index.php")
+	policy := testPolicy(nil)
+	policy.InitialBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	policy.MaxElapsed = 0
+	client.SetRetryPolicy(policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.GetCtx(ctx, "/products"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected backoff to be interrupted, took %v", elapsed)
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("Expected a single request, got %d", n)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// SessionOptions configures automatic login for session-based authentication
+type SessionOptions struct {
+	Account  string // ZenTao account used for lazy login and re-login
+	Password string
+	File     string // optional path where the session is persisted across restarts
+}
+
+// persistedSession is the on-disk format of the session file
+type persistedSession struct {
+	BaseURL     string    `json:"base_url"`
+	Account     string    `json:"account"`
+	SessionName string    `json:"session_name"`
+	SessionID   string    `json:"session_id"`
+	SavedAt     time.Time `json:"saved_at"`
+}
+
+// SetSessionOptions stores the credentials used to log in on demand. When a
+// session file is configured and matches this server and account, the saved
+// session is reused so a restart does not need to log in again.
+func (c *ZenTaoClient) SetSessionOptions(opts SessionOptions) {
+	c.sessionMutex.Lock()
+	c.sessionAccount = opts.Account
+	c.sessionPassword = opts.Password
+	c.sessionFile = opts.File
+	c.sessionMutex.Unlock()
+
+	logger.Info("client", "Session login configured", map[string]interface{}{
+		"account":      opts.Account,
+		"has_password": opts.Password != "",
+		"session_file": opts.File,
+	})
+
+	if opts.File != "" {
+		c.loadSessionFile(opts.File, opts.Account)
+	}
+}
+
+// LoginSessionCtx obtains a fresh session and logs in with account/password.
+// The credentials are remembered so an expired session can be renewed later.
+func (c *ZenTaoClient) LoginSessionCtx(ctx context.Context, account, password string) error {
+	if target := c.ForContext(ctx); target != c {
+		return target.LoginSessionCtx(ctx, account, password)
+	}
+
+	c.sessionMutex.Lock()
+	c.sessionAccount = account
+	c.sessionPassword = password
+	c.sessionMutex.Unlock()
+
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+
+	return c.performSessionLogin(ctx, account, password)
+}
+
+func (c *ZenTaoClient) sessionCredentials() (account, password string) {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	return c.sessionAccount, c.sessionPassword
+}
+
+func (c *ZenTaoClient) currentSessionID() string {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	return c.sessionID
+}
+
+// canRenewSession reports whether the client is able to log in without the user
+func (c *ZenTaoClient) canRenewSession() bool {
+	account, password := c.sessionCredentials()
+	return c.authMethod == AuthSession && account != "" && password != ""
+}
+
+// ensureSession logs in lazily before the first request in session mode
+func (c *ZenTaoClient) ensureSession(ctx context.Context) error {
+	if !c.canRenewSession() || c.currentSessionID() != "" {
+		return nil
+	}
+
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+
+	// Another request may have logged in while we were waiting
+	if c.currentSessionID() != "" {
+		return nil
+	}
+
+	logger.Info("client", "No session yet, logging in on first request", nil)
+	account, password := c.sessionCredentials()
+	return c.performSessionLogin(ctx, account, password)
+}
+
+// renewSession replaces staleID with a fresh session. Concurrent callers that
+// saw the same stale session share a single login.
+func (c *ZenTaoClient) renewSession(ctx context.Context, staleID string) error {
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+
+	if current := c.currentSessionID(); current != "" && current != staleID {
+		logger.Debug("client", "Session already renewed by another request", nil)
+		return nil
+	}
+
+	c.sessionMutex.Lock()
+	c.sessionName = ""
+	c.sessionID = ""
+	c.sessionMutex.Unlock()
+
+	account, password := c.sessionCredentials()
+	return c.performSessionLogin(ctx, account, password)
+}
+
+// performSessionLogin runs getSessionID + user-login; callers hold loginMutex
+func (c *ZenTaoClient) performSessionLogin(ctx context.Context, account, password string) error {
+	if err := c.GetSessionIDCtx(ctx); err != nil {
+		return err
+	}
+	if err := c.LoginCtx(ctx, account, password); err != nil {
+		return err
+	}
+
+	c.sessionMutex.Lock()
+	path := c.sessionFile
+	c.sessionMutex.Unlock()
+	if path != "" {
+		c.saveSessionFile(path, account)
+	}
+	return nil
+}
+
+// loadSessionFile restores a saved session for the same server and account
+func (c *ZenTaoClient) loadSessionFile(path, account string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warn("client", "Failed to read session file", map[string]interface{}{
+				"path":  path,
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	var saved persistedSession
+	if err := json.Unmarshal(data, &saved); err != nil {
+		logger.Warn("client", "Ignoring malformed session file", map[string]interface{}{
+			"path":  path,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if saved.BaseURL != c.BaseURL || saved.Account != account || saved.SessionName == "" || saved.SessionID == "" {
+		logger.Info("client", "Session file belongs to another server or account, ignoring it", map[string]interface{}{
+			"path": path,
+		})
+		return
+	}
+
+	c.sessionMutex.Lock()
+	c.sessionName = saved.SessionName
+	c.sessionID = saved.SessionID
+	c.sessionMutex.Unlock()
+	logger.AddRedactedKey(saved.SessionName)
+	addVCRSessionParam(saved.SessionName)
+
+	logger.Info("client", "Restored session from file", map[string]interface{}{
+		"path":         path,
+		"session_name": saved.SessionName,
+		"saved_at":     saved.SavedAt.Format(time.RFC3339),
+	})
+}
+
+// saveSessionFile writes the current session atomically with owner-only permissions
+func (c *ZenTaoClient) saveSessionFile(path, account string) {
+	c.sessionMutex.Lock()
+	saved := persistedSession{
+		BaseURL:     c.BaseURL,
+		Account:     account,
+		SessionName: c.sessionName,
+		SessionID:   c.sessionID,
+		SavedAt:     time.Now(),
+	}
+	c.sessionMutex.Unlock()
+
+	if err := writeFileAtomic(path, saved); err != nil {
+		logger.Warn("client", "Failed to persist session", map[string]interface{}{
+			"path":  path,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Debug("client", "Session persisted", map[string]interface{}{
+		"path": path,
+	})
+}
+
+func writeFileAtomic(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sessionServer emulates ZenTao's getSessionID/user-login flow. Requests with
+// an unknown session get the HTML login page, like a real expired session.
+type sessionServer struct {
+	mu       sync.Mutex
+	next     int
+	valid    map[string]bool
+	issued   int
+	logins   int
+	requests int
+}
+
+func newSessionServer() (*sessionServer, *httptest.Server) {
+	ss := &sessionServer{valid: map[string]bool{}}
+	return ss, httptest.NewServer(http.HandlerFunc(ss.handle))
+}
+
+func (ss *sessionServer) handle(w http.ResponseWriter, r *http.Request) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	query := r.URL.Query()
+	sid := query.Get("zentaosid")
+
+	switch {
+	case query.Get("m") == "api" && query.Get("f") == "getSessionID":
+		ss.issued++
+		ss.next++
+		fmt.Fprintf(w, `{"status":"success","data":{"sessionName":"zentaosid","sessionID":"sid%d"}}`, ss.next)
+	case query.Get("m") == "user" && query.Get("f") == "login":
+		ss.logins++
+		ss.valid[sid] = true
+		w.Write([]byte(`{"status":"success","user":{"account":"admin"}}`))
+	default:
+		ss.requests++
+		if !ss.valid[sid] {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><script>location="index.php?m=user&f=login"</script></html>`))
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":[]}`))
+	}
+}
+
+func (ss *sessionServer) expireAll() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.valid = map[string]bool{}
+}
+
+func (ss *sessionServer) counts() (issued, logins int) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.issued, ss.logins
+}
+
+func newSessionTestClient(url, file string) *ZenTaoClient {
+	client := NewZenTaoClientWithSession(url + "/index.php")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	client.SetSessionOptions(SessionOptions{Account: "admin", Password: "secret", File: file})
+	return client
+}
+
+func TestSessionLazyLogin(t *testing.T) {
+	ss, server := newSessionServer()
+	defer server.Close()
+
+	client := newSessionTestClient(server.URL, "")
+	if client.IsAuthenticated() {
+		t.Fatal("Expected no session before the first request")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get("/products"); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+	if issued, logins := ss.counts(); issued != 1 || logins != 1 {
+		t.Errorf("Expected a single lazy login, got %d sessions and %d logins", issued, logins)
+	}
+}
+
+func TestSessionReloginOnExpiry(t *testing.T) {
+	ss, server := newSessionServer()
+	defer server.Close()
+
+	client := newSessionTestClient(server.URL, "")
+	if _, err := client.Get("/products"); err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+
+	ss.expireAll()
+	if _, err := client.Get("/products"); err != nil {
+		t.Fatalf("Expected transparent re-login, got %v", err)
+	}
+	if _, logins := ss.counts(); logins != 2 {
+		t.Errorf("Expected 2 logins, got %d", logins)
+	}
+	if sid := client.currentSessionID(); sid != "sid2" {
+		t.Errorf("Expected renewed session sid2, got %s", sid)
+	}
+}
+
+func TestSessionReloginOnlyOnce(t *testing.T) {
+	var logins int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("f") == "getSessionID":
+			w.Write([]byte(`{"status":"success","data":{"sessionName":"zentaosid","sessionID":"sid"}}`))
+		case query.Get("f") == "login":
+			logins++
+			w.Write([]byte(`{"status":"success"}`))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><div id="loginPanel"></div></html>`))
+		}
+	}))
+	defer server.Close()
+
+	client := newSessionTestClient(server.URL, "")
+	_, err := client.Get("/products")
+	if !errors.Is(err, ErrAuthExpired) {
+		t.Fatalf("Expected ErrAuthExpired, got %v", err)
+	}
+	if logins != 2 {
+		t.Errorf("Expected initial login plus one re-login, got %d", logins)
+	}
+}
+
+func TestSessionPersistence(t *testing.T) {
+	ss, server := newSessionServer()
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "session.json")
+
+	first := newSessionTestClient(server.URL, file)
+	if _, err := first.Get("/products"); err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+
+	second := newSessionTestClient(server.URL, file)
+	if !second.IsAuthenticated() {
+		t.Fatal("Expected session to be restored from file")
+	}
+	if _, err := second.Get("/products"); err != nil {
+		t.Fatalf("Request with restored session failed: %v", err)
+	}
+	if _, logins := ss.counts(); logins != 1 {
+		t.Errorf("Expected restored session to skip login, got %d logins", logins)
+	}
+
+	other := NewZenTaoClientWithSession(server.URL + "/index.php")
+	other.SetSessionOptions(SessionOptions{Account: "guest", Password: "x", File: file})
+	if other.IsAuthenticated() {
+		t.Error("Expected session file of another account to be ignored")
+	}
+}
+
+func TestGetSessionIDWithExistingSessionDoesNotDeadlock(t *testing.T) {
+	_, server := newSessionServer()
+	defer server.Close()
+
+	client := NewZenTaoClientWithSession(server.URL + "/index.php")
+	client.SetSessionCredentials("zentaosid", "old")
+
+	done := make(chan error, 1)
+	go func() {
+		if err := client.GetSessionIDCtx(context.Background()); err != nil {
+			done <- err
+			return
+		}
+		done <- client.LoginCtx(context.Background(), "admin", "secret")
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetSessionID/Login deadlocked on sessionMutex")
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// skewResolution is the precision of the HTTP Date header. Offsets below it
+// are indistinguishable from rounding and are treated as no skew at all.
+const skewResolution = time.Second
+
+// ClockSkewConfig controls how the client compensates for a local clock that
+// differs from the ZenTao server's. App tokens are md5(code+key+time), so
+// ZenTao rejects them once the two clocks drift too far apart.
+type ClockSkewConfig struct {
+	Disabled  bool          // do not apply the learned server offset to token timestamps
+	Threshold time.Duration // offset beyond which skew is logged and reported as the cause of auth failures
+}
+
+// DefaultClockSkewConfig returns the skew settings used when nothing is configured
+func DefaultClockSkewConfig() ClockSkewConfig {
+	return ClockSkewConfig{
+		Threshold: 10 * time.Second,
+	}
+}
+
+// ClockSkewStatus is a snapshot of the learned server offset for health reporting
+type ClockSkewStatus struct {
+	Compensating     bool       `json:"compensating"`
+	Known            bool       `json:"known"`
+	OffsetSeconds    float64    `json:"offset_seconds"` // server clock minus local clock
+	ThresholdSeconds float64    `json:"threshold_seconds"`
+	ExceedsThreshold bool       `json:"exceeds_threshold"`
+	Samples          int        `json:"samples"`
+	LastSampleAt     *time.Time `json:"last_sample_at,omitempty"`
+}
+
+// ClockSkewError explains an app token rejection caused by clock skew. It
+// unwraps to the original error, so errors.Is(err, ErrAuthExpired) still holds.
+type ClockSkewError struct {
+	Offset       time.Duration // server clock minus local clock
+	Threshold    time.Duration
+	Compensating bool
+	Err          error
+}
+
+func (e *ClockSkewError) Error() string {
+	hint := "enable clock skew compensation or synchronize the host clock (NTP)"
+	if e.Compensating {
+		hint = "the offset is compensated but ZenTao still rejects the token; synchronize the host clock (NTP)"
+	}
+	return fmt.Sprintf("local clock differs from the ZenTao server by %s (threshold %s), app tokens are time based: %s: %v",
+		e.Offset.Round(time.Second), e.Threshold, hint, e.Err)
+}
+
+func (e *ClockSkewError) Unwrap() error {
+	return e.Err
+}
+
+// clockSkew learns the server clock offset from response Date headers. The
+// zero value is ready to use and compensates with the default threshold.
+type clockSkew struct {
+	mu        sync.Mutex
+	config    ClockSkewConfig
+	offset    time.Duration
+	known     bool
+	samples   int
+	lastAt    time.Time
+	exceeding bool // last reported side of the threshold, to warn once per crossing
+}
+
+func (s *clockSkew) threshold() time.Duration {
+	if s.config.Threshold > 0 {
+		return s.config.Threshold
+	}
+	return DefaultClockSkewConfig().Threshold
+}
+
+// observe records one server Date header. sent and received bracket the
+// request, and their midpoint is taken as the moment the server stamped it.
+func (s *clockSkew) observe(date string, sent, received time.Time) {
+	if date == "" {
+		return
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		logger.Debug("client", "Ignoring unparsable Date header", map[string]interface{}{
+			"date": date,
+		})
+		return
+	}
+
+	// Date is truncated to the second, so its midpoint is the best estimate
+	serverTime = serverTime.Add(skewResolution / 2)
+	localTime := sent.Add(received.Sub(sent) / 2)
+	sample := serverTime.Sub(localTime)
+	if sample.Abs() < skewResolution {
+		sample = 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.samples++
+	s.lastAt = received
+
+	drift := sample - s.offset
+	switch {
+	case !s.known:
+		s.known = true
+		s.offset = sample
+		logger.Info("client", "Learned ZenTao server clock offset", map[string]interface{}{
+			"offset_ms":    sample.Milliseconds(),
+			"compensating": !s.config.Disabled,
+		})
+	case drift.Abs() >= skewResolution:
+		s.offset = sample
+		logger.Info("client", "ZenTao server clock offset drifted", map[string]interface{}{
+			"offset_ms":    sample.Milliseconds(),
+			"drift_ms":     drift.Milliseconds(),
+			"samples":      s.samples,
+			"compensating": !s.config.Disabled,
+		})
+	}
+
+	exceeding := s.offset.Abs() > s.threshold()
+	if exceeding != s.exceeding {
+		s.exceeding = exceeding
+		if exceeding {
+			logger.Warn("client", "Local clock differs from ZenTao server beyond threshold", map[string]interface{}{
+				"offset_ms":    s.offset.Milliseconds(),
+				"threshold_ms": s.threshold().Milliseconds(),
+				"compensating": !s.config.Disabled,
+			})
+		} else {
+			logger.Info("client", "Clock skew back within threshold", map[string]interface{}{
+				"offset_ms": s.offset.Milliseconds(),
+			})
+		}
+	}
+}
+
+// applied returns the offset to add to the local clock for token timestamps
+func (s *clockSkew) applied() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config.Disabled {
+		return 0
+	}
+	return s.offset
+}
+
+// explain wraps an auth failure in a *ClockSkewError when skew is its likely cause
+func (s *clockSkew) explain(err error) error {
+	if err == nil || !errors.Is(err, ErrAuthExpired) {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.known || s.offset.Abs() <= s.threshold() {
+		return err
+	}
+	return &ClockSkewError{
+		Offset:       s.offset,
+		Threshold:    s.threshold(),
+		Compensating: !s.config.Disabled,
+		Err:          err,
+	}
+}
+
+func (s *clockSkew) status() ClockSkewStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ClockSkewStatus{
+		Compensating:     !s.config.Disabled,
+		Known:            s.known,
+		OffsetSeconds:    s.offset.Seconds(),
+		ThresholdSeconds: s.threshold().Seconds(),
+		ExceedsThreshold: s.offset.Abs() > s.threshold(),
+		Samples:          s.samples,
+	}
+	if s.samples > 0 {
+		lastAt := s.lastAt
+		status.LastSampleAt = &lastAt
+	}
+	return status
+}
+
+// serverNow returns the local time corrected by the learned server offset
+func (c *ZenTaoClient) serverNow() time.Time {
+	return time.Now().Add(c.skew.applied())
+}
+
+// SetClockSkewConfig changes how the server clock offset is applied. The
+// offset learned so far is kept.
+func (c *ZenTaoClient) SetClockSkewConfig(config ClockSkewConfig) {
+	c.skew.mu.Lock()
+	c.skew.config = config
+	threshold := c.skew.threshold()
+	c.skew.mu.Unlock()
+
+	logger.Info("client", "Clock skew compensation configured", map[string]interface{}{
+		"compensating":      !config.Disabled,
+		"threshold_seconds": threshold.Seconds(),
+	})
+}
+
+// ClockSkew reports the learned server clock offset
+func (c *ZenTaoClient) ClockSkew() ClockSkewStatus {
+	return c.skew.status()
+}
+
+// ProbeClockSkew measures the server clock offset with an unauthenticated
+// HEAD request, so it works even while skew makes every token invalid
+func (c *ZenTaoClient) ProbeClockSkew(ctx context.Context) (time.Duration, error) {
+	if target := c.ForContext(ctx); target != c {
+		return target.ProbeClockSkew(ctx)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.BaseURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create clock probe: %w", err)
+	}
+
+	sent := time.Now()
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		logger.Warn("client", "Clock skew probe failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return 0, &TransportError{Method: http.MethodHead, Endpoint: c.BaseURL, Err: err}
+	}
+	resp.Body.Close()
+	received := time.Now()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return 0, fmt.Errorf("ZenTao server sent no Date header, cannot measure clock skew")
+	}
+	c.skew.observe(date, sent, received)
+
+	c.skew.mu.Lock()
+	offset := c.skew.offset
+	c.skew.mu.Unlock()
+
+	logger.Info("client", "Clock skew probe completed", map[string]interface{}{
+		"offset_ms": offset.Milliseconds(),
+	})
+	return offset, nil
+}
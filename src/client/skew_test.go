@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+// newSkewedFake starts an emulator whose clock runs offset ahead of ours
+func newSkewedFake(t *testing.T, offset time.Duration) (*ztfake.Server, *ZenTaoClient) {
+	fake := ztfake.New(ztfake.Options{Now: func() time.Time { return time.Now().Add(offset) }})
+	t.Cleanup(fake.Close)
+	fake.Seed("product", map[string]interface{}{"name": "Skewed"})
+
+	opts := fake.Options()
+	client := NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, opts.AppKey)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	return fake, client
+}
+
+func TestClockSkewObserve(t *testing.T) {
+	var skew clockSkew
+	sent := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	received := sent.Add(200 * time.Millisecond)
+
+	// Sub-second differences are Date header rounding, not skew
+	skew.observe(sent.Format(http.TimeFormat), sent, received)
+	if status := skew.status(); !status.Known || status.OffsetSeconds != 0 {
+		t.Errorf("Expected a known zero offset, got %+v", status)
+	}
+
+	skew.observe(sent.Add(time.Hour).Format(http.TimeFormat), sent, received)
+	if got := skew.applied(); got < time.Hour-time.Second || got > time.Hour+time.Second {
+		t.Errorf("Expected an offset of about 1h, got %s", got)
+	}
+	if !skew.status().ExceedsThreshold {
+		t.Error("Expected a 1h offset to exceed the default threshold")
+	}
+
+	skew.observe("not a date", sent, received)
+	if skew.status().Samples != 2 {
+		t.Errorf("Expected an unparsable Date header to be ignored, got %d samples", skew.status().Samples)
+	}
+
+	skew.config.Disabled = true
+	if got := skew.applied(); got != 0 {
+		t.Errorf("Expected no offset applied when compensation is disabled, got %s", got)
+	}
+}
+
+func TestClockSkewCompensatesAppTokens(t *testing.T) {
+	_, client := newSkewedFake(t, 2*time.Hour)
+
+	// The first attempt is signed with our clock and rejected; the response
+	// teaches the client the offset and the retry succeeds
+	if _, err := client.Get("/products/1"); err != nil {
+		t.Fatalf("Expected the request to succeed after learning the offset: %v", err)
+	}
+
+	status := client.ClockSkew()
+	if !status.Known || status.OffsetSeconds < 7190 || status.OffsetSeconds > 7210 {
+		t.Errorf("Expected an offset of about 2h, got %+v", status)
+	}
+
+	// Later requests are signed with the corrected clock straight away
+	if _, err := client.Get("/products/1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestClockSkewReportedWhenNotCompensating(t *testing.T) {
+	_, client := newSkewedFake(t, -time.Hour)
+	client.SetClockSkewConfig(ClockSkewConfig{Disabled: true, Threshold: time.Minute})
+
+	_, err := client.Get("/products/1")
+	var skewErr *ClockSkewError
+	if !errors.As(err, &skewErr) {
+		t.Fatalf("Expected a *ClockSkewError, got %v", err)
+	}
+	if !errors.Is(err, ErrAuthExpired) {
+		t.Error("Expected the skew error to unwrap to ErrAuthExpired")
+	}
+	if skewErr.Offset > -59*time.Minute || skewErr.Compensating {
+		t.Errorf("Unexpected skew error details: %+v", skewErr)
+	}
+}
+
+func TestProbeClockSkew(t *testing.T) {
+	fake, client := newSkewedFake(t, 30*time.Minute)
+
+	offset, err := client.ProbeClockSkew(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if offset < 29*time.Minute || offset > 31*time.Minute {
+		t.Errorf("Expected an offset of about 30m, got %s", offset)
+	}
+	if len(fake.Requests()) != 1 || fake.Requests()[0].Method != http.MethodHead {
+		t.Errorf("Expected a single HEAD probe, got %+v", fake.Requests())
+	}
+}
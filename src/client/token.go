@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// NewZenTaoClientWithToken creates a client that logs in with POST /tokens
+// and sends the token in the Token header of every request. The token is
+// fetched lazily and fetched again whenever ZenTao answers 401.
+func NewZenTaoClientWithToken(baseURL, account, password string) *ZenTaoClient {
+	logger.Info("client", "Creating new ZenTao client with token authentication", map[string]interface{}{
+		"base_url":     baseURL,
+		"auth_type":    "token",
+		"account":      account,
+		"has_password": password != "",
+	})
+
+	return &ZenTaoClient{
+		BaseURL:      baseURL,
+		Client:       newDefaultHTTPClient(),
+		authMethod:   AuthToken,
+		restBaseURL:  deriveRESTBaseURL(baseURL),
+		restAccount:  account,
+		restPassword: password,
+	}
+}
+
+// LoginTokenCtx exchanges account/password for a token via POST /tokens and
+// switches the client to token authentication. The credentials are kept so
+// an expired token can be renewed.
+func (c *ZenTaoClient) LoginTokenCtx(ctx context.Context, account, password string) error {
+	if target := c.ForContext(ctx); target != c {
+		return target.LoginTokenCtx(ctx, account, password)
+	}
+
+	c.tokenFetch.Lock()
+	defer c.tokenFetch.Unlock()
+
+	c.restMutex.Lock()
+	if c.restBaseURL == "" {
+		c.restBaseURL = deriveRESTBaseURL(c.BaseURL)
+	}
+	restBaseURL := c.restBaseURL
+	c.restMutex.Unlock()
+
+	token, err := c.fetchRESTToken(ctx, restBaseURL, account, password)
+	if err != nil {
+		logger.Error("client", "Token login failed", err, map[string]interface{}{
+			"account": account,
+		})
+		return fmt.Errorf("token login failed: %w", err)
+	}
+
+	c.restMutex.Lock()
+	c.restAccount = account
+	c.restPassword = password
+	c.restToken = token
+	c.authMethod = AuthToken
+	c.restMutex.Unlock()
+
+	logger.Info("client", "Token login successful", map[string]interface{}{
+		"account": account,
+	})
+	return nil
+}
+
+// usesTokenHeader reports whether a request authenticates with the Token
+// header: every request in token mode, documented endpoints in REST mode
+func (c *ZenTaoClient) usesTokenHeader(method, path string) bool {
+	return c.authMethod == AuthToken || c.useREST(method, path)
+}
+
+// hasToken reports whether a token from POST /tokens is cached
+func (c *ZenTaoClient) hasToken() bool {
+	c.restMutex.Lock()
+	defer c.restMutex.Unlock()
+	return c.restToken != ""
+}
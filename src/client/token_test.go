@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+func countTokenRequests(fake *ztfake.Server) int {
+	n := 0
+	for _, req := range fake.Requests() {
+		if strings.HasSuffix(req.Path, "/api.php/v1/tokens") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTokenAuthSendsHeader(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+	fake.Seed("bug", map[string]interface{}{"title": "Crash", "product": 1})
+
+	opts := fake.Options()
+	client := NewZenTaoClientWithToken(fake.BaseURL(), opts.Account, opts.Password)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	if client.IsAuthenticated() {
+		t.Error("Expected no token before the first request")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get("/products/1/bugs"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if !client.IsAuthenticated() {
+		t.Error("Expected a cached token after the first request")
+	}
+	if n := countTokenRequests(fake); n != 1 {
+		t.Errorf("Expected one token request, got %d", n)
+	}
+
+	for _, req := range fake.Requests() {
+		if req.Module != "bug" {
+			continue
+		}
+		if req.Token == "" {
+			t.Error("Expected the Token header on every request")
+		}
+		if req.Query.Has("token") || req.Query.Has("zentaosid") {
+			t.Errorf("Expected no auth query parameters, got %s", req.Query.Encode())
+		}
+	}
+}
+
+func TestTokenAuthRefreshesOn401(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+
+	opts := fake.Options()
+	client := NewZenTaoClientWithToken(fake.BaseURL(), opts.Account, opts.Password)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	if _, err := client.Get("/products/1/bugs"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fake.ExpireSessions()
+	if _, err := client.Get("/products/1/bugs"); err != nil {
+		t.Fatalf("Expected request to succeed after token refresh, got %v", err)
+	}
+	if n := countTokenRequests(fake); n != 2 {
+		t.Errorf("Expected two token requests, got %d", n)
+	}
+}
+
+func TestTokenAuthBadCredentials(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+
+	client := NewZenTaoClientWithToken(fake.BaseURL(), "admin", "wrong")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	if _, err := client.Get("/products/1/bugs"); err == nil {
+		t.Error("Expected an error with a wrong password")
+	}
+	if err := client.LoginTokenCtx(context.Background(), "admin", "wrong"); err == nil {
+		t.Error("Expected token login to fail with a wrong password")
+	}
+}
+
+func TestLoginTokenCtx(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+
+	// Start without credentials, as with zentao_login_token
+	client := NewZenTaoClientWithToken(fake.BaseURL(), "", "")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	opts := fake.Options()
+	if err := client.LoginTokenCtx(context.Background(), opts.Account, opts.Password); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !client.IsAuthenticated() || client.GetAuthMethod() != AuthToken {
+		t.Errorf("Expected an authenticated token client, got method %d", client.GetAuthMethod())
+	}
+	if _, err := client.Get("/products/1/bugs"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := countTokenRequests(fake); n != 1 {
+		t.Errorf("Expected the login token to be reused, got %d token requests", n)
+	}
+}
+
+func TestTokenFetchDoesNotStallOtherCalls(t *testing.T) {
+	release := make(chan struct{})
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/api.php/v1/tokens") {
+			tokenRequests.Add(1)
+			<-release
+			w.Write([]byte(`{"token":"slow-token"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":"{}"}`))
+	}))
+	defer server.Close()
+
+	client := NewZenTaoClientWithToken(server.URL+"/index.php", "admin", "secret")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get("/products/1/bugs"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	waitFor(t, "the token request", func() bool { return tokenRequests.Load() == 1 })
+
+	done := make(chan struct{})
+	go func() {
+		client.IsAuthenticated()
+		client.useREST("GET", "/products/1/bugs")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expected calls that only read token state not to wait for the token fetch")
+	}
+
+	close(release)
+	wg.Wait()
+	if n := tokenRequests.Load(); n != 1 {
+		t.Errorf("Expected concurrent requests to share one token fetch, got %d", n)
+	}
+}
+
+func TestNewClientFromProfileToken(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+
+	opts := fake.Options()
+	client, err := NewClientFromProfile(InstanceProfile{
+		Name:       "default",
+		BaseURL:    fake.BaseURL(),
+		AuthMethod: "token",
+		Account:    opts.Account,
+		Password:   opts.Password,
+	}, DefaultClientOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.GetAuthMethod() != AuthToken || client.GetAPIMode() != APIModeLegacy {
+		t.Errorf("Expected token auth on legacy routes, got method %d mode %s", client.GetAuthMethod(), client.GetAPIMode())
+	}
+	if _, err := client.Get("/products/1/bugs"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zentao/mcp-server/logger"
+)
+
+// VCRMode selects whether ZenTao traffic is recorded to or replayed from a cassette
+type VCRMode int
+
+const (
+	VCROff VCRMode = iota
+	VCRRecord
+	VCRReplay
+)
+
+func (m VCRMode) String() string {
+	switch m {
+	case VCRRecord:
+		return "record"
+	case VCRReplay:
+		return "replay"
+	default:
+		return "off"
+	}
+}
+
+// VCRConfig is parsed from ZENTAO_VCR, e.g. "record:/tmp/session.json"
+type VCRConfig struct {
+	Mode VCRMode
+	Path string // cassette file
+}
+
+// ErrVCRNoMatch is returned in replay mode for requests the cassette does not contain
+var ErrVCRNoMatch = errors.New("no matching interaction in cassette")
+
+// vcrRedacted replaces secrets in recorded cassettes
+const vcrRedacted = "REDACTED"
+
+// vcrVolatileParams change on every request; they are redacted when stored
+// and ignored when matching
+var vcrVolatileParams = []string{"time", "token", "code", "zentaosid", "sid"}
+
+// vcrSessionParams are session parameter names learned at runtime. ZenTao
+// reports its session name in getSessionID, and servers may configure a name
+// other than zentaosid.
+var (
+	vcrSessionParamsMu sync.RWMutex
+	vcrSessionParams   []string
+)
+
+// addVCRSessionParam treats the session parameter name as volatile in every cassette
+func addVCRSessionParam(name string) {
+	if name == "" {
+		return
+	}
+
+	vcrSessionParamsMu.Lock()
+	defer vcrSessionParamsMu.Unlock()
+	for _, known := range vcrSessionParams {
+		if known == name {
+			return
+		}
+	}
+	vcrSessionParams = append(vcrSessionParams, name)
+}
+
+// volatileVCRParams returns the fixed volatile parameters and the learned session names
+func volatileVCRParams() []string {
+	vcrSessionParamsMu.RLock()
+	defer vcrSessionParamsMu.RUnlock()
+
+	params := make([]string, 0, len(vcrVolatileParams)+len(vcrSessionParams))
+	params = append(params, vcrVolatileParams...)
+	return append(params, vcrSessionParams...)
+}
+
+// vcrSecretFields are JSON body keys whose values are scrubbed
+var vcrSecretFields = []string{"token", "password", "sessionid", "zentaosid", "key", "appkey"}
+
+// vcrHeaders are the response headers kept in a cassette; the rest (Date,
+// Set-Cookie, ...) are dropped
+var vcrHeaders = []string{"Content-Type", "Retry-After", "Location"}
+
+// ParseVCR parses "record:<path>" or "replay:<path>"; an empty spec means off
+func ParseVCR(spec string) (VCRConfig, error) {
+	if spec == "" || spec == "off" {
+		return VCRConfig{}, nil
+	}
+
+	mode, path, ok := strings.Cut(spec, ":")
+	if !ok || path == "" {
+		return VCRConfig{}, fmt.Errorf("expected record:<path> or replay:<path>, got %q", spec)
+	}
+
+	switch mode {
+	case "record":
+		return VCRConfig{Mode: VCRRecord, Path: path}, nil
+	case "replay":
+		return VCRConfig{Mode: VCRReplay, Path: path}, nil
+	default:
+		return VCRConfig{}, fmt.Errorf("unknown VCR mode %q (want record or replay)", mode)
+	}
+}
+
+// cassette is the on-disk format
+type cassette struct {
+	Version      int              `json:"version"`
+	RecordedAt   time.Time        `json:"recorded_at"`
+	Interactions []vcrInteraction `json:"interactions"`
+}
+
+type vcrInteraction struct {
+	Request  vcrRequest  `json:"request"`
+	Response vcrResponse `json:"response"`
+}
+
+type vcrRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type vcrResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+// VCRTransport records ZenTao traffic to a cassette or serves it back
+// without touching the network
+type VCRTransport struct {
+	mode VCRMode
+	path string
+	next http.RoundTripper // record mode only
+
+	mu        sync.Mutex
+	cassette  cassette
+	used      []bool
+	lastMatch map[string]int // match key -> index of the last interaction served
+}
+
+// vcrTransports shares one recorder per cassette path, so every instance
+// client of a pool appends to the same file instead of overwriting it
+var (
+	vcrTransportsMu sync.Mutex
+	vcrTransports   = map[string]*VCRTransport{}
+)
+
+// NewVCRTransport wraps next (record) or replaces it (replay). Recording
+// starts a fresh cassette; replay requires the cassette to exist.
+func NewVCRTransport(config VCRConfig, next http.RoundTripper) (*VCRTransport, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &VCRTransport{
+		mode:      config.Mode,
+		path:      config.Path,
+		next:      next,
+		lastMatch: map[string]int{},
+	}
+
+	switch config.Mode {
+	case VCRRecord:
+		t.cassette = cassette{Version: 1, RecordedAt: time.Now().UTC()}
+		if err := t.save(); err != nil {
+			return nil, fmt.Errorf("failed to create cassette %s: %w", config.Path, err)
+		}
+	case VCRReplay:
+		data, err := os.ReadFile(config.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette %s: %w", config.Path, err)
+		}
+		if err := json.Unmarshal(data, &t.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", config.Path, err)
+		}
+		t.used = make([]bool, len(t.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("VCR mode is off")
+	}
+
+	logger.Info("client", "VCR enabled", map[string]interface{}{
+		"mode":         config.Mode.String(),
+		"cassette":     config.Path,
+		"interactions": len(t.cassette.Interactions),
+	})
+	return t, nil
+}
+
+// sharedVCRTransport returns the transport already open for config.Path, or
+// opens one. The first caller's next transport is used for recording.
+func sharedVCRTransport(config VCRConfig, next http.RoundTripper) (*VCRTransport, error) {
+	vcrTransportsMu.Lock()
+	defer vcrTransportsMu.Unlock()
+
+	key := config.Mode.String() + ":" + config.Path
+	if t, ok := vcrTransports[key]; ok {
+		return t, nil
+	}
+	t, err := NewVCRTransport(config, next)
+	if err != nil {
+		return nil, err
+	}
+	vcrTransports[key] = t
+	return t, nil
+}
+
+// Interactions returns how many request/response pairs the cassette holds
+func (t *VCRTransport) Interactions() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.cassette.Interactions)
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *VCRTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := vcrRequest{
+		Method: req.Method,
+		URL:    scrubURL(req.URL),
+		Body:   scrubBody(body),
+	}
+
+	if t.mode == VCRReplay {
+		return t.replay(req, recorded)
+	}
+	return t.record(req, recorded)
+}
+
+func (t *VCRTransport) record(req *http.Request, recorded vcrRequest) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	headers := map[string]string{}
+	for _, name := range vcrHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, vcrInteraction{
+		Request: recorded,
+		Response: vcrResponse{
+			Status:  resp.StatusCode,
+			Headers: headers,
+			Body:    scrubBody(data),
+		},
+	})
+
+	// Saved after every interaction so a crashed session still leaves a cassette
+	if err := t.save(); err != nil {
+		logger.Warn("client", "Failed to write VCR cassette", map[string]interface{}{
+			"cassette": t.path,
+			"error":    err.Error(),
+		})
+	}
+	return resp, nil
+}
+
+// replay serves the first unused interaction with the same method, path,
+// stable query parameters and body. Once all such interactions are used the
+// last one is served again, so retries and polling keep working.
+func (t *VCRTransport) replay(req *http.Request, recorded vcrRequest) (*http.Response, error) {
+	key := vcrMatchKey(recorded)
+
+	t.mu.Lock()
+	index := -1
+	for i, interaction := range t.cassette.Interactions {
+		if !t.used[i] && vcrMatchKey(interaction.Request) == key {
+			index = i
+			break
+		}
+	}
+	if index >= 0 {
+		t.used[index] = true
+		t.lastMatch[key] = index
+	} else if last, ok := t.lastMatch[key]; ok {
+		index = last
+	}
+	var response vcrResponse
+	if index >= 0 {
+		response = t.cassette.Interactions[index].Response
+	}
+	t.mu.Unlock()
+
+	if index < 0 {
+		logger.Warn("client", "VCR replay found no matching interaction", map[string]interface{}{
+			"method":   recorded.Method,
+			"url":      recorded.URL,
+			"cassette": t.path,
+		})
+		return nil, fmt.Errorf("%w: %s %s", ErrVCRNoMatch, recorded.Method, recorded.URL)
+	}
+
+	header := http.Header{}
+	for name, value := range response.Headers {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// save writes the cassette; callers hold t.mu or own t exclusively
+func (t *VCRTransport) save() error {
+	return writeFileAtomic(t.path, t.cassette)
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// scrubURL drops the volatile auth parameters and sorts the rest
+func scrubURL(u *url.URL) string {
+	query := u.Query()
+	for _, name := range volatileVCRParams() {
+		if query.Has(name) {
+			query.Set(name, vcrRedacted)
+		}
+	}
+
+	scrubbed := *u
+	scrubbed.User = nil
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
+
+// vcrMatchKey identifies a request independently of host, auth parameters
+// and parameter order
+func vcrMatchKey(r vcrRequest) string {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return r.Method + " " + r.URL + " " + r.Body
+	}
+	query := u.Query()
+	for _, name := range volatileVCRParams() {
+		query.Del(name)
+	}
+	return r.Method + " " + u.Path + "?" + query.Encode() + " " + r.Body
+}
+
+// scrubBody redacts secrets in JSON bodies. Bodies without secrets, and
+// anything that is not JSON, are kept byte for byte.
+func scrubBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	// UseNumber keeps large IDs exactly as ZenTao sent them
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return string(data)
+	}
+	if !scrubValue(decoded) {
+		return string(data)
+	}
+	scrubbed, err := json.Marshal(decoded)
+	if err != nil {
+		return string(data)
+	}
+	return string(scrubbed)
+}
+
+// scrubValue redacts secret fields in place and reports whether it changed anything
+func scrubValue(value interface{}) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isVCRSecret(key) {
+				v[key] = vcrRedacted
+				changed = true
+				continue
+			}
+			changed = scrubValue(item) || changed
+		}
+	case []interface{}:
+		for _, item := range v {
+			changed = scrubValue(item) || changed
+		}
+	}
+	return changed
+}
+
+func isVCRSecret(key string) bool {
+	for _, secret := range vcrSecretFields {
+		if strings.EqualFold(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// UseVCR wraps the client's transport with a recorder or replayer
+func (c *ZenTaoClient) UseVCR(config VCRConfig) error {
+	if config.Mode == VCROff {
+		return nil
+	}
+
+	next := c.Client.Transport
+	if existing, ok := next.(*VCRTransport); ok {
+		next = existing.next
+	}
+
+	transport, err := sharedVCRTransport(config, next)
+	if err != nil {
+		logger.Error("client", "Failed to enable VCR", err, map[string]interface{}{
+			"mode":     config.Mode.String(),
+			"cassette": config.Path,
+		})
+		return err
+	}
+
+	httpClient := *c.Client
+	httpClient.Transport = transport
+	c.Client = &httpClient
+	return nil
+}
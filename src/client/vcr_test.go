@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+func TestParseVCR(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    VCRConfig
+		wantErr bool
+	}{
+		{"", VCRConfig{}, false},
+		{"off", VCRConfig{}, false},
+		{"record:/tmp/a.json", VCRConfig{Mode: VCRRecord, Path: "/tmp/a.json"}, false},
+		{"replay:C:/cassettes/a.json", VCRConfig{Mode: VCRReplay, Path: "C:/cassettes/a.json"}, false},
+		{"replay:", VCRConfig{}, true},
+		{"rewind:/tmp/a.json", VCRConfig{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseVCR(test.spec)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseVCR(%q): unexpected error state %v", test.spec, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseVCR(%q) = %+v, expected %+v", test.spec, got, test.want)
+		}
+	}
+}
+
+func TestVCRRecordAndReplay(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+	fake.Seed("product", map[string]interface{}{"name": "Widget"})
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	opts := fake.Options()
+
+	recorder := NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, opts.AppKey)
+	recorder.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	if err := recorder.UseVCR(VCRConfig{Mode: VCRRecord, Path: cassettePath}); err != nil {
+		t.Fatalf("Failed to start recording: %v", err)
+	}
+
+	calls := []struct {
+		method string
+		path   string
+		body   interface{}
+	}{
+		{"GET", "/products", nil},
+		{"POST", "/products", map[string]interface{}{"name": "Gadget", "code": "GDG"}},
+		{"GET", "/products", nil},
+		{"GET", "/product/2", nil},
+	}
+
+	recorded := make([]string, len(calls))
+	for i, call := range calls {
+		resp, err := recorder.DoRequest(call.method, call.path, call.body, nil)
+		if err != nil {
+			t.Fatalf("Recording %s %s failed: %v", call.method, call.path, err)
+		}
+		recorded[i] = string(resp)
+	}
+
+	data, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("Cassette was not written: %v", err)
+	}
+	cassetteText := string(data)
+	var tokens []string
+	for _, req := range fake.Requests() {
+		tokens = append(tokens, req.Query.Get("token"), "time="+req.Query.Get("time"))
+	}
+	for _, secret := range append(tokens, opts.AppKey) {
+		if strings.Contains(cassetteText, secret) {
+			t.Errorf("Cassette leaks %q", secret)
+		}
+	}
+
+	// Replay offline with other credentials and a later clock
+	fake.Close()
+	replayer := NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, "another-key")
+	replayer.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	replayer.lastTime = time.Now().Unix() + 3600
+	if err := replayer.UseVCR(VCRConfig{Mode: VCRReplay, Path: cassettePath}); err != nil {
+		t.Fatalf("Failed to start replay: %v", err)
+	}
+
+	for i, call := range calls {
+		resp, err := replayer.DoRequest(call.method, call.path, call.body, nil)
+		if err != nil {
+			t.Fatalf("Replaying %s %s failed: %v", call.method, call.path, err)
+		}
+		if string(resp) != recorded[i] {
+			t.Errorf("Call %d: replayed %s, recorded %s", i, resp, recorded[i])
+		}
+	}
+
+	// The same list call was recorded twice; extra calls reuse the latest answer
+	resp, err := replayer.Get("/products")
+	if err != nil || string(resp) != recorded[2] {
+		t.Errorf("Expected repeated call to reuse the last interaction, got %s (%v)", resp, err)
+	}
+
+	if _, err := replayer.Get("/product/" + strconv.Itoa(99)); !errors.Is(err, ErrVCRNoMatch) {
+		t.Errorf("Expected ErrVCRNoMatch for an unrecorded request, got %v", err)
+	}
+}
+
+func TestVCRScrubsSessionSecrets(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	opts := fake.Options()
+
+	client := NewZenTaoClientWithSession(fake.BaseURL())
+	if err := client.UseVCR(VCRConfig{Mode: VCRRecord, Path: cassettePath}); err != nil {
+		t.Fatalf("Failed to start recording: %v", err)
+	}
+	if err := client.LoginSessionCtx(context.Background(), opts.Account, opts.Password); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(cassettePath)
+	if strings.Contains(string(data), opts.Password) || strings.Contains(string(data), client.currentSessionID()) {
+		t.Errorf("Expected password and session ID to be scrubbed, got %s", data)
+	}
+
+	replayer := NewZenTaoClientWithSession("http://offline.invalid/index.php")
+	if err := replayer.UseVCR(VCRConfig{Mode: VCRReplay, Path: cassettePath}); err != nil {
+		t.Fatalf("Failed to start replay: %v", err)
+	}
+	if err := replayer.LoginSessionCtx(context.Background(), opts.Account, "any-password"); err != nil {
+		t.Errorf("Expected login to replay regardless of password, got %v", err)
+	}
+}
+
+func TestVCRScrubsCustomSessionName(t *testing.T) {
+	var sessions int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("f") == "getSessionID" {
+			sessions++
+			fmt.Fprintf(w, `{"status":"success","data":{"sessionName":"customsid","sessionID":"live-session-%d"}}`, sessions)
+			return
+		}
+		if r.URL.Query().Get("customsid") == "" {
+			http.Error(w, "no session", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":"{}"}`))
+	}))
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := NewZenTaoClientWithSession(server.URL + "/index.php")
+	recorder.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	if err := recorder.UseVCR(VCRConfig{Mode: VCRRecord, Path: cassettePath}); err != nil {
+		t.Fatalf("Failed to start recording: %v", err)
+	}
+	if err := recorder.LoginSessionCtx(context.Background(), "admin", "secret"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if _, err := recorder.Get("/index.php?m=bug&f=browse&t=json&productID=1"); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(cassettePath)
+	if strings.Contains(string(data), "live-session-") {
+		t.Errorf("Expected the custom session parameter to be scrubbed, got %s", data)
+	}
+
+	server.Close()
+	replayer := NewZenTaoClientWithSession(server.URL + "/index.php")
+	replayer.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+	if err := replayer.UseVCR(VCRConfig{Mode: VCRReplay, Path: cassettePath}); err != nil {
+		t.Fatalf("Failed to start replay: %v", err)
+	}
+	if err := replayer.LoginSessionCtx(context.Background(), "admin", "secret"); err != nil {
+		t.Fatalf("Expected login to replay, got %v", err)
+	}
+	if _, err := replayer.Get("/index.php?m=bug&f=browse&t=json&productID=1"); err != nil {
+		t.Errorf("Expected the request to match regardless of the session ID, got %v", err)
+	}
+}
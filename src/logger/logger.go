@@ -15,6 +15,7 @@ package logger
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
@@ -33,6 +34,7 @@ const (
 type Logger struct {
 	level      LogLevel
 	enableJSON bool
+	out        io.Writer // os.Stderr; stdout belongs to the MCP stdio transport
 }
 
 var defaultLogger *Logger
@@ -42,6 +44,7 @@ func init() {
 	defaultLogger = &Logger{
 		level:      INFO,
 		enableJSON: false,
+		out:        os.Stderr,
 	}
 
 	// Check environment variables
@@ -61,6 +64,19 @@ func init() {
 	if os.Getenv("ZENTAO_LOG_JSON") == "true" {
 		defaultLogger.enableJSON = true
 	}
+
+	// Secrets are masked in every field, URL and body before output
+	activeRedactor.Store(newRedactor(DefaultRedactedKeys, nil))
+	var keys, patterns []string
+	if v := os.Getenv("ZENTAO_LOG_REDACT_KEYS"); v != "" {
+		keys = strings.Split(v, ",")
+	}
+	if v := os.Getenv("ZENTAO_LOG_REDACT_PATTERNS"); v != "" {
+		patterns = strings.Split(v, ",")
+	}
+	if err := SetRedaction(keys, patterns); err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] logger: %v, using the default redaction list\n", err)
+	}
 }
 
 type LogEntry struct {
@@ -94,6 +110,9 @@ func (l *Logger) log(level LogLevel, component string, message string, fields ma
 		}
 	}
 
+	redactor := activeRedactor.Load()
+	fields = redactor.redactMap(fields)
+
 	entry := LogEntry{
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Component: component,
@@ -113,15 +132,15 @@ func (l *Logger) log(level LogLevel, component string, message string, fields ma
 	}
 
 	if err != nil {
-		entry.Error = err.Error()
+		entry.Error = redactor.redactString(err.Error())
 	}
 
 	if l.enableJSON {
 		entry.Message = message
 		if jsonData, err := json.Marshal(entry); err == nil {
-			fmt.Fprintln(os.Stderr, string(jsonData))
+			fmt.Fprintln(l.out, string(jsonData))
 		} else {
-			fmt.Fprintf(os.Stderr, "[%s] %s: %s %s\n", entry.Level, component, message, entry.Error)
+			fmt.Fprintf(l.out, "[%s] %s: %s %s\n", entry.Level, component, message, entry.Error)
 		}
 	} else {
 		logMsg := fmt.Sprintf("[%s] %s/%s: %s", entry.Level, component, funcName, message)
@@ -135,10 +154,10 @@ func (l *Logger) log(level LogLevel, component string, message string, fields ma
 		}
 
 		if err != nil {
-			logMsg += " error=" + err.Error()
+			logMsg += " error=" + entry.Error
 		}
 
-		fmt.Fprintln(os.Stderr, logMsg)
+		fmt.Fprintln(l.out, logMsg)
 	}
 }
 
@@ -183,7 +202,8 @@ func LogRequest(component, method, url string, headers map[string]string, body i
 		"headers": headers,
 	}
 	if body != nil {
-		fields["body"] = fmt.Sprintf("%v", body)
+		// Kept structured so nested secrets such as a login password are masked
+		fields["body"] = body
 	}
 	Debug(component, "Making HTTP request", fields)
 }
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Redacted replaces every secret before a log line is written
+const Redacted = "REDACTED"
+
+// DefaultRedactedKeys are the field, parameter and header names whose values
+// never reach the log. Keys also match after a _ or - prefix, so "app_key"
+// and "session_token" are covered by "key" and "token".
+var DefaultRedactedKeys = []string{
+	"password", "passwd", "token", "key", "secret",
+	"zentaosid", "sid", "sessionid", "session_id",
+	"authorization", "cookie", "set-cookie",
+}
+
+// redactor masks secrets in log fields, URLs and nested bodies. It is
+// immutable; changing the deny-list swaps in a new one.
+type redactor struct {
+	keys     map[string]bool
+	patterns []*regexp.Regexp // user patterns, the whole match is masked
+
+	queryParam *regexp.Regexp // key=value in URLs and form bodies
+	jsonField  *regexp.Regexp // "key": "value" inside JSON text
+}
+
+var (
+	activeRedactor atomic.Pointer[redactor]
+	redactMu       sync.Mutex // serializes updates of activeRedactor
+)
+
+func newRedactor(keys []string, patterns []*regexp.Regexp) *redactor {
+	r := &redactor{keys: map[string]bool{}, patterns: patterns}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" || r.keys[key] {
+			continue
+		}
+		r.keys[key] = true
+		names = append(names, regexp.QuoteMeta(key))
+	}
+	if len(names) == 0 {
+		return r
+	}
+
+	// Longest first so "session_id" is tried before "sid"
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	alternatives := strings.Join(names, "|")
+	// Same matching rule as deniedKey: the key itself or a _key / -key suffix
+	name := `(?:[\w.-]*[_-])?(?:` + alternatives + `)`
+	r.queryParam = regexp.MustCompile(`(?i)((?:^|[?&;\s])` + name + `=)[^&\s"',\]}]+`)
+	r.jsonField = regexp.MustCompile(`(?i)("` + name + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	return r
+}
+
+// deniedKey reports whether values under key are secrets
+func (r *redactor) deniedKey(key string) bool {
+	key = strings.ToLower(key)
+	if r.keys[key] {
+		return true
+	}
+	for denied := range r.keys {
+		if strings.HasSuffix(key, "_"+denied) || strings.HasSuffix(key, "-"+denied) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString masks secrets embedded in free text such as URLs, form
+// bodies, JSON previews and error messages
+func (r *redactor) redactString(s string) string {
+	if r.queryParam != nil {
+		s = r.queryParam.ReplaceAllString(s, "${1}"+Redacted)
+		s = r.jsonField.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+	}
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// redactValue returns a copy of v with secrets masked. Values under a denied
+// key are replaced entirely, except booleans and numbers such as
+// has_password=true, which carry no secret.
+func (r *redactor) redactValue(key string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	kind := reflect.TypeOf(v).Kind()
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v
+	}
+	if key != "" && r.deniedKey(key) {
+		if s, ok := v.(string); ok && s == "" {
+			return s
+		}
+		return Redacted
+	}
+
+	switch value := v.(type) {
+	case string:
+		return r.redactString(value)
+	case []byte:
+		return r.redactString(string(value))
+	case error:
+		return r.redactString(value.Error())
+	case map[string]interface{}:
+		return r.redactMap(value)
+	case map[string]string:
+		out := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			out[k] = r.redactValue(k, item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, item := range value {
+			out[i] = r.redactValue("", item)
+		}
+		return out
+	case []string:
+		out := make([]string, len(value))
+		for i, item := range value {
+			out[i] = r.redactString(item)
+		}
+		return out
+	case fmt.Stringer:
+		return r.redactString(value.String())
+	}
+
+	switch kind {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Ptr:
+		// Other bodies are walked through their JSON form so nested fields are masked too
+		data, err := json.Marshal(v)
+		if err != nil {
+			return r.redactString(fmt.Sprintf("%v", v))
+		}
+		var generic interface{}
+		if json.Unmarshal(data, &generic) != nil {
+			return r.redactString(string(data))
+		}
+		return r.redactValue("", generic)
+	}
+	return r.redactString(fmt.Sprintf("%v", v))
+}
+
+func (r *redactor) redactMap(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		out[k] = r.redactValue(k, v)
+	}
+	return out
+}
+
+// SetRedaction replaces the deny-list. keys are added to DefaultRedactedKeys;
+// patterns are regular expressions whose whole match is masked.
+func SetRedaction(keys []string, patterns []string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	activeRedactor.Store(newRedactor(append(append([]string(nil), DefaultRedactedKeys...), keys...), compiled))
+	return nil
+}
+
+// AddRedactedKey masks one more key, for example the session parameter name
+// ZenTao reports at runtime
+func AddRedactedKey(key string) {
+	if key == "" {
+		return
+	}
+
+	redactMu.Lock()
+	defer redactMu.Unlock()
+
+	current := activeRedactor.Load()
+	if current.keys[strings.ToLower(key)] {
+		return
+	}
+	keys := make([]string, 0, len(current.keys)+1)
+	for k := range current.keys {
+		keys = append(keys, k)
+	}
+	activeRedactor.Store(newRedactor(append(keys, key), current.patterns))
+}
+
+// RedactString masks secrets in s with the active deny-list
+func RedactString(s string) string {
+	return activeRedactor.Load().redactString(s)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactFields(t *testing.T) {
+	r := newRedactor(append(DefaultRedactedKeys, "mysid"), nil)
+
+	fields := r.redactMap(map[string]interface{}{
+		"password":     "hunter2",
+		"app_key":      "abc123",
+		"has_password": true,
+		"token_count":  3,
+		"account":      "admin",
+		"headers":      map[string]string{"Token": "t0k3n", "Accept": "application/json"},
+		"body": map[string]interface{}{
+			"account": "admin",
+			"nested":  []interface{}{map[string]interface{}{"secret": "s"}},
+		},
+	})
+
+	for key, want := range map[string]interface{}{
+		"password":     Redacted,
+		"app_key":      Redacted,
+		"has_password": true,
+		"token_count":  3,
+		"account":      "admin",
+	} {
+		if fields[key] != want {
+			t.Errorf("%s = %v, expected %v", key, fields[key], want)
+		}
+	}
+
+	headers := fields["headers"].(map[string]interface{})
+	if headers["Token"] != Redacted || headers["Accept"] != "application/json" {
+		t.Errorf("Unexpected headers: %v", headers)
+	}
+	nested := fields["body"].(map[string]interface{})["nested"].([]interface{})[0].(map[string]interface{})
+	if nested["secret"] != Redacted {
+		t.Errorf("Expected nested secret to be redacted, got %v", nested)
+	}
+}
+
+func TestRedactURLsAndText(t *testing.T) {
+	r := newRedactor(append(DefaultRedactedKeys, "mysid"), []*regexp.Regexp{regexp.MustCompile(`Bearer \S+`)})
+
+	tests := []struct{ in, want string }{
+		{
+			"http://zentao/index.php?m=bug&f=view&code=mcp&time=1700000000&token=0123456789abcdef&t=json",
+			"http://zentao/index.php?m=bug&f=view&code=mcp&time=1700000000&token=REDACTED&t=json",
+		},
+		{"http://zentao/index.php?m=my&mysid=abc123", "http://zentao/index.php?m=my&mysid=REDACTED"},
+		{`{"account":"admin","password":"hunter2"}`, `{"account":"admin","password":"REDACTED"}`},
+		{`login failed: {"token": "x\"y"}`, `login failed: {"token": "REDACTED"}`},
+		{"Authorization: Bearer abc.def", "Authorization: REDACTED"},
+		{"?m=search&f=index&monkey=banana", "?m=search&f=index&monkey=banana"},
+	}
+	for _, test := range tests {
+		if got := r.redactString(test.in); got != test.want {
+			t.Errorf("redactString(%q) = %q, expected %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestLoggerRedactsBeforeOutput(t *testing.T) {
+	var out bytes.Buffer
+	l := &Logger{level: DEBUG, enableJSON: true, out: &out}
+
+	l.Error("client", "Login failed", errors.New(`Get "http://zentao/?zentaosid=s3ss10n": timeout`), map[string]interface{}{
+		"url":  "http://zentao/index.php?m=user&f=login&zentaosid=s3ss10n",
+		"body": struct{ Account, Password string }{"admin", "hunter2"},
+	})
+
+	line := out.String()
+	for _, secret := range []string{"s3ss10n", "hunter2"} {
+		if strings.Contains(line, secret) {
+			t.Errorf("Log output leaks %q: %s", secret, line)
+		}
+	}
+	var entry LogEntry
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log line: %v", err)
+	}
+	if body := entry.Fields["body"].(map[string]interface{}); body["Account"] != "admin" {
+		t.Errorf("Expected non-secret body fields to survive, got %v", body)
+	}
+}
+
+func TestAddRedactedKey(t *testing.T) {
+	if got := RedactString("?customsid=abc"); got != "?customsid=abc" {
+		t.Fatalf("Expected customsid to be logged before it is registered, got %s", got)
+	}
+	AddRedactedKey("customsid")
+	if got := RedactString("?customsid=abc"); got != "?customsid=REDACTED" {
+		t.Errorf("Expected customsid to be redacted, got %s", got)
+	}
+}
@@ -16,6 +16,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -25,69 +27,80 @@ import (
 	"github.com/zentao/mcp-server/tools"
 )
 
-var ztClient *client.ZenTaoClient
+var (
+	ztPool   *client.Pool
+	ztClient *client.ZenTaoClient // default instance; tool calls may select another one
+)
 
 func main() {
 	logger.Info("server", "ZenTao MCP Server starting up", map[string]interface{}{
 		"version": "1.0.0",
 	})
 
-	// Read configuration
-	baseURL := os.Getenv("ZENTAO_BASE_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:8080"
-		logger.Warn("server", "ZENTAO_BASE_URL not set, using default", map[string]interface{}{
-			"default_url": baseURL,
-		})
+	serveCfg, err := parseServeConfig(os.Args[1:])
+	if err != nil {
+		logger.Error("server", "Invalid transport configuration", err, nil)
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(2)
 	}
 
-	// Determine authentication method
-	authMethod := os.Getenv("ZENTAO_AUTH_METHOD")
-	if authMethod == "" {
-		authMethod = "app" // Default to app-based auth
+	// Configure HTTP transport (timeouts, proxy, TLS) and instance profiles
+	clientOpts, err := client.LoadClientOptions()
+	if err != nil {
+		logger.Error("server", "Invalid HTTP client configuration", err, nil)
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
 	}
+	// Read-only mode hides mutating tools; the clients also reject writes
+	serveCfg.Tools.SetReadOnly(clientOpts.ReadOnly)
 
-	code := os.Getenv("ZENTAO_APP_CODE")
-	key := os.Getenv("ZENTAO_APP_KEY")
+	profiles, defaultInstance, err := clientOpts.ResolveInstances()
+	if err != nil {
+		logger.Error("server", "Invalid instance configuration", err, nil)
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
+	}
 
-	logger.Info("server", "Configuration loaded", map[string]interface{}{
-		"base_url":     baseURL,
-		"auth_method":  authMethod,
-		"has_app_code": code != "",
-		"has_app_key":  key != "",
-		"log_level":    os.Getenv("ZENTAO_LOG_LEVEL"),
-		"log_json":     os.Getenv("ZENTAO_LOG_JSON"),
-	})
+	for _, profile := range profiles {
+		logger.Info("server", "Configuration loaded", map[string]interface{}{
+			"instance":     profile.Name,
+			"base_url":     profile.BaseURL,
+			"auth_method":  profile.AuthMethod,
+			"has_app_code": profile.AppCode != "",
+			"has_app_key":  profile.AppKey != "",
+			"has_account":  profile.Account != "",
+			"api_mode":     profile.APIMode,
+			"log_level":    os.Getenv("ZENTAO_LOG_LEVEL"),
+			"log_json":     os.Getenv("ZENTAO_LOG_JSON"),
+		})
+	}
 
-	// Initialize ZenTao client based on auth method
-	logger.Debug("server", "Initializing ZenTao client", map[string]interface{}{
-		"base_url":    baseURL,
-		"auth_method": authMethod,
-		"app_code":    code,
-		"app_key":     key != "",
+	// Initialize one ZenTao client per instance
+	logger.Debug("server", "Initializing ZenTao clients", map[string]interface{}{
+		"instance_count":   len(profiles),
+		"default_instance": defaultInstance,
 	})
 
-	switch authMethod {
-	case "app":
-		ztClient = client.NewZenTaoClientWithApp(baseURL, code, key)
-	case "session":
-		ztClient = client.NewZenTaoClientWithSession(baseURL)
-		logger.Info("server", "Session-based authentication enabled", map[string]interface{}{
-			"note": "Use zentao_login_session tool for authentication",
-		})
-	default:
-		logger.Warn("server", "Unknown auth method, defaulting to app-based", map[string]interface{}{
-			"provided_method": authMethod,
-			"default_method":  "app",
-		})
-		ztClient = client.NewZenTaoClientWithApp(baseURL, code, key)
+	ztPool, err = client.NewPool(profiles, defaultInstance, clientOpts)
+	if err != nil {
+		logger.Error("server", "Failed to initialize ZenTao clients", err, nil)
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
 	}
+	ztClient = ztPool.Default()
+	reloadCredentialsOnSIGHUP()
 
 	// Initialize MCP server
 	logger.Info("server", "Initializing MCP server", map[string]interface{}{
 		"name":    "ZenTao MCP Server",
 		"version": "1.0.0",
 	})
+	// Per-caller clients of an HTTP session are dropped when the session ends
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		ztPool.EvictSession(session.SessionID())
+	})
+
 	s := server.NewMCPServer(
 		"ZenTao MCP Server",
 		"1.0.0",
@@ -95,266 +108,148 @@ func main() {
 		server.WithResourceCapabilities(true, true),
 		server.WithPromptCapabilities(true),
 		server.WithRecovery(),
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(tools.InstanceMiddleware(ztPool)),
 	)
 
 	// Register components
 	logger.Info("server", "Registering tools", nil)
-	registerTools(s)
+	registerTools(s, hooks, serveCfg)
 
 	logger.Info("server", "Registering resources", nil)
-	registerResources(s)
+	registerResources(s, serveCfg.Tools)
 
 	logger.Info("server", "Registering prompts", nil)
 	registerPrompts(s)
 
-	logger.Info("server", "Starting MCP server on stdio", nil)
-
 	// Start server
-	if err := server.ServeStdio(s); err != nil {
+	if serveCfg.Transport == "http" {
+		err = serveHTTP(s, serveCfg, ztPool, clientOpts.Callers)
+	} else {
+		logger.Info("server", "Starting MCP server on stdio", nil)
+		err = server.ServeStdio(s)
+	}
+	if err != nil {
 		logger.Error("server", "Server failed to start", err, map[string]interface{}{
-			"transport": "stdio",
+			"transport": serveCfg.Transport,
 		})
 		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func registerTools(s *server.MCPServer) {
-	logger.Debug("server", "Registering auth tools", nil)
-	tools.RegisterAuthTools(s, ztClient)
-
-	logger.Debug("server", "Registering product tools", nil)
-	tools.RegisterProductTools(s, ztClient)
-
-	logger.Debug("server", "Registering project tools", nil)
-	tools.RegisterProjectTools(s, ztClient)
-
-	logger.Debug("server", "Registering story tools", nil)
-	tools.RegisterStoryTools(s, ztClient)
-
-	logger.Debug("server", "Registering task tools", nil)
-	tools.RegisterTaskTools(s, ztClient)
-
-	logger.Debug("server", "Registering bug tools", nil)
-	tools.RegisterBugTools(s, ztClient)
-
-	logger.Debug("server", "Registering test case tools", nil)
-	tools.RegisterTestCaseTools(s, ztClient)
-
-	logger.Debug("server", "Registering plan tools", nil)
-	tools.RegisterPlanTools(s, ztClient)
-
-	logger.Debug("server", "Registering build tools", nil)
-	tools.RegisterBuildTools(s, ztClient)
-
-	logger.Debug("server", "Registering user tools", nil)
-	tools.RegisterUserTools(s, ztClient)
-
-	logger.Debug("server", "Registering feedback tools", nil)
-	tools.RegisterFeedbackTools(s, ztClient)
-
-	logger.Debug("server", "Registering ticket tools", nil)
-	tools.RegisterTicketTools(s, ztClient)
-
-	logger.Debug("server", "Registering program tools", nil)
-	tools.RegisterProgramTools(s, ztClient)
-
-	logger.Debug("server", "Registering test task tools", nil)
-	tools.RegisterTestTaskTools(s, ztClient)
-
-	logger.Debug("server", "Registering release tools", nil)
-	tools.RegisterReleaseTools(s, ztClient)
-
-	logger.Debug("server", "Registering API library tools", nil)
-	tools.RegisterApiLibTools(s, ztClient)
-
-	logger.Debug("server", "Registering entry tools", nil)
-	tools.RegisterEntryTools(s, ztClient)
-
-	logger.Debug("server", "Registering my module tools", nil)
-	tools.RegisterMyTools(s, ztClient)
-
-	logger.Debug("server", "Registering todo tools", nil)
-	tools.RegisterTodoTools(s, ztClient)
-
-	logger.Debug("server", "Registering personnel tools", nil)
-	tools.RegisterPersonnelTools(s, ztClient)
-
-	logger.Debug("server", "Registering stakeholder tools", nil)
-	tools.RegisterStakeholderTools(s, ztClient)
-
-	logger.Debug("server", "Registering branch tools", nil)
-	tools.RegisterBranchTools(s, ztClient)
-
-	logger.Debug("server", "Registering design tools", nil)
-	tools.RegisterDesignTools(s, ztClient)
-
-	logger.Debug("server", "Registering projectbuild tools", nil)
-	tools.RegisterProjectBuildTools(s, ztClient)
-
-	logger.Debug("server", "Registering execution tools", nil)
-	tools.RegisterExecutionTools(s, ztClient)
-
-	logger.Debug("server", "Registering kanban tools", nil)
-	tools.RegisterKanbanTools(s, ztClient)
-
-	logger.Debug("server", "Registering epic tools", nil)
-	tools.RegisterEpicTools(s, ztClient)
-
-	logger.Debug("server", "Registering requirement tools", nil)
-	tools.RegisterRequirementTools(s, ztClient)
-
-	logger.Debug("server", "Registering space tools", nil)
-	tools.RegisterSpaceTools(s, ztClient)
-
-	logger.Debug("server", "Registering transfer tools", nil)
-	tools.RegisterTransferTools(s, ztClient)
-
-	logger.Debug("server", "Registering ZAI tools", nil)
-	tools.RegisterZaiTools(s, ztClient)
-
-	logger.Debug("server", "Registering AI tools", nil)
-	tools.RegisterAiTools(s, ztClient)
-
-	logger.Debug("server", "Registering zanode tools", nil)
-	tools.RegisterZanodeTools(s, ztClient)
-
-	logger.Debug("server", "Registering case library tools", nil)
-	tools.RegisterCaseLibTools(s, ztClient)
-
-	logger.Debug("server", "Registering QA tools", nil)
-	tools.RegisterQaTools(s, ztClient)
-
-	logger.Debug("server", "Registering test report tools", nil)
-	tools.RegisterTestReportTools(s, ztClient)
-
-	logger.Debug("server", "Registering test suite tools", nil)
-	tools.RegisterTestSuiteTools(s, ztClient)
-
-	logger.Debug("server", "Registering documentation tools", nil)
-	tools.RegisterDocTools(s, ztClient)
-
-	logger.Debug("server", "Registering datatable and report tools", nil)
-	tools.RegisterDatatableTools(s, ztClient)
-
-	logger.Debug("server", "Registering admin tools", nil)
-	tools.RegisterAdminTools(s, ztClient)
-
-	logger.Debug("server", "Registering aiapp tools", nil)
-	tools.RegisterAiappTools(s, ztClient)
+// reloadCredentialsOnSIGHUP re-resolves every credential source on SIGHUP, so
+// rotated app keys and passwords take effect without restarting the server
+func reloadCredentialsOnSIGHUP() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		for range hup {
+			logger.Info("server", "SIGHUP received, reloading credentials", nil)
+
+			opts, err := client.LoadClientOptions()
+			if err != nil {
+				logger.Error("server", "Credential reload failed, keeping current credentials", err, nil)
+				continue
+			}
+			profiles, _, err := opts.ResolveInstances()
+			if err != nil {
+				logger.Error("server", "Credential reload failed, keeping current credentials", err, nil)
+				continue
+			}
+			ztPool.ReloadCredentials(profiles)
+		}
+	}()
+}
 
-	logger.Debug("server", "Registering bi tools", nil)
-	tools.RegisterBiTools(s, ztClient)
+func registerTools(s *server.MCPServer, hooks *server.Hooks, cfg serveConfig) {
+	logger.Debug("server", "Registering instance tools", nil)
+	tools.RegisterInstanceTools(s, ztPool)
 
-	logger.Debug("server", "Registering tree tools", nil)
-	tools.RegisterTreeTools(s, ztClient)
+	// Paged list tools accept all and max_items, and every tool accepts an
+	// optional instance argument
+	prepare := func(target *server.MCPServer) {
+		tools.AddPaginationArguments(target)
+		tools.AddInstanceArgument(target, ztPool.Names(), ztPool.DefaultName())
+	}
 
-	logger.Debug("server", "Registering search tools", nil)
-	tools.RegisterSearchTools(s, ztClient)
+	var toolsets []string
+	if cfg.Discovery {
+		// Only core tools and the meta-tools are listed; agents enable the rest,
+		// per session on the HTTP transport
+		discovery := tools.NewDiscovery(s, ztClient, cfg.Tools, prepare)
+		toolsets = discovery.EnableCore()
+		tools.RegisterDiscoveryTools(s, discovery)
+		hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+			discovery.ForgetSession(session.SessionID())
+		})
+	} else {
+		// Register the selected toolsets, then drop tools matching --exclude-tools
+		toolsets = tools.RegisterToolsets(s, ztClient, cfg.Tools)
+	}
+	prepare(s)
 
 	logger.Info("server", "All tool registrations completed", map[string]interface{}{
-		"total_tools": 542,
+		"toolsets":       toolsets,
+		"tool_discovery": cfg.Discovery,
+		"read_only":      cfg.Tools.ReadOnly(),
+		"total_tools":    len(s.ListTools()),
 	})
 }
 
-func registerResources(s *server.MCPServer) {
-	logger.Debug("server", "Registering product resources", nil)
-	resources.RegisterProductResources(s, ztClient)
-
-	logger.Debug("server", "Registering project resources", nil)
-	resources.RegisterProjectResources(s, ztClient)
-
-	logger.Debug("server", "Registering program resources", nil)
-	resources.RegisterProgramResources(s, ztClient)
-
-	logger.Debug("server", "Registering story resources", nil)
-	resources.RegisterStoryResources(s, ztClient)
-
-	logger.Debug("server", "Registering task resources", nil)
-	resources.RegisterTaskResources(s, ztClient)
-
-	logger.Debug("server", "Registering bug resources", nil)
-	resources.RegisterBugResources(s, ztClient)
-
-	logger.Debug("server", "Registering user resources", nil)
-	resources.RegisterUserResources(s, ztClient)
-
-	logger.Debug("server", "Registering test task resources", nil)
-	resources.RegisterTestTaskResources(s, ztClient)
-
-	logger.Debug("server", "Registering build resources", nil)
-	resources.RegisterBuildResources(s, ztClient)
-
-	logger.Debug("server", "Registering plan resources", nil)
-	resources.RegisterPlanResources(s, ztClient)
-
-	logger.Debug("server", "Registering release resources", nil)
-	resources.RegisterReleaseResources(s, ztClient)
-
-	logger.Debug("server", "Registering API library resources", nil)
-	resources.RegisterApiLibResources(s, ztClient)
-
-	logger.Debug("server", "Registering entry resources", nil)
-	resources.RegisterEntryResources(s, ztClient)
-
-	logger.Debug("server", "Registering my module resources", nil)
-	resources.RegisterMyResources(s, ztClient)
-
-	logger.Debug("server", "Registering todo resources", nil)
-	resources.RegisterTodoResources(s, ztClient)
-
-	logger.Debug("server", "Registering personnel resources", nil)
-	resources.RegisterPersonnelResources(s, ztClient)
-
-	logger.Debug("server", "Registering program resources", nil)
-	resources.RegisterProgramResources(s, ztClient)
-
-	logger.Debug("server", "Registering stakeholder resources", nil)
-	resources.RegisterStakeholderResources(s, ztClient)
-
-	logger.Debug("server", "Registering execution resources", nil)
-	resources.RegisterExecutionResources(s, ztClient)
-
-	logger.Debug("server", "Registering kanban resources", nil)
-	resources.RegisterKanbanResources(s, ztClient)
-
-	logger.Debug("server", "Registering epic resources", nil)
-	resources.RegisterEpicResources(s, ztClient)
-
-	logger.Debug("server", "Registering requirement resources", nil)
-	resources.RegisterRequirementResources(s, ztClient)
-
-	logger.Debug("server", "Registering space resources", nil)
-	resources.RegisterSpaceResources(s, ztClient)
-
-	logger.Debug("server", "Registering transfer resources", nil)
-	resources.RegisterTransferResources(s, ztClient)
-
-	logger.Debug("server", "Registering ZAI resources", nil)
-	resources.RegisterZaiResources(s, ztClient)
-
-	logger.Debug("server", "Registering AI resources", nil)
-	resources.RegisterAiResources(s, ztClient)
-
-	logger.Debug("server", "Registering zanode resources", nil)
-	resources.RegisterZanodeResources(s, ztClient)
-
-	logger.Debug("server", "Registering case library resources", nil)
-	resources.RegisterCaseLibResources(s, ztClient)
-
-	logger.Debug("server", "Registering QA resources", nil)
-	resources.RegisterQaResources(s, ztClient)
-
-	logger.Debug("server", "Registering test report resources", nil)
-	resources.RegisterTestReportResources(s, ztClient)
+// resourceGroups ties each group of resources to the toolset that gates it
+var resourceGroups = []struct {
+	toolset  string
+	name     string
+	register func(*server.MCPServer, client.ZenTaoAPI)
+}{
+	{"products", "product", resources.RegisterProductResources},
+	{"projects", "project", resources.RegisterProjectResources},
+	{"programs", "program", resources.RegisterProgramResources},
+	{"stories", "story", resources.RegisterStoryResources},
+	{"tasks", "task", resources.RegisterTaskResources},
+	{"bugs", "bug", resources.RegisterBugResources},
+	{"users", "user", resources.RegisterUserResources},
+	{"testtasks", "test task", resources.RegisterTestTaskResources},
+	{"builds", "build", resources.RegisterBuildResources},
+	{"plans", "plan", resources.RegisterPlanResources},
+	{"releases", "release", resources.RegisterReleaseResources},
+	{"apilibs", "API library", resources.RegisterApiLibResources},
+	{"entries", "entry", resources.RegisterEntryResources},
+	{"my", "my module", resources.RegisterMyResources},
+	{"todos", "todo", resources.RegisterTodoResources},
+	{"personnel", "personnel", resources.RegisterPersonnelResources},
+	{"stakeholders", "stakeholder", resources.RegisterStakeholderResources},
+	{"executions", "execution", resources.RegisterExecutionResources},
+	{"kanban", "kanban", resources.RegisterKanbanResources},
+	{"epics", "epic", resources.RegisterEpicResources},
+	{"requirements", "requirement", resources.RegisterRequirementResources},
+	{"spaces", "space", resources.RegisterSpaceResources},
+	{"transfer", "transfer", resources.RegisterTransferResources},
+	{"zai", "ZAI", resources.RegisterZaiResources},
+	{"ai", "AI", resources.RegisterAiResources},
+	{"zanode", "zanode", resources.RegisterZanodeResources},
+	{"caselibs", "case library", resources.RegisterCaseLibResources},
+	{"qa", "QA", resources.RegisterQaResources},
+	{"testreports", "test report", resources.RegisterTestReportResources},
+	{"testsuites", "test suite", resources.RegisterTestSuiteResources},
+}
 
-	logger.Debug("server", "Registering test suite resources", nil)
-	resources.RegisterTestSuiteResources(s, ztClient)
+func registerResources(s *server.MCPServer, sel tools.ToolsetSelection) {
+	registered := 0
+	for _, group := range resourceGroups {
+		if !sel.Enabled(group.toolset) {
+			continue
+		}
+		logger.Debug("server", "Registering "+group.name+" resources", nil)
+		group.register(s, ztClient)
+		registered++
+	}
 
 	logger.Info("server", "All resource registrations completed", map[string]interface{}{
-		"total_resources":      46,
-		"templates_registered": 100,
-		"note":                 "List resources + resource templates for individual/scoped access",
+		"resource_groups": registered,
+		"note":            "List resources + resource templates for individual/scoped access",
 	})
 }
 
@@ -19,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterAiResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register AI admin index resource
 	s.AddResource(mcp.NewResource(
 		"zentao://ai/admin",
@@ -27,7 +27,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("AI module administrative interface and overview"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=adminIndex&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=adminIndex&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get AI admin index: %w", err)
 		}
@@ -47,7 +47,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of available AI mini programs"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=miniPrograms&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=miniPrograms&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get mini programs: %w", err)
 		}
@@ -67,7 +67,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of available AI prompts"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=prompts&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=prompts&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get prompts: %w", err)
 		}
@@ -87,7 +87,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Available AI role templates"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=roleTemplates&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=roleTemplates&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get role templates: %w", err)
 		}
@@ -111,7 +111,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			promptID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptView&t=json&id=%s", promptID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptView&t=json&id=%s", promptID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get prompt %s: %w", promptID, err)
 			}
@@ -137,7 +137,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			appID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=miniProgramView&t=json&appID=%s", appID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=miniProgramView&t=json&appID=%s", appID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get mini program %s: %w", appID, err)
 			}
@@ -159,7 +159,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Current status of prompt executions"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=promptExecutionStatus&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=promptExecutionStatus&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get prompt execution status: %w", err)
 		}
@@ -179,7 +179,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Analytics and usage statistics for AI features"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=analytics&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=analytics&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get AI analytics: %w", err)
 		}
@@ -199,7 +199,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of published AI prompts"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=prompts&t=json&status=published")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=prompts&t=json&status=published")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get published prompts: %w", err)
 		}
@@ -219,7 +219,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of prompts in testing phase"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=prompts&t=json&status=testing")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=prompts&t=json&status=testing")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get testing prompts: %w", err)
 		}
@@ -239,7 +239,7 @@ func RegisterAiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of published AI mini programs"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=ai&f=miniPrograms&t=json&status=published")
+		resp, err := client.GetCtx(ctx, "/index.php?m=ai&f=miniPrograms&t=json&status=published")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get published mini programs: %w", err)
 		}
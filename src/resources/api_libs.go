@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,12 +20,12 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterApiLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterApiLibResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register API library index resource
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://api-libs", "ZenTao API Libraries Index"),
 		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-			resp, err := client.Get("/index.php?m=api&f=index&t=json")
+			resp, err := client.GetCtx(ctx, "/index.php?m=api&f=index&t=json")
 			if err != nil {
 				return nil, fmt.Errorf("failed to get API libraries index: %w", err)
 			}
@@ -52,7 +53,7 @@ func RegisterApiLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			libID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=releases&t=json&libID=%s", libID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=releases&t=json&libID=%s", libID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get API library releases: %w", err)
 			}
@@ -80,7 +81,7 @@ func RegisterApiLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			libID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=struct&t=json&libID=%s", libID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=struct&t=json&libID=%s", libID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get API library structures: %w", err)
 			}
@@ -109,7 +110,7 @@ func RegisterApiLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			libID := parts[1]
 			releaseID := parts[3]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=struct&t=json&libID=%s&releaseID=%s", libID, releaseID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=struct&t=json&libID=%s&releaseID=%s", libID, releaseID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get API library structures by release: %w", err)
 			}
@@ -138,8 +139,8 @@ func RegisterApiLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			libID := parts[1]
 			apiID := parts[3]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=view&t=json&libID=%s&apiID=%s", libID, apiID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=api&f=view&t=json&libID=%s&apiID=%s", libID, apiID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get API details: %w", err)
 			}
 
@@ -166,7 +167,7 @@ func RegisterApiLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			libID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=index&t=json&libID=%s", libID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=index&t=json&libID=%s", libID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get API library APIs: %w", err)
 			}
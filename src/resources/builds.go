@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterBuildResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterBuildResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register build details resource template
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://builds/{buildID}", "ZenTao Build Details"),
@@ -31,8 +32,8 @@ func RegisterBuildResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			buildID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=view&t=json&buildID=%s", buildID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=build&f=view&t=json&buildID=%s", buildID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get build details: %w", err)
 			}
 
@@ -57,7 +58,7 @@ func RegisterBuildResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			productID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetProductBuilds&t=json&productID=%s", productID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetProductBuilds&t=json&productID=%s", productID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product builds: %w", err)
 			}
@@ -83,7 +84,7 @@ func RegisterBuildResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			projectID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetProjectBuilds&t=json&projectID=%s", projectID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetProjectBuilds&t=json&projectID=%s", projectID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project builds: %w", err)
 			}
@@ -109,7 +110,7 @@ func RegisterBuildResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetExecutionBuilds&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetExecutionBuilds&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution builds: %w", err)
 			}
@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterCaseLibResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterCaseLibResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Case library list resource
 	caseLibListResource := mcp.NewResource(
 		"zentao://caselibs",
@@ -29,7 +30,7 @@ func RegisterCaseLibResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddResource(caseLibListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=caselib&f=index&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=caselib&f=index&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get case libraries: %w", err)
 		}
@@ -54,8 +55,8 @@ func RegisterCaseLibResources(s *server.MCPServer, client *client.ZenTaoClient)
 			return nil, fmt.Errorf("invalid case library URI: %s", request.Params.URI)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=view&t=json&libID=%s", libID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=caselib&f=view&t=json&libID=%s", libID), &resp); err != nil {
 			return nil, fmt.Errorf("failed to get case library: %w", err)
 		}
 		return []mcp.ResourceContents{
@@ -79,8 +80,8 @@ func RegisterCaseLibResources(s *server.MCPServer, client *client.ZenTaoClient)
 			return nil, fmt.Errorf("invalid case library URI: %s", request.Params.URI)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=browse&t=json&libID=%s", libID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=caselib&f=browse&t=json&libID=%s", libID), &resp); err != nil {
 			return nil, fmt.Errorf("failed to get case library cases: %w", err)
 		}
 		return []mcp.ResourceContents{
@@ -106,8 +107,8 @@ func RegisterCaseLibResources(s *server.MCPServer, client *client.ZenTaoClient)
 		}
 		caseID := matches[2]
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=viewCase&t=json&caseID=%s", caseID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=caselib&f=viewCase&t=json&caseID=%s", caseID), &resp); err != nil {
 			return nil, fmt.Errorf("failed to get case library case: %w", err)
 		}
 		return []mcp.ResourceContents{
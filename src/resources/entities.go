@@ -23,7 +23,7 @@ import (
 	"github.com/zentao/mcp-server/logger"
 )
 
-func RegisterStoryResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterStoryResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Note: No general /stories endpoint in ZenTao API
 	// Stories are accessed via projects/products/executions
 
@@ -40,7 +40,7 @@ func RegisterStoryResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/products/%s/stories", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/products/%s/stories", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product stories: %w", err)
 			}
@@ -67,7 +67,7 @@ func RegisterStoryResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("story ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/stories/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/stories/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get story details: %w", err)
 			}
@@ -83,7 +83,7 @@ func RegisterStoryResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 }
 
-func RegisterTaskResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTaskResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Note: No general /tasks endpoint in ZenTao API
 	// Tasks are accessed via executions
 
@@ -100,7 +100,7 @@ func RegisterTaskResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/executions/%s/tasks", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/executions/%s/tasks", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution tasks: %w", err)
 			}
@@ -127,7 +127,7 @@ func RegisterTaskResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("task ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/tasks/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/tasks/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get task details: %w", err)
 			}
@@ -143,7 +143,7 @@ func RegisterTaskResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 }
 
-func RegisterBugResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterBugResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	bugsListResource := mcp.NewResource(
 		"zentao://bugs",
 		"ZenTao Bugs List",
@@ -152,7 +152,7 @@ func RegisterBugResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(bugsListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/bugs")
+		resp, err := client.GetCtx(ctx, "/bugs")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get bugs: %w", err)
 		}
@@ -179,7 +179,7 @@ func RegisterBugResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/products/%s/bugs", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/products/%s/bugs", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product bugs: %w", err)
 			}
@@ -206,7 +206,7 @@ func RegisterBugResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("bug ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/bugs/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/bugs/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get bug details: %w", err)
 			}
@@ -229,7 +229,7 @@ func RegisterBugResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 }
 
-func RegisterUserResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterUserResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	usersListResource := mcp.NewResource(
 		"zentao://users",
 		"ZenTao Users List",
@@ -238,7 +238,7 @@ func RegisterUserResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(usersListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/users")
+		resp, err := client.GetCtx(ctx, "/users")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get users: %w", err)
 		}
@@ -264,7 +264,7 @@ func RegisterUserResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("user ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/users/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/users/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get user details: %w", err)
 			}
@@ -287,7 +287,7 @@ func RegisterUserResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myProfileResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/user")
+		resp, err := client.GetCtx(ctx, "/user")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get user profile: %w", err)
 		}
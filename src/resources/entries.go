@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterEntryResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterEntryResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register entries list resource
 	entriesListResource := mcp.NewResource(
 		"zentao://entries",
@@ -29,8 +30,8 @@ func RegisterEntryResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(entriesListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=entry&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=entry&f=browse&t=json", &resp); err != nil {
 			return nil, fmt.Errorf("failed to get entries list: %w", err)
 		}
 
@@ -55,7 +56,7 @@ func RegisterEntryResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("entry ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=entry&f=log&t=json&id=%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=entry&f=log&t=json&id=%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get entry details: %w", err)
 			}
@@ -83,7 +84,7 @@ func RegisterEntryResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=entry&f=log&t=json&id=%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=entry&f=log&t=json&id=%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get entry log: %w", err)
 			}
@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterEpicResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register epic details resource template
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://epics/{epicID}", "ZenTao Epic Details"),
@@ -31,8 +32,8 @@ func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			epicID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=view&t=json&storyID=%s", epicID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=epic&f=view&t=json&storyID=%s", epicID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get epic details: %w", err)
 			}
 
@@ -57,7 +58,7 @@ func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			epicID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=linkStories&t=json&storyID=%s", epicID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=linkStories&t=json&storyID=%s", epicID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get epic stories: %w", err)
 			}
@@ -83,7 +84,7 @@ func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			epicID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=linkRequirements&t=json&storyID=%s", epicID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=linkRequirements&t=json&storyID=%s", epicID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get epic requirements: %w", err)
 			}
@@ -109,7 +110,7 @@ func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			productID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=import&t=json&productID=%s", productID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=import&t=json&productID=%s", productID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product epics: %w", err)
 			}
@@ -135,7 +136,7 @@ func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			projectID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=import&t=json&projectID=%s", projectID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=import&t=json&projectID=%s", projectID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project epics: %w", err)
 			}
@@ -161,7 +162,7 @@ func RegisterEpicResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=import&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=import&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution epics: %w", err)
 			}
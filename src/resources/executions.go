@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterExecutionResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register execution details resource template
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://executions/{executionID}", "ZenTao Execution Details"),
@@ -31,8 +32,8 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=view&t=json&executionID=%s", executionID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=view&t=json&executionID=%s", executionID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get execution details: %w", err)
 			}
 
@@ -57,7 +58,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=task&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=task&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution tasks: %w", err)
 			}
@@ -83,7 +84,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=story&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=story&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution stories: %w", err)
 			}
@@ -109,7 +110,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=bug&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=bug&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution bugs: %w", err)
 			}
@@ -135,7 +136,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=team&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=team&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution team: %w", err)
 			}
@@ -161,7 +162,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=ajaxGetBurn&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=ajaxGetBurn&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution burn chart: %w", err)
 			}
@@ -187,7 +188,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=ajaxGetCFD&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=ajaxGetCFD&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution CFD: %w", err)
 			}
@@ -213,7 +214,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=ajaxGetExecutionKanban&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=ajaxGetExecutionKanban&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution kanban: %w", err)
 			}
@@ -235,7 +236,7 @@ func RegisterExecutionResources(s *server.MCPServer, client *client.ZenTaoClient
 		mcp.WithResourceDescription("List of all executions in ZenTao"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=execution&f=all&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=execution&f=all&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get all executions: %w", err)
 		}
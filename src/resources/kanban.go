@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterKanbanResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register kanban spaces resource
 	s.AddResource(mcp.NewResource(
 		"zentao://kanban/spaces",
@@ -27,7 +28,7 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of all kanban spaces"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=kanban&f=space&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=kanban&f=space&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get kanban spaces: %w", err)
 		}
@@ -50,7 +51,7 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("invalid kanban space URI format: %s", uri)
 			}
 			// Get space details (this might need adjustment based on actual API)
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=space&t=json"))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=space&t=json"))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get kanban space details: %w", err)
 			}
@@ -76,8 +77,8 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			kanbanID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=view&t=json&kanbanID=%s", kanbanID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=view&t=json&kanbanID=%s", kanbanID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get kanban board details: %w", err)
 			}
 
@@ -103,8 +104,8 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			kanbanID := parts[1]
 			regionID := parts[3]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=view&t=json&kanbanID=%s&regionID=%s", kanbanID, regionID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=view&t=json&kanbanID=%s&regionID=%s", kanbanID, regionID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get kanban region details: %w", err)
 			}
 
@@ -129,7 +130,7 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			regionID := parts[3]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=ajaxGetLanes&t=json&regionID=%s", regionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=ajaxGetLanes&t=json&regionID=%s", regionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get kanban lane details: %w", err)
 			}
@@ -155,7 +156,7 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			laneID := parts[5]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=ajaxGetColumns&t=json&laneID=%s", laneID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=ajaxGetColumns&t=json&laneID=%s", laneID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get kanban column details: %w", err)
 			}
@@ -181,8 +182,8 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			cardID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=viewCard&t=json&cardID=%s", cardID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=viewCard&t=json&cardID=%s", cardID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get kanban card details: %w", err)
 			}
 
@@ -207,8 +208,8 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			regionID := parts[3]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=viewArchivedCard&t=json&regionID=%s", regionID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=viewArchivedCard&t=json&regionID=%s", regionID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get archived kanban cards: %w", err)
 			}
 
@@ -233,8 +234,8 @@ func RegisterKanbanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			regionID := parts[3]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=viewArchivedColumn&t=json&regionID=%s", regionID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=viewArchivedColumn&t=json&regionID=%s", regionID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get archived kanban columns: %w", err)
 			}
 
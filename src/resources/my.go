@@ -19,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterMyResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Dashboard resource
 	myDashboardResource := mcp.NewResource(
 		"zentao://my/dashboard",
@@ -29,7 +29,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myDashboardResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=index&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=index&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get dashboard: %w", err)
 		}
@@ -52,7 +52,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myProfileResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=profile&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=profile&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get profile: %w", err)
 		}
@@ -75,7 +75,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myCalendarResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=calendar&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=calendar&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get calendar: %w", err)
 		}
@@ -101,7 +101,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("work mode not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=work&t=json&mode=%s", mode))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=my&f=work&t=json&mode=%s", mode))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get work: %w", err)
 			}
@@ -125,7 +125,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myTodosResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=todo&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=todo&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get todos: %w", err)
 		}
@@ -148,7 +148,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myStoriesResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=story&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=story&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get stories: %w", err)
 		}
@@ -171,7 +171,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myTasksResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=task&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=task&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get tasks: %w", err)
 		}
@@ -194,7 +194,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myBugsResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=bug&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=bug&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get bugs: %w", err)
 		}
@@ -217,7 +217,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myProjectsResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=project&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=project&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get projects: %w", err)
 		}
@@ -240,7 +240,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myExecutionsResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=execution&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=execution&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get executions: %w", err)
 		}
@@ -263,7 +263,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myTeamResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=team&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=team&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get team: %w", err)
 		}
@@ -286,7 +286,7 @@ func RegisterMyResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(myDynamicResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=dynamic&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=dynamic&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get dynamic: %w", err)
 		}
@@ -18,7 +18,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterPersonnelResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterPersonnelResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Personnel accessible list resource template
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://personnel/accessible/{programID}", "ZenTao Personnel Accessible"),
@@ -31,7 +31,7 @@ func RegisterPersonnelResources(s *server.MCPServer, client *client.ZenTaoClient
 				return nil, fmt.Errorf("program ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=accessible&t=json&programID=%s", programID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=personnel&f=accessible&t=json&programID=%s", programID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get accessible personnel: %w", err)
 			}
@@ -58,7 +58,7 @@ func RegisterPersonnelResources(s *server.MCPServer, client *client.ZenTaoClient
 				return nil, fmt.Errorf("program ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=invest&t=json&programID=%s", programID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=personnel&f=invest&t=json&programID=%s", programID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get personnel invest: %w", err)
 			}
@@ -85,7 +85,7 @@ func RegisterPersonnelResources(s *server.MCPServer, client *client.ZenTaoClient
 				return nil, fmt.Errorf("object ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=whitelist&t=json&objectID=%s", objectID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=personnel&f=whitelist&t=json&objectID=%s", objectID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get personnel whitelist: %w", err)
 			}
@@ -21,7 +21,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterPlanResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterPlanResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Note: No general /productplans endpoint in ZenTao API
 	// Plans are accessed individually by ID
 
@@ -37,7 +37,7 @@ func RegisterPlanResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("plan ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/productplans/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/productplans/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get plan details: %w", err)
 			}
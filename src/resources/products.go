@@ -32,7 +32,7 @@ func extractIDFromURI(uri, resourceType string) string {
 	return ""
 }
 
-func RegisterProductResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProductResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	productListResource := mcp.NewResource(
 		"zentao://products",
 		"ZenTao Product List",
@@ -41,7 +41,7 @@ func RegisterProductResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddResource(productListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/products")
+		resp, err := client.GetCtx(ctx, "/products")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get products: %w", err)
 		}
@@ -67,7 +67,7 @@ func RegisterProductResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("product ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/products/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/products/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product details: %w", err)
 			}
@@ -83,7 +83,7 @@ func RegisterProductResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 }
 
-func RegisterProjectResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProjectResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	projectListResource := mcp.NewResource(
 		"zentao://projects",
 		"ZenTao Project List",
@@ -92,7 +92,7 @@ func RegisterProjectResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddResource(projectListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/projects")
+		resp, err := client.GetCtx(ctx, "/projects")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get projects: %w", err)
 		}
@@ -118,7 +118,7 @@ func RegisterProjectResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("project ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/projects/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/projects/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project details: %w", err)
 			}
@@ -146,7 +146,7 @@ func RegisterProjectResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/projects/%s/executions", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/projects/%s/executions", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project executions: %w", err)
 			}
@@ -174,7 +174,7 @@ func RegisterProjectResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/projects/%s/stories", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/projects/%s/stories", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project stories: %w", err)
 			}
@@ -201,7 +201,7 @@ func RegisterProjectResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("execution ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/executions/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/executions/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution details: %w", err)
 			}
@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProgramResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Programs list resource
 	programsResource := mcp.NewResource(
 		"zentao://programs",
@@ -29,8 +30,8 @@ func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddResource(programsResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=program&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=program&f=browse&t=json", &resp); err != nil {
 			return nil, fmt.Errorf("failed to get programs list: %w", err)
 		}
 
@@ -52,7 +53,7 @@ func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddResource(programKanbanResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=program&f=kanban&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=program&f=kanban&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get program kanban: %w", err)
 		}
@@ -78,8 +79,8 @@ func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("program ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=view&t=json&programID=%s", id))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=view&t=json&programID=%s", id), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get program details: %w", err)
 			}
 
@@ -105,7 +106,7 @@ func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("program ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=product&t=json&programID=%s", programID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=program&f=product&t=json&programID=%s", programID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get program products: %w", err)
 			}
@@ -132,7 +133,7 @@ func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("program ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=project&t=json&programID=%s", programID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=program&f=project&t=json&programID=%s", programID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get program projects: %w", err)
 			}
@@ -159,7 +160,7 @@ func RegisterProgramResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("program ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=stakeholder&t=json&programID=%s", programID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=program&f=stakeholder&t=json&programID=%s", programID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get program stakeholders: %w", err)
 			}
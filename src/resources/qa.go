@@ -18,7 +18,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterQaResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterQaResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	qaIndexResource := mcp.NewResource(
 		"zentao://qa",
 		"ZenTao QA Index",
@@ -27,7 +27,7 @@ func RegisterQaResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(qaIndexResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=qa&f=index&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=qa&f=index&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get QA index: %w", err)
 		}
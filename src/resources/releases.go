@@ -19,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterReleaseResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterReleaseResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register project releases resource template
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://projects/{projectId}/releases", "ZenTao Project Releases"),
@@ -33,7 +33,7 @@ func RegisterReleaseResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/projects/%s/releases", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/projects/%s/releases", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project releases: %w", err)
 			}
@@ -61,7 +61,7 @@ func RegisterReleaseResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/products/%s/releases", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/products/%s/releases", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product releases: %w", err)
 			}
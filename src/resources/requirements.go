@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterRequirementResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register requirement details resource template
 	s.AddResourceTemplate(
 		mcp.NewResourceTemplate("zentao://requirements/{requirementID}", "ZenTao Requirement Details"),
@@ -31,8 +32,8 @@ func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			requirementID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=view&t=json&storyID=%s", requirementID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=requirement&f=view&t=json&storyID=%s", requirementID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get requirement details: %w", err)
 			}
 
@@ -57,7 +58,7 @@ func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			requirementID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=linkStory&t=json&storyID=%s", requirementID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=linkStory&t=json&storyID=%s", requirementID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get requirement stories: %w", err)
 			}
@@ -83,7 +84,7 @@ func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			requirementID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=linkRequirements&t=json&storyID=%s", requirementID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=linkRequirements&t=json&storyID=%s", requirementID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get requirement linked requirements: %w", err)
 			}
@@ -109,7 +110,7 @@ func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			productID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=import&t=json&productID=%s", productID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=import&t=json&productID=%s", productID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get product requirements: %w", err)
 			}
@@ -135,7 +136,7 @@ func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			projectID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=import&t=json&projectID=%s", projectID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=import&t=json&projectID=%s", projectID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project requirements: %w", err)
 			}
@@ -161,7 +162,7 @@ func RegisterRequirementResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			executionID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=import&t=json&executionID=%s", executionID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=import&t=json&executionID=%s", executionID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get execution requirements: %w", err)
 			}
@@ -13,11 +13,47 @@
 package resources
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
 )
 
+// fakeAPI records GET paths; the embedded nil interface panics on anything else
+type fakeAPI struct {
+	client.ZenTaoAPI
+	paths []string
+	err   error
+}
+
+func (f *fakeAPI) GetCtx(ctx context.Context, path string) ([]byte, error) {
+	f.paths = append(f.paths, path)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte(`{"status":"success"}`), nil
+}
+
+// readResource sends resources/read through the server and returns the JSON-RPC response
+func readResource(t *testing.T, s *server.MCPServer, uri string) map[string]interface{} {
+	t.Helper()
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"` + uri + `"}}`
+	raw, err := json.Marshal(s.HandleMessage(context.Background(), []byte(message)))
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return response
+}
+
 func TestExtractIDFromURI(t *testing.T) {
 	tests := []struct {
 		uri          string
@@ -129,3 +165,39 @@ func BenchmarkResourceRegistration(b *testing.B) {
 		RegisterTestTaskResources(s, nil)
 	}
 }
+
+func TestProductResourceHandlers(t *testing.T) {
+	tests := []struct {
+		uri      string
+		wantPath string
+	}{
+		{"zentao://products", "/products"},
+		{"zentao://product/7", "/products/7"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.uri, func(t *testing.T) {
+			fake := &fakeAPI{}
+			s := server.NewMCPServer("test-server", "1.0.0", server.WithResourceCapabilities(true, true))
+			RegisterProductResources(s, fake)
+
+			response := readResource(t, s, test.uri)
+			if response["error"] != nil {
+				t.Fatalf("Unexpected error: %v", response["error"])
+			}
+			if len(fake.paths) != 1 || fake.paths[0] != test.wantPath {
+				t.Errorf("Expected GET %s, got %v", test.wantPath, fake.paths)
+			}
+
+			failing := &fakeAPI{err: errors.New("connection refused")}
+			s = server.NewMCPServer("test-server", "1.0.0", server.WithResourceCapabilities(true, true))
+			RegisterProductResources(s, failing)
+
+			response = readResource(t, s, test.uri)
+			rpcErr, _ := response["error"].(map[string]interface{})
+			if message, _ := rpcErr["message"].(string); !strings.Contains(message, "connection refused") {
+				t.Errorf("Expected upstream error in response, got %v", response)
+			}
+		})
+	}
+}
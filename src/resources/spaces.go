@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterSpaceResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterSpaceResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register spaces list resource
 	s.AddResource(mcp.NewResource(
 		"zentao://spaces",
@@ -27,8 +28,8 @@ func RegisterSpaceResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of all spaces in ZenTao"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=space&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=space&f=browse&t=json", &resp); err != nil {
 			return nil, fmt.Errorf("failed to get spaces: %w", err)
 		}
 		return []mcp.ResourceContents{
@@ -51,8 +52,8 @@ func RegisterSpaceResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			spaceID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=space&f=browse&t=json&spaceID=%s", spaceID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=space&f=browse&t=json&spaceID=%s", spaceID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get space details: %w", err)
 			}
 
@@ -77,8 +78,8 @@ func RegisterSpaceResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			spaceID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=space&f=browse&t=json&spaceID=%s", spaceID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=space&f=browse&t=json&spaceID=%s", spaceID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get space applications: %w", err)
 			}
 
@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterStakeholderResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterStakeholderResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Stakeholders list resource
 	stakeholdersResource := mcp.NewResource(
 		"zentao://stakeholders",
@@ -29,8 +30,8 @@ func RegisterStakeholderResources(s *server.MCPServer, client *client.ZenTaoClie
 	)
 
 	s.AddResource(stakeholdersResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=stakeholder&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=stakeholder&f=browse&t=json", &resp); err != nil {
 			return nil, fmt.Errorf("failed to get stakeholders list: %w", err)
 		}
 
@@ -55,8 +56,8 @@ func RegisterStakeholderResources(s *server.MCPServer, client *client.ZenTaoClie
 				return nil, fmt.Errorf("stakeholder ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=view&t=json&stakeholderID=%s", id))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=view&t=json&stakeholderID=%s", id), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get stakeholder details: %w", err)
 			}
 
@@ -83,8 +84,8 @@ func RegisterStakeholderResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			projectID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=browse&t=json&projectID=%s", projectID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=browse&t=json&projectID=%s", projectID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get project stakeholders: %w", err)
 			}
 
@@ -111,7 +112,7 @@ func RegisterStakeholderResources(s *server.MCPServer, client *client.ZenTaoClie
 			}
 			stakeholderID := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=userIssue&t=json&stakeholderID=%s", stakeholderID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=userIssue&t=json&stakeholderID=%s", stakeholderID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get stakeholder issues: %w", err)
 			}
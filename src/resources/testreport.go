@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestReportResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestReportResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	testReportResource := mcp.NewResourceTemplate(
 		"zentao://testreports/{reportID}",
 		"ZenTao Test Report",
@@ -31,8 +32,8 @@ func RegisterTestReportResources(s *server.MCPServer, client *client.ZenTaoClien
 			return nil, fmt.Errorf("invalid test report URI: %s", request.Params.URI)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testreport&f=view&t=json&reportID=%s", reportID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testreport&f=view&t=json&reportID=%s", reportID), &resp); err != nil {
 			return nil, fmt.Errorf("failed to get test report: %w", err)
 		}
 		return []mcp.ResourceContents{
@@ -59,7 +60,7 @@ func RegisterTestReportResources(s *server.MCPServer, client *client.ZenTaoClien
 		objectType := matches[1]
 		objectID := matches[2]
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testreport&f=browse&t=json&objectID=%s&objectType=%s", objectID, objectType), nil)
+		resp, err := client.PostCtx(retrySafe(ctx), fmt.Sprintf("/index.php?m=testreport&f=browse&t=json&objectID=%s&objectType=%s", objectID, objectType), nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get test reports: %w", err)
 		}
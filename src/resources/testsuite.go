@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestSuiteResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestSuiteResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	testSuiteResource := mcp.NewResourceTemplate(
 		"zentao://testsuites/{suiteID}",
 		"ZenTao Test Suite",
@@ -30,8 +31,8 @@ func RegisterTestSuiteResources(s *server.MCPServer, client *client.ZenTaoClient
 			return nil, fmt.Errorf("invalid test suite URI: %s", request.Params.URI)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testsuite&f=view&t=json&suiteID=%s", suiteID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testsuite&f=view&t=json&suiteID=%s", suiteID), &resp); err != nil {
 			return nil, fmt.Errorf("failed to get test suite: %w", err)
 		}
 		return []mcp.ResourceContents{
@@ -54,8 +55,8 @@ func RegisterTestSuiteResources(s *server.MCPServer, client *client.ZenTaoClient
 			return nil, fmt.Errorf("invalid product test suites URI: %s", request.Params.URI)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testsuite&f=browse&t=json&productID=%s", productID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testsuite&f=browse&t=json&productID=%s", productID), &resp); err != nil {
 			return nil, fmt.Errorf("failed to get product test suites: %w", err)
 		}
 		return []mcp.ResourceContents{
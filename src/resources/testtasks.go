@@ -22,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestTaskResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestTaskResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	testTaskListResource := mcp.NewResource(
 		"zentao://testtasks",
 		"ZenTao Test Task List",
@@ -31,7 +31,7 @@ func RegisterTestTaskResources(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddResource(testTaskListResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/testtasks")
+		resp, err := client.GetCtx(ctx, "/testtasks")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get test tasks: %w", err)
 		}
@@ -57,7 +57,7 @@ func RegisterTestTaskResources(s *server.MCPServer, client *client.ZenTaoClient)
 				return nil, fmt.Errorf("test task ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/testtasks/%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/testtasks/%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get test task details: %w", err)
 			}
@@ -85,7 +85,7 @@ func RegisterTestTaskResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/projects/%s/testtasks", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/projects/%s/testtasks", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get project test tasks: %w", err)
 			}
@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTodoResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTodoResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Todos list resource
 	todosResource := mcp.NewResource(
 		"zentao://todos",
@@ -29,7 +30,7 @@ func RegisterTodoResources(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddResource(todosResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=my&f=todo&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=my&f=todo&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get todos: %w", err)
 		}
@@ -55,8 +56,8 @@ func RegisterTodoResources(s *server.MCPServer, client *client.ZenTaoClient) {
 				return nil, fmt.Errorf("todo ID not found in URI: %s", uri)
 			}
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=view&t=json&todoID=%s", id))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=todo&f=view&t=json&todoID=%s", id), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get todo details: %w", err)
 			}
 
@@ -83,7 +84,7 @@ func RegisterTodoResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			id := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=ajaxGetDetail&t=json&todoID=%s", id))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=ajaxGetDetail&t=json&todoID=%s", id))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get todo AJAX details: %w", err)
 			}
@@ -111,7 +112,7 @@ func RegisterTodoResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			status := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=todo&t=json&status=%s", status))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=my&f=todo&t=json&status=%s", status))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get todos by status: %w", err)
 			}
@@ -139,7 +140,7 @@ func RegisterTodoResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			todoType := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=todo&t=json&type=%s", todoType))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=my&f=todo&t=json&type=%s", todoType))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get todos by type: %w", err)
 			}
@@ -19,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTransferResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTransferResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register supported modules for transfer resource
 	s.AddResource(mcp.NewResource(
 		"zentao://transfer/modules",
@@ -57,7 +57,7 @@ func RegisterTransferResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			module := parts[1]
 
-			resp, err := client.Post(fmt.Sprintf("/index.php?m=transfer&f=exportTemplate&t=json&module=%s", module), nil)
+			resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=transfer&f=exportTemplate&t=json&module=%s", module), nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get export template for module %s: %w", module, err)
 			}
@@ -83,7 +83,7 @@ func RegisterTransferResources(s *server.MCPServer, client *client.ZenTaoClient)
 			}
 			module := parts[1]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=transfer&f=import&t=json&module=%s", module))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=transfer&f=import&t=json&module=%s", module))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get import status for module %s: %w", module, err)
 			}
@@ -105,7 +105,7 @@ func RegisterTransferResources(s *server.MCPServer, client *client.ZenTaoClient)
 		mcp.WithResourceDescription("History of data exports"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=transfer&f=history&t=json&type=export")
+		resp, err := client.GetCtx(ctx, "/index.php?m=transfer&f=history&t=json&type=export")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get export history: %w", err)
 		}
@@ -125,7 +125,7 @@ func RegisterTransferResources(s *server.MCPServer, client *client.ZenTaoClient)
 		mcp.WithResourceDescription("History of data imports"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=transfer&f=history&t=json&type=import")
+		resp, err := client.GetCtx(ctx, "/index.php?m=transfer&f=history&t=json&type=import")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get import history: %w", err)
 		}
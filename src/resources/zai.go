@@ -18,7 +18,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterZaiResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterZaiResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register ZAI settings resource
 	s.AddResource(mcp.NewResource(
 		"zentao://zai/settings",
@@ -26,7 +26,7 @@ func RegisterZaiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Current ZenTao AI module configuration and settings"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=zai&f=setting&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=zai&f=setting&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get ZAI settings: %w", err)
 		}
@@ -46,7 +46,7 @@ func RegisterZaiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Current authentication token for AI services"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=zai&f=ajaxGetToken&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=zai&f=ajaxGetToken&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get ZAI token: %w", err)
 		}
@@ -66,7 +66,7 @@ func RegisterZaiResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Current vectorization status and progress"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=zai&f=vectorized&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=zai&f=vectorized&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get vectorization status: %w", err)
 		}
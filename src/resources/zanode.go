@@ -11,6 +11,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterZanodeResources(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Register zanode instructions resource
 	s.AddResource(mcp.NewResource(
 		"zentao://zanode/instructions",
@@ -27,7 +28,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("Instructions for ZenTao Node management"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=zanode&f=instruction&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=zanode&f=instruction&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get zanode instructions: %w", err)
 		}
@@ -47,7 +48,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 		mcp.WithResourceDescription("List of all available ZenTao nodes"),
 		mcp.WithMIMEType("application/json"),
 	), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		resp, err := client.Get("/index.php?m=zanode&f=ajaxGetNodes&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=zanode&f=ajaxGetNodes&t=json")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get nodes: %w", err)
 		}
@@ -71,8 +72,8 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			nodeID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=view&t=json&id=%s", nodeID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=view&t=json&id=%s", nodeID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get node %s: %w", nodeID, err)
 			}
 
@@ -97,7 +98,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			nodeID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=getVNC&t=json&nodeID=%s", nodeID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=getVNC&t=json&nodeID=%s", nodeID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get VNC for node %s: %w", nodeID, err)
 			}
@@ -123,8 +124,8 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			nodeID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=browseSnapshot&t=json&nodeID=%s", nodeID))
-			if err != nil {
+			var resp json.RawMessage
+			if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=browseSnapshot&t=json&nodeID=%s", nodeID), &resp); err != nil {
 				return nil, fmt.Errorf("failed to get snapshots for node %s: %w", nodeID, err)
 			}
 
@@ -149,7 +150,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			nodeID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetTaskStatus&t=json&nodeID=%s", nodeID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetTaskStatus&t=json&nodeID=%s", nodeID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get tasks for node %s: %w", nodeID, err)
 			}
@@ -175,7 +176,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			hostID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=nodeList&t=json&hostID=%s", hostID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=nodeList&t=json&hostID=%s", hostID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get nodes for host %s: %w", hostID, err)
 			}
@@ -201,7 +202,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			hostID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImages&t=json&hostID=%s", hostID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImages&t=json&hostID=%s", hostID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get images for host %s: %w", hostID, err)
 			}
@@ -227,7 +228,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			hostID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetServiceStatus&t=json&hostID=%s", hostID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetServiceStatus&t=json&hostID=%s", hostID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get service status for host %s: %w", hostID, err)
 			}
@@ -253,7 +254,7 @@ func RegisterZanodeResources(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 			imageID := parts[2]
 
-			resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImage&t=json&imageID=%s", imageID))
+			resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImage&t=json&imageID=%s", imageID))
 			if err != nil {
 				return nil, fmt.Errorf("failed to get image %s: %w", imageID, err)
 			}
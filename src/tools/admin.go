@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -21,20 +22,20 @@ import (
 )
 
 // RegisterAdminTools registers all company, department, group, and user management tools
-func RegisterAdminTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterAdminTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	registerCompanyTools(s, client)
 	registerDepartmentTools(s, client)
 	registerGroupTools(s, client)
 	registerUserManagementTools(s, client)
 }
 
-func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerCompanyTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	companyIndexTool := mcp.NewTool("company_index",
 		mcp.WithDescription("Get company index"),
 	)
 
 	s.AddTool(companyIndexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=company&f=index&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=company&f=index&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get company index: %v", err)), nil
 		}
@@ -54,32 +55,32 @@ func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 	s.AddTool(companyBrowseTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
-		params := make(map[string]interface{})
 
+		queryParams := ""
 		if v, ok := args["browseType"].(string); ok {
-			params["browseType"] = v
+			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 		if v, ok := args["param"]; ok && v != nil {
-			params["param"] = v
+			queryParams += fmt.Sprintf("&param=%v", v)
 		}
 		if v, ok := args["type"].(string); ok {
-			params["type"] = v
+			queryParams += fmt.Sprintf("&type=%s", v)
 		}
 		if v, ok := args["orderBy"].(string); ok {
-			params["orderBy"] = v
+			queryParams += fmt.Sprintf("&orderBy=%s", v)
 		}
 		if v, ok := args["recTotal"]; ok && v != nil {
-			params["recTotal"] = int(v.(float64))
+			queryParams += fmt.Sprintf("&recTotal=%d", int(v.(float64)))
 		}
 		if v, ok := args["recPerPage"]; ok && v != nil {
-			params["recPerPage"] = int(v.(float64))
+			queryParams += fmt.Sprintf("&recPerPage=%d", int(v.(float64)))
 		}
 		if v, ok := args["pageID"]; ok && v != nil {
-			params["pageID"] = int(v.(float64))
+			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get("/index.php?m=company&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=company&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse companies: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -90,7 +91,7 @@ func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(companyEditTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=company&f=edit&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=company&f=edit&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit company: %v", err)), nil
 		}
@@ -102,8 +103,8 @@ func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(companyViewTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=company&f=view&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=company&f=view&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view company: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -157,7 +158,7 @@ func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["executionID"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=company&f=dynamic&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=company&f=dynamic&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get company dynamic: %v", err)), nil
 		}
@@ -169,7 +170,7 @@ func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(companyAjaxGetOutsideTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=company&f=ajaxGetOutsideCompany&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=company&f=ajaxGetOutsideCompany&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get outside companies: %v", err)), nil
 		}
@@ -177,7 +178,7 @@ func registerCompanyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDepartmentTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	deptBrowseTool := mcp.NewTool("dept_browse",
 		mcp.WithDescription("Browse departments"),
 		mcp.WithNumber("deptID", mcp.Description("Department ID")),
@@ -191,7 +192,7 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&deptID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse departments: %v", err)), nil
 		}
@@ -203,7 +204,7 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(deptUpdateOrderTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=dept&f=updateOrder&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=dept&f=updateOrder&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update department order: %v", err)), nil
 		}
@@ -215,7 +216,7 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(deptManageChildTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=dept&f=manageChild&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=dept&f=manageChild&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage child departments: %v", err)), nil
 		}
@@ -235,7 +236,7 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&deptID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(url, nil)
+		resp, err := client.PostCtx(ctx, url, nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit department: %v", err)), nil
 		}
@@ -254,7 +255,7 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		deptID := int(args["deptID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=dept&f=delete&t=json&deptID=%d", deptID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=dept&f=delete&t=json&deptID=%d", deptID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete department: %v", err)), nil
 		}
@@ -285,7 +286,7 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["key"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=dept&f=ajaxGetUsers&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=dept&f=ajaxGetUsers&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get department users: %v", err)), nil
 		}
@@ -293,14 +294,14 @@ func registerDepartmentTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerGroupTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	groupBrowseTool := mcp.NewTool("group_browse",
 		mcp.WithDescription("Browse groups"),
 	)
 
 	s.AddTool(groupBrowseTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=group&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=group&f=browse&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse groups: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -311,7 +312,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(groupCreateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=group&f=create&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=group&f=create&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create group: %v", err)), nil
 		}
@@ -331,7 +332,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&groupID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(url, nil)
+		resp, err := client.PostCtx(ctx, url, nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit group: %v", err)), nil
 		}
@@ -351,7 +352,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&groupID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(url, nil)
+		resp, err := client.PostCtx(ctx, url, nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to copy group: %v", err)), nil
 		}
@@ -371,7 +372,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&groupID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage group view: %v", err)), nil
 		}
@@ -406,7 +407,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["version"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=group&f=managePriv&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=group&f=managePriv&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage group privileges: %v", err)), nil
 		}
@@ -430,7 +431,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&deptID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(url, nil)
+		resp, err := client.PostCtx(ctx, url, nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage group members: %v", err)), nil
 		}
@@ -454,7 +455,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&deptID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(url, nil)
+		resp, err := client.PostCtx(ctx, url, nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage project admins: %v", err)), nil
 		}
@@ -473,7 +474,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		groupID := int(args["groupID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=group&f=delete&t=json&groupID=%d", groupID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=group&f=delete&t=json&groupID=%d", groupID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete group: %v", err)), nil
 		}
@@ -497,7 +498,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["selectedPackages"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=group&f=ajaxGetPrivByParents&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=group&f=ajaxGetPrivByParents&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get privileges by parent: %v", err)), nil
 		}
@@ -509,7 +510,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(groupAjaxGetRelatedPrivsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=group&f=ajaxGetRelatedPrivs&t=json", nil)
+		resp, err := client.PostCtx(retrySafe(ctx), "/index.php?m=group&f=ajaxGetRelatedPrivs&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get related privileges: %v", err)), nil
 		}
@@ -517,7 +518,7 @@ func registerGroupTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerUserManagementTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	userViewTool := mcp.NewTool("admin_user_view",
 		mcp.WithDescription("View user details"),
 		mcp.WithNumber("userID",
@@ -530,8 +531,8 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		userID := int(args["userID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=user&f=view&t=json&userID=%d", userID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=user&f=view&t=json&userID=%d", userID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view user: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -577,7 +578,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=todo&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=todo&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user todos: %v", err)), nil
 		}
@@ -621,7 +622,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=story&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=story&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user stories: %v", err)), nil
 		}
@@ -661,7 +662,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=task&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=task&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user tasks: %v", err)), nil
 		}
@@ -701,7 +702,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=bug&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=bug&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user bugs: %v", err)), nil
 		}
@@ -737,7 +738,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=testtask&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=testtask&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user test tasks: %v", err)), nil
 		}
@@ -777,7 +778,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=testcase&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=testcase&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user test cases: %v", err)), nil
 		}
@@ -813,7 +814,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=execution&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=execution&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user executions: %v", err)), nil
 		}
@@ -853,7 +854,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=issue&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=issue&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user issues: %v", err)), nil
 		}
@@ -893,7 +894,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=risk&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=risk&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user risks: %v", err)), nil
 		}
@@ -913,7 +914,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			url += fmt.Sprintf("&userID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user profile: %v", err)), nil
 		}
@@ -937,7 +938,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=user&f=create&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=create&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create user: %v", err)), nil
 		}
@@ -961,7 +962,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=user&f=batchCreate&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=batchCreate&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create users: %v", err)), nil
 		}
@@ -981,7 +982,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			url += fmt.Sprintf("&userID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(url, nil)
+		resp, err := client.PostCtx(ctx, url, nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit user: %v", err)), nil
 		}
@@ -1005,7 +1006,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=user&f=batchEdit&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=batchEdit&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit users: %v", err)), nil
 		}
@@ -1024,7 +1025,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		userID := int(args["userID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=user&f=delete&t=json&userID=%d", userID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=user&f=delete&t=json&userID=%d", userID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete user: %v", err)), nil
 		}
@@ -1043,7 +1044,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		userID := int(args["userID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=user&f=unlock&t=json&userID=%d", userID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=user&f=unlock&t=json&userID=%d", userID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlock user: %v", err)), nil
 		}
@@ -1062,7 +1063,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		userID := int(args["userID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=user&f=unbind&t=json&userID=%d", userID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=user&f=unbind&t=json&userID=%d", userID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unbind user: %v", err)), nil
 		}
@@ -1082,7 +1083,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["referer"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=login&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=login&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to login: %v", err)), nil
 		}
@@ -1110,7 +1111,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["referer"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=deny&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=deny&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to deny user: %v", err)), nil
 		}
@@ -1130,7 +1131,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["referer"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=logout&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=logout&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to logout: %v", err)), nil
 		}
@@ -1142,7 +1143,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	)
 
 	s.AddTool(userResetTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=user&f=reset&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=reset&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to reset user: %v", err)), nil
 		}
@@ -1154,7 +1155,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	)
 
 	s.AddTool(userForgetPasswordTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=user&f=forgetPassword&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=forgetPassword&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to forget password: %v", err)), nil
 		}
@@ -1174,7 +1175,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["code"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=user&f=resetPassword&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=resetPassword&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to reset password: %v", err)), nil
 		}
@@ -1213,7 +1214,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["direction"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=dynamic&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=dynamic&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user dynamic: %v", err)), nil
 		}
@@ -1232,7 +1233,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		imageID := int(args["imageID"].(float64))
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=user&f=cropAvatar&t=json&imageID=%d", imageID), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=user&f=cropAvatar&t=json&imageID=%d", imageID), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to crop avatar: %v", err)), nil
 		}
@@ -1259,7 +1260,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["dropdownName"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=ajaxGetOldContactUsers&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=ajaxGetOldContactUsers&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get old contact users: %v", err)), nil
 		}
@@ -1279,7 +1280,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			url += fmt.Sprintf("&contactListID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get contact users: %v", err)), nil
 		}
@@ -1291,7 +1292,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	)
 
 	s.AddTool(userAjaxGetContactListTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=user&f=ajaxGetContactList&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=ajaxGetContactList&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get contact list: %v", err)), nil
 		}
@@ -1311,7 +1312,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			url += fmt.Sprintf("&dropdownName=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get old contact list: %v", err)), nil
 		}
@@ -1331,7 +1332,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["params"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=ajaxGetItems&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=ajaxGetItems&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get items: %v", err)), nil
 		}
@@ -1355,7 +1356,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["type"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=user&f=ajaxGetTemplates&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=ajaxGetTemplates&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get templates: %v", err)), nil
 		}
@@ -1379,7 +1380,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=user&f=ajaxSaveTemplate&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=ajaxSaveTemplate&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save template: %v", err)), nil
 		}
@@ -1398,7 +1399,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		templateID := int(args["templateID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=user&f=ajaxDeleteTemplate&t=json&templateID=%d", templateID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=user&f=ajaxDeleteTemplate&t=json&templateID=%d", templateID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete template: %v", err)), nil
 		}
@@ -1410,7 +1411,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	)
 
 	s.AddTool(userAjaxGetMoreTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=user&f=ajaxGetMore&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=ajaxGetMore&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get more data: %v", err)), nil
 		}
@@ -1433,7 +1434,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			url += fmt.Sprintf("&visions=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get groups: %v", err)), nil
 		}
@@ -1445,7 +1446,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	)
 
 	s.AddTool(userRefreshRandomTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=user&f=refreshRandom&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=user&f=refreshRandom&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to refresh random: %v", err)), nil
 		}
@@ -1469,7 +1470,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			url += fmt.Sprintf("&link=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get print templates: %v", err)), nil
 		}
@@ -1489,7 +1490,7 @@ func registerUserManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=user&f=ajaxSaveOldTemplate&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=user&f=ajaxSaveOldTemplate&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save old template: %v", err)), nil
 		}
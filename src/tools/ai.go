@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,15 +19,15 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterAiTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Mini Programs Tools
 	getAiAdminIndexTool := mcp.NewTool("get_ai_admin_index",
 		mcp.WithDescription("Get AI module admin interface overview"),
 	)
 
 	s.AddTool(getAiAdminIndexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=ai&f=adminIndex&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=ai&f=adminIndex&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get AI admin index: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -77,8 +78,8 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=miniPrograms&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=ai&f=miniPrograms&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get mini programs: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -99,7 +100,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"category_data": args["category_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=ai&f=editMiniProgramCategory&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=ai&f=editMiniProgramCategory&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit mini program category: %v", err)), nil
 		}
@@ -117,7 +118,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(publishMiniProgramTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=publishMiniProgram&t=json&appID=%s", args["appID"]))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=publishMiniProgram&t=json&appID=%s", args["appID"]))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to publish mini program: %v", err)), nil
 		}
@@ -135,7 +136,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unpublishMiniProgramTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=unpublishMiniProgram&t=json&appID=%s", args["appID"]))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=unpublishMiniProgram&t=json&appID=%s", args["appID"]))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unpublish mini program: %v", err)), nil
 		}
@@ -157,7 +158,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"import_data": args["import_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=ai&f=importMiniProgram&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=ai&f=importMiniProgram&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import mini program: %v", err)), nil
 		}
@@ -210,8 +211,8 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=prompts&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=ai&f=prompts&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get prompts: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -228,8 +229,8 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getPromptViewTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptView&t=json&id=%d", int(args["id"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=ai&f=promptView&t=json&id=%d", int(args["id"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get prompt view: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -250,7 +251,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"prompt_data": args["prompt_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=ai&f=createPrompt&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=ai&f=createPrompt&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create prompt: %v", err)), nil
 		}
@@ -277,7 +278,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"prompt_data": args["prompt_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptEdit&t=json&id=%d", int(args["id"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptEdit&t=json&id=%d", int(args["id"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit prompt: %v", err)), nil
 		}
@@ -295,7 +296,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deletePromptTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptDelete&t=json&prompt=%d", int(args["prompt"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptDelete&t=json&prompt=%d", int(args["prompt"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete prompt: %v", err)), nil
 		}
@@ -321,7 +322,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"role_data": args["role_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptAssignRole&t=json&promptID=%d", int(args["promptID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptAssignRole&t=json&promptID=%d", int(args["promptID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign prompt role: %v", err)), nil
 		}
@@ -347,7 +348,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"data_source": args["data_source"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptSelectDataSource&t=json&promptID=%d", int(args["promptID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptSelectDataSource&t=json&promptID=%d", int(args["promptID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to select prompt data source: %v", err)), nil
 		}
@@ -373,7 +374,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"purpose": args["purpose"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptSetPurpose&t=json&promptID=%d", int(args["promptID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptSetPurpose&t=json&promptID=%d", int(args["promptID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to set prompt purpose: %v", err)), nil
 		}
@@ -399,7 +400,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"target_form": args["target_form"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptSetTargetForm&t=json&promptID=%d", int(args["promptID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptSetTargetForm&t=json&promptID=%d", int(args["promptID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to set prompt target form: %v", err)), nil
 		}
@@ -425,7 +426,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["final_config"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptFinalize&t=json&promptID=%d", int(args["promptID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptFinalize&t=json&promptID=%d", int(args["promptID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to finalize prompt: %v", err)), nil
 		}
@@ -459,7 +460,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptExecute&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptExecute&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to execute prompt: %v", err)), nil
 		}
@@ -485,7 +486,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptExecutionReset&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptExecutionReset&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to reset prompt execution: %v", err)), nil
 		}
@@ -527,7 +528,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=ai&f=promptAudit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptAudit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to audit prompt: %v", err)), nil
 		}
@@ -557,7 +558,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			}
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptPublish&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptPublish&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to publish prompt: %v", err)), nil
 		}
@@ -575,7 +576,7 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unpublishPromptTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=promptUnpublish&t=json&id=%d", int(args["id"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=ai&f=promptUnpublish&t=json&id=%d", int(args["id"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unpublish prompt: %v", err)), nil
 		}
@@ -607,8 +608,8 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&targetForm=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=ai&f=ajaxGetTestingLocation&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=ai&f=ajaxGetTestingLocation&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get testing location: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -629,8 +630,8 @@ func RegisterAiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["template_data"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=ai&f=roleTemplates&t=json", body)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.PostJSON(retrySafe(ctx), "/index.php?m=ai&f=roleTemplates&t=json", body, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get role templates: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -21,7 +21,7 @@ import (
 )
 
 // RegisterAiappTools registers all AI app-related tools
-func RegisterAiappTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterAiappTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// AI App view and browse
 	registerAiappViewTools(s, client)
 	// Mini program chat
@@ -34,7 +34,7 @@ func RegisterAiappTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	registerConversationTools(s, client)
 }
 
-func registerAiappViewTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerAiappViewTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	viewTool := mcp.NewTool("aiapp_view",
 		mcp.WithDescription("View AI app"),
 		mcp.WithString("id", mcp.Description("App ID")),
@@ -48,7 +48,7 @@ func registerAiappViewTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&id=%v", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view AI app: %v", err)), nil
 		}
@@ -56,7 +56,7 @@ func registerAiappViewTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerMiniProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerMiniProgramTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseMiniProgramTool := mcp.NewTool("aiapp_browse_mini_program",
 		mcp.WithDescription("Browse AI mini programs"),
 		mcp.WithString("id", mcp.Description("ID filter")),
@@ -70,7 +70,7 @@ func registerMiniProgramTools(s *server.MCPServer, client *client.ZenTaoClient)
 			url += fmt.Sprintf("&id=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse mini programs: %v", err)), nil
 		}
@@ -90,7 +90,7 @@ func registerMiniProgramTools(s *server.MCPServer, client *client.ZenTaoClient)
 			url += fmt.Sprintf("&id=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start mini program chat: %v", err)), nil
 		}
@@ -114,7 +114,7 @@ func registerMiniProgramTools(s *server.MCPServer, client *client.ZenTaoClient)
 			url += fmt.Sprintf("&delete=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to collect mini program: %v", err)), nil
 		}
@@ -122,7 +122,7 @@ func registerMiniProgramTools(s *server.MCPServer, client *client.ZenTaoClient)
 	})
 }
 
-func registerSquareTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerSquareTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	squareTool := mcp.NewTool("aiapp_square",
 		mcp.WithDescription("Browse AI app square"),
 		mcp.WithString("category", mcp.Description("Category filter")),
@@ -148,7 +148,7 @@ func registerSquareTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=aiapp&f=square&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=aiapp&f=square&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse square: %v", err)), nil
 		}
@@ -156,13 +156,13 @@ func registerSquareTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerModelTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerModelTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	modelsTool := mcp.NewTool("aiapp_models",
 		mcp.WithDescription("Get AI models"),
 	)
 
 	s.AddTool(modelsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=aiapp&f=models&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=aiapp&f=models&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get AI models: %v", err)), nil
 		}
@@ -170,7 +170,7 @@ func registerModelTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerConversationTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerConversationTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	conversationTool := mcp.NewTool("aiapp_conversation",
 		mcp.WithDescription("AI app conversation"),
 		mcp.WithString("chat",
@@ -192,7 +192,7 @@ func registerConversationTools(s *server.MCPServer, client *client.ZenTaoClient)
 			params["params"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=aiapp&f=conversation&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=aiapp&f=conversation&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start conversation: %v", err)), nil
 		}
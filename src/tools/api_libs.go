@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterApiLibTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createApiLibTool := mcp.NewTool("create_api_lib",
 		mcp.WithDescription("Create a new API library in ZenTao"),
 		mcp.WithString("type",
@@ -63,7 +64,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&desc=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=createLib&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=createLib&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create API library: %v", err)), nil
 		}
@@ -106,7 +107,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&desc=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=editLib&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=editLib&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit API library: %v", err)), nil
 		}
@@ -126,7 +127,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		libID := int(args["libID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=deleteLib&t=json&libID=%d", libID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=deleteLib&t=json&libID=%d", libID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete API library: %v", err)), nil
 		}
@@ -154,8 +155,8 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&orderBy=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=releases&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=api&f=releases&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get API library releases: %v", err)), nil
 		}
 
@@ -206,7 +207,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&version=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=createRelease&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=createRelease&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create API library release: %v", err)), nil
 		}
@@ -231,7 +232,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		libID := int(args["libID"].(float64))
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=deleteRelease&t=json&libID=%d&id=%d", libID, id))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=deleteRelease&t=json&libID=%d&id=%d", libID, id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete API library release: %v", err)), nil
 		}
@@ -283,8 +284,8 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=struct&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=api&f=struct&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get API library structures: %v", err)), nil
 		}
 
@@ -341,7 +342,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&desc=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=createStruct&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=createStruct&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create API library structure: %v", err)), nil
 		}
@@ -403,7 +404,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&desc=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=editStruct&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=editStruct&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit API library structure: %v", err)), nil
 		}
@@ -428,7 +429,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		libID := int(args["libID"].(float64))
 		structID := int(args["structID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=deleteStruct&t=json&libID=%d&structID=%d", libID, structID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=deleteStruct&t=json&libID=%d&structID=%d", libID, structID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete API library structure: %v", err)), nil
 		}
@@ -515,7 +516,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&path=%s", url.QueryEscape(v.(string)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=create&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=create&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create API: %v", err)), nil
 		}
@@ -589,7 +590,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&path=%s", url.QueryEscape(v.(string)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=api&f=edit&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=api&f=edit&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit API: %v", err)), nil
 		}
@@ -609,7 +610,7 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		apiID := int(args["apiID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=delete&t=json&apiID=%d", apiID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=api&f=delete&t=json&apiID=%d", apiID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete API: %v", err)), nil
 		}
@@ -660,8 +661,8 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=view&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=api&f=view&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get API: %v", err)), nil
 		}
 
@@ -759,8 +760,8 @@ func RegisterApiLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=api&f=index&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=api&f=index&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get APIs: %v", err)), nil
 		}
 
@@ -22,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/logger"
 )
 
-func RegisterAuthTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterAuthTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Handle nil client for testing
 	if client == nil {
 		logger.Warn("tools", "Nil client provided to RegisterAuthTools", nil)
@@ -78,8 +78,8 @@ func RegisterAuthTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			client.SetAppCredentials(code, key)
 
 			logger.Info("auth", "App credentials set successfully", map[string]interface{}{
-				"client_has_code": client.Code != "",
-				"client_has_key": client.Key != "",
+				"client_has_code": code != "",
+				"client_has_key": key != "",
 			})
 
 			result := mcp.NewToolResultText("Successfully set app credentials. The client will now use app-based authentication for all API calls.")
@@ -100,7 +100,7 @@ func RegisterAuthTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		// Session login tool
 		sessionLoginTool := mcp.NewTool("zentao_login_session",
-			mcp.WithDescription("Login to ZenTao with username/password using session authentication"),
+			mcp.WithDescription("Login to ZenTao with username/password using session authentication (not needed when ZENTAO_ACCOUNT/ZENTAO_PASSWORD are set)"),
 			mcp.WithString("account",
 				mcp.Required(),
 				mcp.Description("ZenTao username/account"),
@@ -131,18 +131,8 @@ func RegisterAuthTools(s *server.MCPServer, client *client.ZenTaoClient) {
 				"account": account,
 			})
 
-			// Step 1: Get session ID
-			logger.Debug("auth", "Getting session ID", nil)
-			if err := client.GetSessionID(); err != nil {
-				logger.Error("auth", "Failed to get session ID", err, nil)
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to get session ID: %v", err)), nil
-			}
-
-			// Step 2: Login with credentials
-			logger.Debug("auth", "Performing user login", map[string]interface{}{
-				"account": account,
-			})
-			if err := client.Login(account, password); err != nil {
+			// Get a session ID and log in; credentials are kept for automatic re-login
+			if err := client.LoginSessionCtx(ctx, account, password); err != nil {
 				logger.Error("auth", "Login failed", err, map[string]interface{}{
 					"account": account,
 				})
@@ -157,6 +147,51 @@ func RegisterAuthTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			return mcp.NewToolResultText("Session authentication successful. You can now use other ZenTao tools."), nil
 		})
 
+	case 3: // AuthToken
+		// Token-based authentication via POST /tokens
+		toolCount = 1
+		logger.Debug("tools", "Registering token-based auth tools", map[string]interface{}{
+			"tool_count": toolCount,
+		})
+
+		tokenLoginTool := mcp.NewTool("zentao_login_token",
+			mcp.WithDescription("Login to ZenTao with username/password and obtain an API token (not needed when ZENTAO_ACCOUNT/ZENTAO_PASSWORD are set)"),
+			mcp.WithString("account",
+				mcp.Required(),
+				mcp.Description("ZenTao username/account"),
+			),
+			mcp.WithString("password",
+				mcp.Required(),
+				mcp.Description("ZenTao password"),
+			),
+		)
+
+		s.AddTool(tokenLoginTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := request.GetArguments()
+			account := args["account"].(string)
+			password := args["password"].(string)
+
+			logger.LogMCPToolCall("zentao_login_token", map[string]interface{}{
+				"account":      account,
+				"has_password": password != "",
+			})
+
+			// Fetch a token; credentials are kept to fetch a new one after a 401
+			if err := client.LoginTokenCtx(ctx, account, password); err != nil {
+				logger.Error("auth", "Token login failed", err, map[string]interface{}{
+					"account": account,
+				})
+				return mcp.NewToolResultError(fmt.Sprintf("Login failed: %v", err)), nil
+			}
+
+			logger.Info("auth", "Token login successful", map[string]interface{}{
+				"account":          account,
+				"is_authenticated": client.IsAuthenticated(),
+			})
+
+			return mcp.NewToolResultText("Token authentication successful. You can now use other ZenTao tools."), nil
+		})
+
 	default:
 		// No authentication or unknown method
 		logger.Warn("tools", "No authentication tools registered", map[string]interface{}{
@@ -13,47 +13,233 @@
 package tools
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
 )
 
-// Mock ZenTao client for testing (simplified interface)
-type mockZenTaoClient struct{}
+// recordedCall is one request seen by mockZenTaoClient
+type recordedCall struct {
+	Method string
+	Path   string
+	Body   interface{}
+}
+
+// mockZenTaoClient implements client.ZenTaoAPI, records every request and
+// answers with response (or a per-method default) unless err is set
+type mockZenTaoClient struct {
+	calls      []recordedCall
+	response   []byte
+	err        error
+	authMethod client.AuthMethod
+
+	code, key         string
+	account, password string
+}
+
+var _ client.ZenTaoAPI = (*mockZenTaoClient)(nil)
+
+func (m *mockZenTaoClient) do(method, path string, body interface{}, fallback string) ([]byte, error) {
+	m.calls = append(m.calls, recordedCall{Method: method, Path: path, Body: body})
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.response != nil {
+		return m.response, nil
+	}
+	return []byte(fallback), nil
+}
 
 func (m *mockZenTaoClient) Get(path string) ([]byte, error) {
-	return []byte(`{"status": "success"}`), nil
+	return m.GetCtx(context.Background(), path)
 }
 
 func (m *mockZenTaoClient) Post(path string, body interface{}) ([]byte, error) {
-	return []byte(`{"status": "success", "id": 123}`), nil
+	return m.PostCtx(context.Background(), path, body)
 }
 
 func (m *mockZenTaoClient) Put(path string, body interface{}) ([]byte, error) {
-	return []byte(`{"status": "success"}`), nil
+	return m.PutCtx(context.Background(), path, body)
 }
 
 func (m *mockZenTaoClient) Delete(path string) ([]byte, error) {
-	return []byte(`{"status": "success"}`), nil
+	return m.DeleteCtx(context.Background(), path)
+}
+
+func (m *mockZenTaoClient) GetCtx(ctx context.Context, path string) ([]byte, error) {
+	return m.do("GET", path, nil, `{"status": "success"}`)
+}
+
+func (m *mockZenTaoClient) PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	return m.do("POST", path, body, `{"status": "success", "id": 123}`)
+}
+
+func (m *mockZenTaoClient) PutCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	return m.do("PUT", path, body, `{"status": "success"}`)
+}
+
+func (m *mockZenTaoClient) DeleteCtx(ctx context.Context, path string) ([]byte, error) {
+	return m.do("DELETE", path, nil, `{"status": "success"}`)
+}
+
+func (m *mockZenTaoClient) GetJSON(ctx context.Context, path string, out interface{}) error {
+	resp, err := m.GetCtx(ctx, path)
+	if err != nil {
+		return err
+	}
+	return client.DecodeResponse(resp, out)
 }
 
-func (m *mockZenTaoClient) GetAuthMethod() int {
-	return 1 // AuthApp by default for testing
+func (m *mockZenTaoClient) PostJSON(ctx context.Context, path string, body interface{}, out interface{}) error {
+	resp, err := m.PostCtx(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	return client.DecodeResponse(resp, out)
+}
+
+func (m *mockZenTaoClient) GetAuthMethod() client.AuthMethod {
+	return m.authMethod
 }
 
 func (m *mockZenTaoClient) IsAuthenticated() bool {
-	return true
+	return m.account != "" || m.code != ""
 }
 
-func (m *mockZenTaoClient) GetSessionID() error {
-	return nil
+func (m *mockZenTaoClient) SetAppCredentials(code, key string) {
+	m.code, m.key = code, key
 }
 
-func (m *mockZenTaoClient) Login(account, password string) error {
+func (m *mockZenTaoClient) GetSessionIDCtx(ctx context.Context) error {
+	return m.err
+}
+
+func (m *mockZenTaoClient) LoginCtx(ctx context.Context, account, password string) error {
+	return m.LoginSessionCtx(ctx, account, password)
+}
+
+func (m *mockZenTaoClient) LoginSessionCtx(ctx context.Context, account, password string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.account, m.password = account, password
 	return nil
 }
 
-func (m *mockZenTaoClient) SetSessionCredentials(sessionName, sessionID string) {}
+func (m *mockZenTaoClient) LoginTokenCtx(ctx context.Context, account, password string) error {
+	return m.LoginSessionCtx(ctx, account, password)
+}
+
+// callTool invokes a registered tool handler directly
+func callTool(t *testing.T, s *server.MCPServer, name string, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+
+	tool := s.GetTool(name)
+	if tool == nil {
+		t.Fatalf("Tool %s is not registered", name)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+
+	result, err := tool.Handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Tool %s returned a protocol error: %v", name, err)
+	}
+	return result
+}
+
+// resultText returns the text of a single-content tool result
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+
+	if len(result.Content) != 1 {
+		t.Fatalf("Expected one content item, got %d", len(result.Content))
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("Expected text content, got %T", result.Content[0])
+	}
+	return text.Text
+}
+
+// handlerCase describes one table-driven tool invocation against mockZenTaoClient
+type handlerCase struct {
+	tool       string
+	args       map[string]interface{}
+	wantMethod string
+	wantPath   string
+	wantBody   map[string]interface{} // nil skips the body check
+}
+
+// handlerEnvelope is the response runHandlerCases serves: a legacy envelope
+// whose double-encoded data read tools must unwrap and write tools pass through
+const handlerEnvelope = `{"status":"success","data":"{\"ok\":true}"}`
+
+// runHandlerCases checks the request each tool sends, the response it returns
+// and that client errors surface as tool errors carrying the upstream message
+func runHandlerCases(t *testing.T, register func(*server.MCPServer, client.ZenTaoAPI), cases []handlerCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.tool, func(t *testing.T) {
+			mock := &mockZenTaoClient{response: []byte(handlerEnvelope)}
+			s := server.NewMCPServer("test-server", "1.0.0")
+			register(s, mock)
+
+			result := callTool(t, s, tc.tool, tc.args)
+			if result.IsError {
+				t.Fatalf("Unexpected tool error: %s", resultText(t, result))
+			}
+			want := handlerEnvelope
+			if isReadTool(tc.tool) {
+				want = `{"ok":true}`
+			}
+			if text := resultText(t, result); text != want {
+				t.Errorf("Expected %s, got %s", want, text)
+			}
+
+			if len(mock.calls) != 1 {
+				t.Fatalf("Expected exactly one request, got %d", len(mock.calls))
+			}
+			call := mock.calls[0]
+			if call.Method != tc.wantMethod || call.Path != tc.wantPath {
+				t.Errorf("Expected %s %s, got %s %s", tc.wantMethod, tc.wantPath, call.Method, call.Path)
+			}
+			if tc.wantBody != nil && !reflect.DeepEqual(call.Body, tc.wantBody) {
+				t.Errorf("Expected body %v, got %v", tc.wantBody, call.Body)
+			}
+
+			failing := &mockZenTaoClient{err: errors.New("ZenTao not found on GET /x: no such record")}
+			s = server.NewMCPServer("test-server", "1.0.0")
+			register(s, failing)
+
+			result = callTool(t, s, tc.tool, tc.args)
+			if !result.IsError {
+				t.Fatal("Expected client error to produce a tool error")
+			}
+			if text := resultText(t, result); !strings.HasPrefix(text, "Failed to ") || !strings.Contains(text, "no such record") {
+				t.Errorf("Expected mapped error message, got %q", text)
+			}
+		})
+	}
+}
+
+func isReadTool(name string) bool {
+	for _, prefix := range []string{"get_", "browse_", "view_"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
 
 func TestRegisterAuthTools(t *testing.T) {
 	// Test that registration doesn't panic
@@ -140,3 +326,71 @@ func BenchmarkAuthToolList(b *testing.B) {
 		_ = s.ListTools()
 	}
 }
+
+func TestAuthToolHandlers(t *testing.T) {
+	tests := []struct {
+		name       string
+		authMethod client.AuthMethod
+		tool       string
+		args       map[string]interface{}
+		err        error
+		wantError  bool
+		check      func(*mockZenTaoClient) bool
+	}{
+		{
+			name:       "app credentials",
+			authMethod: client.AuthApp,
+			tool:       "zentao_login",
+			args:       map[string]interface{}{"code": "MCP", "key": "secret"},
+			check:      func(m *mockZenTaoClient) bool { return m.code == "MCP" && m.key == "secret" },
+		},
+		{
+			name:       "session login",
+			authMethod: client.AuthSession,
+			tool:       "zentao_login_session",
+			args:       map[string]interface{}{"account": "admin", "password": "pw"},
+			check:      func(m *mockZenTaoClient) bool { return m.account == "admin" && m.password == "pw" },
+		},
+		{
+			name:       "session login failure",
+			authMethod: client.AuthSession,
+			tool:       "zentao_login_session",
+			args:       map[string]interface{}{"account": "admin", "password": "wrong"},
+			err:        errors.New("login failed"),
+			wantError:  true,
+			check:      func(m *mockZenTaoClient) bool { return m.account == "" },
+		},
+		{
+			name:       "token login",
+			authMethod: client.AuthToken,
+			tool:       "zentao_login_token",
+			args:       map[string]interface{}{"account": "admin", "password": "pw"},
+			check:      func(m *mockZenTaoClient) bool { return m.account == "admin" && m.password == "pw" },
+		},
+		{
+			name:       "token login failure",
+			authMethod: client.AuthToken,
+			tool:       "zentao_login_token",
+			args:       map[string]interface{}{"account": "admin", "password": "wrong"},
+			err:        errors.New("login failed"),
+			wantError:  true,
+			check:      func(m *mockZenTaoClient) bool { return m.account == "" },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := &mockZenTaoClient{authMethod: test.authMethod, err: test.err}
+			s := server.NewMCPServer("test-server", "1.0.0")
+			RegisterAuthTools(s, mock)
+
+			result := callTool(t, s, test.tool, test.args)
+			if result.IsError != test.wantError {
+				t.Errorf("Expected IsError=%v, got %s", test.wantError, resultText(t, result))
+			}
+			if !test.check(mock) {
+				t.Errorf("Unexpected client state after %s: %+v", test.tool, mock)
+			}
+		})
+	}
+}
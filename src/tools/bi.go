@@ -21,7 +21,7 @@ import (
 )
 
 // RegisterBiTools registers all BI (Business Intelligence) tools
-func RegisterBiTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterBiTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Parquet file management
 	registerParquetTools(s, client)
 	// DuckDB management
@@ -30,13 +30,13 @@ func RegisterBiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	registerScopeFieldTools(s, client)
 }
 
-func registerParquetTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerParquetTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	syncParquetFileTool := mcp.NewTool("bi_sync_parquet_file",
 		mcp.WithDescription("Sync Parquet file"),
 	)
 
 	s.AddTool(syncParquetFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=bi&f=syncParquetFile&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=bi&f=syncParquetFile&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to sync Parquet file: %v", err)), nil
 		}
@@ -48,7 +48,7 @@ func registerParquetTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(initParquetTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=bi&f=initParquet&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=bi&f=initParquet&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to initialize Parquet: %v", err)), nil
 		}
@@ -56,13 +56,13 @@ func registerParquetTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerDuckdbTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDuckdbTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	installDuckdbTool := mcp.NewTool("bi_install_duckdb",
 		mcp.WithDescription("Install DuckDB"),
 	)
 
 	s.AddTool(installDuckdbTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=bi&f=ajaxInstallDuckdb&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=bi&f=ajaxInstallDuckdb&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to install DuckDB: %v", err)), nil
 		}
@@ -74,7 +74,7 @@ func registerDuckdbTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(checkDuckdbTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=bi&f=ajaxCheckDuckdb&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=bi&f=ajaxCheckDuckdb&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to check DuckDB: %v", err)), nil
 		}
@@ -82,7 +82,7 @@ func registerDuckdbTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerScopeFieldTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerScopeFieldTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getScopeOptionsTool := mcp.NewTool("bi_get_scope_options",
 		mcp.WithDescription("Get BI scope options"),
 		mcp.WithString("type", mcp.Description("Type filter")),
@@ -96,7 +96,7 @@ func registerScopeFieldTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&type=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get scope options: %v", err)), nil
 		}
@@ -108,7 +108,7 @@ func registerScopeFieldTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getTableFieldsMenuTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=bi&f=ajaxGetTableFieldsMenu&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=bi&f=ajaxGetTableFieldsMenu&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get table fields menu: %v", err)), nil
 		}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterBranchTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	manageBranchesTool := mcp.NewTool("manage_branches",
 		mcp.WithDescription("Manage branches for a product"),
 		mcp.WithNumber("productID",
@@ -62,7 +63,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=branch&f=manage&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=manage&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage branches: %v", err)), nil
 		}
@@ -96,7 +97,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=branch&f=create&t=json&productID=%d", int(args["productID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=create&t=json&productID=%d", int(args["productID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create branch: %v", err)), nil
 		}
@@ -134,7 +135,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=branch&f=edit&t=json&branchID=%d&productID=%d", int(args["branchID"].(float64)), int(args["productID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=edit&t=json&branchID=%d&productID=%d", int(args["branchID"].(float64)), int(args["productID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit branch: %v", err)), nil
 		}
@@ -174,7 +175,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["descs"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=branch&f=batchEdit&t=json&productID=%d", int(args["productID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=batchEdit&t=json&productID=%d", int(args["productID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit branches: %v", err)), nil
 		}
@@ -194,7 +195,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		branchID := int(args["branchID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=branch&f=close&t=json&branchID=%d", branchID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=close&t=json&branchID=%d", branchID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close branch: %v", err)), nil
 		}
@@ -214,7 +215,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		branchID := int(args["branchID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=branch&f=activate&t=json&branchID=%d", branchID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=activate&t=json&branchID=%d", branchID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate branch: %v", err)), nil
 		}
@@ -237,7 +238,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"orders": args["branchOrders"],
 		}
 
-		resp, err := client.Post("/index.php?m=branch&f=sort&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=branch&f=sort&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to sort branches: %v", err)), nil
 		}
@@ -306,8 +307,8 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&charterID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=branch&f=ajaxGetBranches&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=branch&f=ajaxGetBranches&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get branches: %v", err)), nil
 		}
 
@@ -338,7 +339,7 @@ func RegisterBranchTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"targetBranch": int(args["targetBranch"].(float64)),
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=branch&f=mergeBranch&t=json&productID=%d", int(args["productID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=branch&f=mergeBranch&t=json&productID=%d", int(args["productID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to merge branches: %v", err)), nil
 		}
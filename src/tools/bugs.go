@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterBugTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createBugTool := mcp.NewTool("create_bug",
 		mcp.WithDescription("Create a new bug in ZenTao"),
 		mcp.WithNumber("product",
@@ -125,7 +126,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["openedBuild"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/products/%d/bugs", productID), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/products/%d/bugs", productID), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create bug: %v", err)), nil
 		}
@@ -239,7 +240,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["openedBuild"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/bugs/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/bugs/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update bug: %v", err)), nil
 		}
@@ -259,7 +260,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/bugs/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/bugs/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete bug: %v", err)), nil
 		}
@@ -321,12 +322,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if openedBy, ok := args["openedBy"].(float64); ok && openedBy > 0 {
 			params["openedBy"] = fmt.Sprintf("%.0f", openedBy)
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/bugs"
 		if len(params) > 0 {
@@ -340,8 +336,8 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get bugs: %v", err)), nil
 		}
 
@@ -361,8 +357,8 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=bug&f=view&t=json&bugID=%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=bug&f=view&t=json&bugID=%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get bug: %v", err)), nil
 		}
 
@@ -438,8 +434,8 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&blockID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=bug&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=bug&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse bugs: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -464,7 +460,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["assignedTo"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=assignTo&t=json&bugID=%d", int(args["bugID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=assignTo&t=json&bugID=%d", int(args["bugID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign bug: %v", err)), nil
 		}
@@ -498,7 +494,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=confirm&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=confirm&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to confirm bug: %v", err)), nil
 		}
@@ -524,7 +520,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["extra"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=resolve&t=json&bugID=%d", int(args["bugID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=resolve&t=json&bugID=%d", int(args["bugID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve bug: %v", err)), nil
 		}
@@ -550,7 +546,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["kanbanInfo"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=activate&t=json&bugID=%d", int(args["bugID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=activate&t=json&bugID=%d", int(args["bugID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate bug: %v", err)), nil
 		}
@@ -576,7 +572,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["extra"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=close&t=json&bugID=%d", int(args["bugID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=close&t=json&bugID=%d", int(args["bugID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close bug: %v", err)), nil
 		}
@@ -610,7 +606,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&executionID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=export&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=export&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export bugs: %v", err)), nil
 		}
@@ -654,7 +650,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&chartType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=report&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(retrySafe(ctx), fmt.Sprintf("/index.php?m=bug&f=report&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate bug report: %v", err)), nil
 		}
@@ -706,7 +702,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchCreate&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchCreate&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create bugs: %v", err)), nil
 		}
@@ -740,7 +736,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchEdit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchEdit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit bugs: %v", err)), nil
 		}
@@ -766,7 +762,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchChangeBranch&t=json&branchID=%d", int(args["branchID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchChangeBranch&t=json&branchID=%d", int(args["branchID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change branch: %v", err)), nil
 		}
@@ -792,7 +788,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchChangeModule&t=json&moduleID=%d", int(args["moduleID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchChangeModule&t=json&moduleID=%d", int(args["moduleID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change module: %v", err)), nil
 		}
@@ -818,7 +814,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchChangePlan&t=json&planID=%d", int(args["planID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchChangePlan&t=json&planID=%d", int(args["planID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change plan: %v", err)), nil
 		}
@@ -859,7 +855,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchAssignTo&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchAssignTo&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch assign bugs: %v", err)), nil
 		}
@@ -881,7 +877,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=bug&f=batchConfirm&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=bug&f=batchConfirm&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch confirm bugs: %v", err)), nil
 		}
@@ -917,7 +913,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchResolve&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchResolve&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch resolve bugs: %v", err)), nil
 		}
@@ -953,7 +949,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchClose&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchClose&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch close bugs: %v", err)), nil
 		}
@@ -987,7 +983,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs_data": args["bugs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=bug&f=batchActivate&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=batchActivate&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch activate bugs: %v", err)), nil
 		}
@@ -1043,7 +1039,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=bug&f=linkBugs&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=linkBugs&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link bugs: %v", err)), nil
 		}
@@ -1061,7 +1057,7 @@ func RegisterBugTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(confirmStoryChangeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=bug&f=confirmStoryChange&t=json&bugID=%d", int(args["bugID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=bug&f=confirmStoryChange&t=json&bugID=%d", int(args["bugID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to confirm story change: %v", err)), nil
 		}
@@ -13,9 +13,14 @@
 package tools
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
 )
 
 // Mock ZenTao client for testing (defined in auth_test.go)
@@ -188,3 +193,60 @@ func BenchmarkBugToolList(b *testing.B) {
 		_ = s.ListTools()
 	}
 }
+
+func TestBugToolHandlers(t *testing.T) {
+	runHandlerCases(t, RegisterBugTools, []handlerCase{
+		{
+			tool:       "create_bug",
+			args:       map[string]interface{}{"product": float64(1), "title": "Crash", "severity": float64(2), "pri": float64(1), "type": "codeerror", "module": float64(9)},
+			wantMethod: "POST",
+			wantPath:   "/products/1/bugs",
+			wantBody:   map[string]interface{}{"title": "Crash", "severity": 2, "pri": 1, "type": "codeerror", "module": 9},
+		},
+		{
+			tool:       "delete_bug",
+			args:       map[string]interface{}{"id": float64(7)},
+			wantMethod: "DELETE",
+			wantPath:   "/bugs/7",
+		},
+		{
+			tool:       "get_bug",
+			args:       map[string]interface{}{"id": float64(7)},
+			wantMethod: "GET",
+			wantPath:   "/index.php?m=bug&f=view&t=json&bugID=7",
+		},
+		{
+			tool:       "resolve_bug",
+			args:       map[string]interface{}{"bugID": float64(7), "extra": "resolution=fixed"},
+			wantMethod: "POST",
+			wantPath:   "/index.php?m=bug&f=resolve&t=json&bugID=7",
+			wantBody:   map[string]interface{}{"extra": "resolution=fixed"},
+		},
+	})
+}
+
+func TestReportBugsRetriesTransientFailure(t *testing.T) {
+	var calls atomic.Int32
+	zentao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":"{}"}`))
+	}))
+	defer zentao.Close()
+
+	c := client.NewZenTaoClient(zentao.URL + "/index.php")
+	c.SetRetryPolicy(client.RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	s := server.NewMCPServer("test-server", "1.0.0")
+	RegisterBugTools(s, c)
+
+	result := callTool(t, s, "report_bugs", map[string]interface{}{"productID": float64(1)})
+	if result.IsError {
+		t.Fatalf("Expected the report POST to be retried, got %s", resultText(t, result))
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls.Load())
+	}
+}
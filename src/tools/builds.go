@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterBuildTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createBuildTool := mcp.NewTool("create_build",
 		mcp.WithDescription("Create a new build"),
 		mcp.WithNumber("executionID",
@@ -76,7 +77,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["date"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=build&f=create&t=json&executionID=%d&productID=%d&projectID=%d",
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=build&f=create&t=json&executionID=%d&productID=%d&projectID=%d",
 			int(args["executionID"].(float64)), int(args["productID"].(float64)), int(args["projectID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create build: %v", err)), nil
@@ -135,7 +136,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["date"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=build&f=edit&t=json&buildID=%d", int(args["buildID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=build&f=edit&t=json&buildID=%d", int(args["buildID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit build: %v", err)), nil
 		}
@@ -198,8 +199,8 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=view&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=build&f=view&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view build: %v", err)), nil
 		}
 
@@ -226,7 +227,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=delete&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=build&f=delete&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete build: %v", err)), nil
 		}
@@ -271,8 +272,8 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&type=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetProductBuilds&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetProductBuilds&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get product builds: %v", err)), nil
 		}
 
@@ -334,8 +335,8 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&system=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetProjectBuilds&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetProjectBuilds&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project builds: %v", err)), nil
 		}
 
@@ -391,8 +392,8 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&type=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetExecutionBuilds&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetExecutionBuilds&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution builds: %v", err)), nil
 		}
 
@@ -420,8 +421,8 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&executionID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=ajaxGetLastBuild&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=build&f=ajaxGetLastBuild&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get last build: %v", err)), nil
 		}
 
@@ -477,7 +478,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=build&f=linkStory&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=build&f=linkStory&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link story to build: %v", err)), nil
 		}
@@ -500,7 +501,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unlinkStoryFromBuildTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=unlinkStory&t=json&buildID=%d&storyID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=build&f=unlinkStory&t=json&buildID=%d&storyID=%d",
 			int(args["buildID"].(float64)), int(args["storyID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink story from build: %v", err)), nil
@@ -521,7 +522,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		buildID := int(args["buildID"].(float64))
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=build&f=batchUnlinkStory&t=json&buildID=%d", buildID), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=build&f=batchUnlinkStory&t=json&buildID=%d", buildID), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink stories from build: %v", err)), nil
 		}
@@ -578,7 +579,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=build&f=linkBug&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=build&f=linkBug&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link bug to build: %v", err)), nil
 		}
@@ -601,7 +602,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unlinkBugFromBuildTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=build&f=unlinkBug&t=json&buildID=%d&bugID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=build&f=unlinkBug&t=json&buildID=%d&bugID=%d",
 			int(args["buildID"].(float64)), int(args["bugID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink bug from build: %v", err)), nil
@@ -622,7 +623,7 @@ func RegisterBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		buildID := int(args["buildID"].(float64))
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=build&f=batchUnlinkBug&t=json&buildID=%d", buildID), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=build&f=batchUnlinkBug&t=json&buildID=%d", buildID), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink bugs from build: %v", err)), nil
 		}
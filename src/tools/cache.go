@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+)
+
+// RegisterCacheTools registers zentao_cache_stats and zentao_cache_clear
+func RegisterCacheTools(s *server.MCPServer, client client.ZenTaoAPI) {
+	// Handle nil client for testing
+	if client == nil {
+		logger.Warn("tools", "Nil client provided to RegisterCacheTools", nil)
+		return
+	}
+
+	statsTool := mcp.NewTool("zentao_cache_stats",
+		mcp.WithDescription("Report the response cache: hits, misses, invalidations and cached entries per module"),
+	)
+
+	s.AddTool(statsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger.LogMCPToolCall("zentao_cache_stats", nil)
+
+		resolver, ok := client.(instanceResolver)
+		if !ok {
+			return mcp.NewToolResultError("Failed to read cache stats: client does not expose a cache"), nil
+		}
+
+		resp, err := json.MarshalIndent(resolver.ForContext(ctx).CacheStats(), "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode cache stats: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(resp)), nil
+	})
+
+	clearTool := mcp.NewTool("zentao_cache_clear",
+		mcp.WithDescription("Drop cached ZenTao responses so the next reads fetch fresh data"),
+		mcp.WithString("module",
+			mcp.Description("Only clear this ZenTao module, e.g. bug or user (default: everything)"),
+		),
+	)
+
+	s.AddTool(clearTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		module, _ := args["module"].(string)
+
+		logger.LogMCPToolCall("zentao_cache_clear", map[string]interface{}{
+			"module": module,
+		})
+
+		resolver, ok := client.(instanceResolver)
+		if !ok {
+			return mcp.NewToolResultError("Failed to clear cache: client does not expose a cache"), nil
+		}
+
+		removed, err := resolver.ForContext(ctx).ClearCache(module)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to clear cache: %v", err)), nil
+		}
+
+		resp, err := json.Marshal(map[string]interface{}{
+			"module":  module,
+			"removed": removed,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(resp)), nil
+	})
+}
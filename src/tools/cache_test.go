@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+func TestCacheTools(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+	fake.Seed("product", map[string]interface{}{"name": "Widget"})
+
+	opts := fake.Options()
+	c := client.NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, opts.AppKey)
+	c.SetRetryPolicy(client.RetryPolicy{MaxRetries: 0})
+	c.SetCacheConfig(client.CacheConfig{TTL: time.Minute})
+
+	s := server.NewMCPServer("test-server", "1.0.0")
+	RegisterProductTools(s, c)
+	RegisterCacheTools(s, c)
+
+	for i := 0; i < 2; i++ {
+		if result := callTool(t, s, "get_product", map[string]interface{}{"id": float64(1)}); result.IsError {
+			t.Fatalf("get_product failed: %s", resultText(t, result))
+		}
+	}
+
+	var stats client.CacheStats
+	if err := json.Unmarshal([]byte(resultText(t, callTool(t, s, "zentao_cache_stats", nil))), &stats); err != nil {
+		t.Fatalf("Failed to parse stats: %v", err)
+	}
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Modules["product"] != 1 {
+		t.Errorf("Expected one hit and one cached product, got %+v", stats)
+	}
+
+	result := callTool(t, s, "zentao_cache_clear", map[string]interface{}{"module": "product"})
+	if result.IsError || !strings.Contains(resultText(t, result), `"removed":1`) {
+		t.Errorf("Expected one entry cleared, got %s", resultText(t, result))
+	}
+
+	fake.ResetRequests()
+	callTool(t, s, "get_product", map[string]interface{}{"id": float64(1)})
+	if len(fake.Requests()) != 1 {
+		t.Errorf("Expected the product to be refetched after clearing, got %d requests", len(fake.Requests()))
+	}
+}
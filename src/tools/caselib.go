@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,14 +19,14 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterCaseLibTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getCaseLibIndexTool := mcp.NewTool("get_caselib_index",
 		mcp.WithDescription("Get case library index"),
 	)
 
 	s.AddTool(getCaseLibIndexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=caselib&f=index&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=caselib&f=index&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get case library index: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -46,7 +47,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"lib_data": args["lib_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=caselib&f=create&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=caselib&f=create&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create case library: %v", err)), nil
 		}
@@ -72,7 +73,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"lib_data": args["lib_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=edit&t=json&libID=%d", int(args["libID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=edit&t=json&libID=%d", int(args["libID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit case library: %v", err)), nil
 		}
@@ -90,7 +91,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteCaseLibTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=delete&t=json&libID=%d", int(args["libID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=delete&t=json&libID=%d", int(args["libID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete case library: %v", err)), nil
 		}
@@ -158,8 +159,8 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&blockID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=caselib&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse case library: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -176,8 +177,8 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(viewCaseLibTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=view&t=json&libID=%d", int(args["libID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=caselib&f=view&t=json&libID=%d", int(args["libID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view case library: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -216,7 +217,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"case_data": args["case_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=createCase&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=createCase&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create case: %v", err)), nil
 		}
@@ -250,7 +251,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=batchCreateCase&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=batchCreateCase&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create cases: %v", err)), nil
 		}
@@ -276,7 +277,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"case_data": args["case_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=editCase&t=json&caseID=%d", int(args["caseID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=editCase&t=json&caseID=%d", int(args["caseID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit case: %v", err)), nil
 		}
@@ -316,7 +317,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=batchEditCase&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=batchEditCase&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit cases: %v", err)), nil
 		}
@@ -360,8 +361,8 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&stepsType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=caselib&f=viewCase&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=caselib&f=viewCase&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view case: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -378,7 +379,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(exportTemplateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=exportTemplate&t=json&libID=%d", int(args["libID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=exportTemplate&t=json&libID=%d", int(args["libID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export template: %v", err)), nil
 		}
@@ -404,7 +405,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"import_data": args["import_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=import&t=json&libID=%d", int(args["libID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=import&t=json&libID=%d", int(args["libID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import cases: %v", err)), nil
 		}
@@ -442,7 +443,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&insert=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=showImport&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=showImport&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to show import: %v", err)), nil
 		}
@@ -474,7 +475,7 @@ func RegisterCaseLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=caselib&f=exportCase&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=caselib&f=exportCase&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export cases: %v", err)), nil
 		}
@@ -21,7 +21,7 @@ import (
 )
 
 // RegisterDatatableTools registers all datatable and report-related tools
-func RegisterDatatableTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterDatatableTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Datatable display and management
 	registerDatatableDisplayTools(s, client)
 	// Datatable save operations
@@ -34,7 +34,7 @@ func RegisterDatatableTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	registerReportTools(s, client)
 }
 
-func registerDatatableDisplayTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDatatableDisplayTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	ajaxDisplayTool := mcp.NewTool("datatable_ajax_display",
 		mcp.WithDescription("Display datatable with specified configuration"),
 		mcp.WithString("datatableID",
@@ -67,7 +67,7 @@ func registerDatatableDisplayTools(s *server.MCPServer, client *client.ZenTaoCli
 			params["currentMethod"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=datatable&f=ajaxDisplay&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=datatable&f=ajaxDisplay&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to display datatable: %v", err)), nil
 		}
@@ -75,7 +75,7 @@ func registerDatatableDisplayTools(s *server.MCPServer, client *client.ZenTaoCli
 	})
 }
 
-func registerDatatableSaveTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDatatableSaveTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	ajaxSaveTool := mcp.NewTool("datatable_ajax_save",
 		mcp.WithDescription("Save datatable configuration"),
 	)
@@ -83,7 +83,7 @@ func registerDatatableSaveTools(s *server.MCPServer, client *client.ZenTaoClient
 	s.AddTool(ajaxSaveTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		params := make(map[string]interface{})
 
-		resp, err := client.Post("/index.php?m=datatable&f=ajaxSave&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=datatable&f=ajaxSave&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save datatable: %v", err)), nil
 		}
@@ -111,7 +111,7 @@ func registerDatatableSaveTools(s *server.MCPServer, client *client.ZenTaoClient
 			params["extra"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=datatable&f=ajaxSaveFields&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=datatable&f=ajaxSaveFields&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save datatable fields: %v", err)), nil
 		}
@@ -125,7 +125,7 @@ func registerDatatableSaveTools(s *server.MCPServer, client *client.ZenTaoClient
 	s.AddTool(ajaxOldSaveTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		params := make(map[string]interface{})
 
-		resp, err := client.Post("/index.php?m=datatable&f=ajaxOldSave&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=datatable&f=ajaxOldSave&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save datatable (old): %v", err)), nil
 		}
@@ -133,7 +133,7 @@ func registerDatatableSaveTools(s *server.MCPServer, client *client.ZenTaoClient
 	})
 }
 
-func registerDatatableCustomTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDatatableCustomTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	ajaxCustomTool := mcp.NewTool("datatable_ajax_custom",
 		mcp.WithDescription("Perform custom datatable operation"),
 		mcp.WithString("module", mcp.Description("Module name")),
@@ -155,7 +155,7 @@ func registerDatatableCustomTools(s *server.MCPServer, client *client.ZenTaoClie
 			params["extra"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=datatable&f=ajaxCustom&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=datatable&f=ajaxCustom&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to perform custom operation: %v", err)), nil
 		}
@@ -183,7 +183,7 @@ func registerDatatableCustomTools(s *server.MCPServer, client *client.ZenTaoClie
 			params["extra"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=datatable&f=ajaxOldCustom&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=datatable&f=ajaxOldCustom&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to perform old custom operation: %v", err)), nil
 		}
@@ -191,7 +191,7 @@ func registerDatatableCustomTools(s *server.MCPServer, client *client.ZenTaoClie
 	})
 }
 
-func registerDatatableResetTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDatatableResetTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	ajaxResetTool := mcp.NewTool("datatable_ajax_reset",
 		mcp.WithDescription("Reset datatable configuration"),
 		mcp.WithString("module", mcp.Description("Module name")),
@@ -221,7 +221,7 @@ func registerDatatableResetTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["extra"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=datatable&f=ajaxReset&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=datatable&f=ajaxReset&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to reset datatable: %v", err)), nil
 		}
@@ -253,7 +253,7 @@ func registerDatatableResetTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["confirm"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=datatable&f=ajaxOldReset&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=datatable&f=ajaxOldReset&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to reset datatable (old): %v", err)), nil
 		}
@@ -285,7 +285,7 @@ func registerDatatableResetTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["confirm"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=datatable&f=ajaxSaveGlobal&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=datatable&f=ajaxSaveGlobal&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save global settings: %v", err)), nil
 		}
@@ -293,13 +293,13 @@ func registerDatatableResetTools(s *server.MCPServer, client *client.ZenTaoClien
 	})
 }
 
-func registerReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerReportTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	reportIndexTool := mcp.NewTool("report_index",
 		mcp.WithDescription("Get report index"),
 	)
 
 	s.AddTool(reportIndexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=report&f=index&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=report&f=index&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get report index: %v", err)), nil
 		}
@@ -311,7 +311,7 @@ func registerReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(reportRemindTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=report&f=remind&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=report&f=remind&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get report reminders: %v", err)), nil
 		}
@@ -339,7 +339,7 @@ func registerReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["account"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=report&f=annualData&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=report&f=annualData&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get annual data: %v", err)), nil
 		}
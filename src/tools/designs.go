@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterDesignTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseDesignsTool := mcp.NewTool("browse_designs",
 		mcp.WithDescription("Browse designs for a project/product"),
 		mcp.WithNumber("projectID",
@@ -81,8 +82,8 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=browse&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=design&f=browse&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse designs: %v", err)), nil
 		}
 
@@ -137,7 +138,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["assignedTo"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=design&f=create&t=json&projectID=%d&productID=%d&type=%s",
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=design&f=create&t=json&projectID=%d&productID=%d&type=%s",
 			int(args["projectID"].(float64)), int(args["productID"].(float64)), args["type"]), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create design: %v", err)), nil
@@ -181,7 +182,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["descs"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=design&f=batchCreate&t=json&projectID=%d&productID=%d&type=%s",
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=design&f=batchCreate&t=json&projectID=%d&productID=%d&type=%s",
 			int(args["projectID"].(float64)), int(args["productID"].(float64)), args["type"]), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create designs: %v", err)), nil
@@ -202,8 +203,8 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		designID := int(args["designID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=view&t=json&designID=%d", designID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=design&f=view&t=json&designID=%d", designID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view design: %v", err)), nil
 		}
 
@@ -248,7 +249,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["assignedTo"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=design&f=edit&t=json&designID=%d", int(args["designID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=design&f=edit&t=json&designID=%d", int(args["designID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit design: %v", err)), nil
 		}
@@ -268,7 +269,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		designID := int(args["designID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=delete&t=json&designID=%d", designID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=design&f=delete&t=json&designID=%d", designID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete design: %v", err)), nil
 		}
@@ -295,7 +296,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"assignedTo": int(args["assignedTo"].(float64)),
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=design&f=assignTo&t=json&designID=%d", int(args["designID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=design&f=assignTo&t=json&designID=%d", int(args["designID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign design: %v", err)), nil
 		}
@@ -353,7 +354,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=design&f=linkCommit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=design&f=linkCommit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link commit to design: %v", err)), nil
 		}
@@ -376,7 +377,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unlinkCommitFromDesignTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=unlinkCommit&t=json&designID=%d&commitID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=design&f=unlinkCommit&t=json&designID=%d&commitID=%d",
 			int(args["designID"].(float64)), int(args["commitID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink commit from design: %v", err)), nil
@@ -416,8 +417,8 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=viewCommit&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=design&f=viewCommit&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view design commits: %v", err)), nil
 		}
 
@@ -439,9 +440,9 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getDesignSwitcherMenuTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=ajaxSwitcherMenu&t=json&projectID=%d&productID=%d",
-			int(args["projectID"].(float64)), int(args["productID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=design&f=ajaxSwitcherMenu&t=json&projectID=%d&productID=%d",
+			int(args["projectID"].(float64)), int(args["productID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get design switcher menu: %v", err)), nil
 		}
 
@@ -477,8 +478,8 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&hasParent=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=ajaxGetProductStories&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=design&f=ajaxGetProductStories&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get product stories for design: %v", err)), nil
 		}
 
@@ -497,7 +498,7 @@ func RegisterDesignTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		designID := int(args["designID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=design&f=confirmStoryChange&t=json&designID=%d", designID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=design&f=confirmStoryChange&t=json&designID=%d", designID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to confirm story change for design: %v", err)), nil
 		}
@@ -0,0 +1,428 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+)
+
+// defaultSearchLimit caps zentao_search_tools results unless the caller asks for more
+const defaultSearchLimit = 20
+
+// Discovery registers toolsets on demand. Only the core toolsets and the
+// zentao_search_tools, zentao_describe_tool and zentao_enable_toolset
+// meta-tools are listed upfront; the rest of the catalog is searchable and
+// registered one toolset at a time. Sessions that support their own tools
+// (streamable HTTP, SSE) enable toolsets for themselves only; stdio enables
+// them on the server.
+type Discovery struct {
+	server *server.MCPServer
+
+	mu       sync.Mutex
+	catalog  map[string][]server.ServerTool // toolset -> its tools, ready to add
+	owner    map[string]string              // tool name -> toolset
+	enabled  map[string]bool                // toolsets registered on the server
+	sessions map[string]map[string]bool     // session ID -> toolsets registered for it
+}
+
+// NewDiscovery builds the catalog of every selected toolset. Each toolset is
+// registered on a scratch server and passed through prepare, which adds the
+// arguments every tool carries (pagination, instance); excluded tools are
+// dropped. Nothing is added to s until a toolset is enabled.
+func NewDiscovery(s *server.MCPServer, c client.ZenTaoAPI, sel ToolsetSelection, prepare func(*server.MCPServer)) *Discovery {
+	d := &Discovery{
+		server:   s,
+		catalog:  make(map[string][]server.ServerTool),
+		owner:    make(map[string]string),
+		enabled:  make(map[string]bool),
+		sessions: make(map[string]map[string]bool),
+	}
+
+	for _, toolset := range Toolsets {
+		if !sel.Enabled(toolset.Name) {
+			continue
+		}
+		scratch := server.NewMCPServer("zentao-tool-catalog", "1.0.0")
+		toolset.Register(scratch, c)
+		if prepare != nil {
+			prepare(scratch)
+		}
+		sel.RemoveExcluded(scratch)
+
+		registered := scratch.ListTools()
+		tools := make([]server.ServerTool, 0, len(registered))
+		for name, tool := range registered {
+			tools = append(tools, *tool)
+			d.owner[name] = toolset.Name
+		}
+		sort.Slice(tools, func(i, j int) bool { return tools[i].Tool.Name < tools[j].Tool.Name })
+		d.catalog[toolset.Name] = tools
+	}
+
+	logger.Info("tools", "Tool discovery catalog built", map[string]interface{}{
+		"toolsets": len(d.catalog),
+		"tools":    len(d.owner),
+	})
+	return d
+}
+
+// Enable registers the tools of the named toolset, or of every toolset in a
+// preset. When ctx carries a session that supports its own tools, they are
+// added to that session only; otherwise they are added to the server. Either
+// way the affected clients receive notifications/tools/list_changed. It
+// returns the names of the tools that were added.
+func (d *Discovery) Enable(ctx context.Context, name string) ([]string, error) {
+	names := []string{name}
+	if preset, ok := ToolsetPresets[name]; ok {
+		names = preset
+	}
+
+	session, _ := server.ClientSessionFromContext(ctx).(server.SessionWithTools)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, toolset := range names {
+		if _, ok := d.catalog[toolset]; !ok {
+			return nil, fmt.Errorf("unknown or disabled toolset %q (available: %s)", toolset, strings.Join(d.toolsetNames(), ", "))
+		}
+	}
+
+	enabled := d.enabled
+	if session != nil {
+		if d.sessions[session.SessionID()] == nil {
+			d.sessions[session.SessionID()] = make(map[string]bool)
+		}
+		enabled = d.sessions[session.SessionID()]
+	}
+
+	var added []server.ServerTool
+	var toolsets []string
+	for _, toolset := range names {
+		if d.enabled[toolset] || enabled[toolset] {
+			continue
+		}
+		toolsets = append(toolsets, toolset)
+		added = append(added, d.catalog[toolset]...)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	sessionID := ""
+	if session != nil {
+		sessionID = session.SessionID()
+		if err := d.server.AddSessionTools(sessionID, added...); err != nil {
+			return nil, fmt.Errorf("failed to enable toolset %s: %w", name, err)
+		}
+	} else {
+		d.server.AddTools(added...)
+	}
+	for _, toolset := range toolsets {
+		enabled[toolset] = true
+	}
+
+	toolNames := make([]string, len(added))
+	for i, tool := range added {
+		toolNames[i] = tool.Tool.Name
+	}
+	logger.Info("tools", "Toolset enabled", map[string]interface{}{
+		"toolset": name,
+		"tools":   len(toolNames),
+		"session": sessionID,
+	})
+	return toolNames, nil
+}
+
+// EnableCore registers the core toolsets on the server, for every session
+func (d *Discovery) EnableCore() []string {
+	var enabled []string
+	for _, toolset := range Toolsets {
+		if !toolset.Core {
+			continue
+		}
+		if _, err := d.Enable(context.Background(), toolset.Name); err == nil {
+			enabled = append(enabled, toolset.Name)
+		}
+	}
+	return enabled
+}
+
+// ForgetSession drops what an ended session enabled
+func (d *Discovery) ForgetSession(sessionID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.sessions, sessionID)
+}
+
+// isEnabled reports whether toolset is registered for the session in ctx;
+// callers hold d.mu
+func (d *Discovery) isEnabled(ctx context.Context, toolset string) bool {
+	if d.enabled[toolset] {
+		return true
+	}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return d.sessions[session.SessionID()][toolset]
+	}
+	return false
+}
+
+// toolsetNames returns the catalog's toolsets in registration order; callers hold d.mu
+func (d *Discovery) toolsetNames() []string {
+	names := make([]string, 0, len(d.catalog))
+	for _, toolset := range Toolsets {
+		if _, ok := d.catalog[toolset.Name]; ok {
+			names = append(names, toolset.Name)
+		}
+	}
+	return names
+}
+
+// lookup returns a catalog tool and its toolset
+func (d *Discovery) lookup(name string) (server.ServerTool, string, bool) {
+	toolset, ok := d.owner[name]
+	if !ok {
+		return server.ServerTool{}, "", false
+	}
+	for _, tool := range d.catalog[toolset] {
+		if tool.Tool.Name == name {
+			return tool, toolset, true
+		}
+	}
+	return server.ServerTool{}, "", false
+}
+
+// toolMatch is one zentao_search_tools result
+type toolMatch struct {
+	Name        string `json:"name"`
+	Toolset     string `json:"toolset"`
+	Enabled     bool   `json:"enabled"`
+	Description string `json:"description"`
+	score       int
+}
+
+// Search ranks catalog tools by how well they match every word of query.
+// Name hits weigh most, then the description, then parameter names and
+// descriptions. Tools that miss any word are left out.
+func (d *Discovery) Search(ctx context.Context, query, toolset string, limit int) []toolMatch {
+	words := strings.Fields(strings.ToLower(strings.NewReplacer("_", " ", "-", " ").Replace(query)))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var matches []toolMatch
+	for name, owner := range d.owner {
+		if toolset != "" && owner != toolset {
+			continue
+		}
+		tool, _, _ := d.lookup(name)
+		score := scoreTool(tool.Tool, words)
+		if score == 0 {
+			continue
+		}
+		matches = append(matches, toolMatch{
+			Name:        name,
+			Toolset:     owner,
+			Enabled:     d.isEnabled(ctx, owner),
+			Description: tool.Tool.Description,
+			score:       score,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func scoreTool(tool mcp.Tool, words []string) int {
+	if len(words) == 0 {
+		return 0
+	}
+	name := strings.ReplaceAll(tool.Name, "_", " ")
+	description := strings.ToLower(tool.Description)
+	var params strings.Builder
+	for key, value := range tool.InputSchema.Properties {
+		params.WriteString(strings.ToLower(key) + " ")
+		if property, ok := value.(map[string]any); ok {
+			if text, ok := property["description"].(string); ok {
+				params.WriteString(strings.ToLower(text) + " ")
+			}
+		}
+	}
+
+	total := 0
+	for _, word := range words {
+		score := 0
+		if strings.Contains(name, word) {
+			score += 3
+		}
+		if strings.Contains(description, word) {
+			score += 2
+		}
+		if strings.Contains(params.String(), word) {
+			score++
+		}
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total
+}
+
+// RegisterDiscoveryTools registers the zentao_search_tools,
+// zentao_describe_tool and zentao_enable_toolset meta-tools
+func RegisterDiscoveryTools(s *server.MCPServer, d *Discovery) {
+	d.mu.Lock()
+	toolsets := d.toolsetNames()
+	d.mu.Unlock()
+
+	var summary strings.Builder
+	for _, name := range toolsets {
+		toolset, _ := FindToolset(name)
+		fmt.Fprintf(&summary, "\n- %s: %s", name, toolset.Description)
+	}
+	presets := make([]string, 0, len(ToolsetPresets))
+	for preset := range ToolsetPresets {
+		presets = append(presets, preset)
+	}
+	sort.Strings(presets)
+
+	searchTool := mcp.NewTool("zentao_search_tools",
+		mcp.WithDescription("Search all ZenTao tools, including ones not enabled yet, by name, description and parameters. Enable a result's toolset with zentao_enable_toolset before calling it."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Words to search for, e.g. \"assign bug\" or \"testcase result\""),
+		),
+		mcp.WithString("toolset",
+			mcp.Description("Only search this toolset"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of results (default %d)", defaultSearchLimit)),
+		),
+	)
+
+	s.AddTool(searchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		query, _ := args["query"].(string)
+		toolset, _ := args["toolset"].(string)
+		limit := defaultSearchLimit
+		if v, ok := args["limit"].(float64); ok && v > 0 {
+			limit = int(v)
+		}
+
+		logger.LogMCPToolCall("zentao_search_tools", map[string]interface{}{
+			"query":   query,
+			"toolset": toolset,
+			"limit":   limit,
+		})
+
+		if strings.TrimSpace(query) == "" {
+			return mcp.NewToolResultError("query must not be empty"), nil
+		}
+		matches := d.Search(ctx, query, toolset, limit)
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"query":   query,
+			"matches": matches,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	})
+
+	describeTool := mcp.NewTool("zentao_describe_tool",
+		mcp.WithDescription("Show the full description and input schema of a ZenTao tool, enabled or not"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Tool name as returned by zentao_search_tools"),
+		),
+	)
+
+	s.AddTool(describeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.GetArguments()["name"].(string)
+
+		logger.LogMCPToolCall("zentao_describe_tool", map[string]interface{}{
+			"name": name,
+		})
+
+		d.mu.Lock()
+		tool, toolset, ok := d.lookup(name)
+		enabled := d.isEnabled(ctx, toolset)
+		d.mu.Unlock()
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown tool %q; use zentao_search_tools to find one", name)), nil
+		}
+
+		description := map[string]interface{}{
+			"name":        tool.Tool.Name,
+			"toolset":     toolset,
+			"enabled":     enabled,
+			"description": tool.Tool.Description,
+			"inputSchema": tool.Tool.InputSchema,
+		}
+		if !enabled {
+			description["hint"] = fmt.Sprintf("Call zentao_enable_toolset with name %q to use this tool", toolset)
+		}
+		result, _ := json.MarshalIndent(description, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	})
+
+	enableTool := mcp.NewTool("zentao_enable_toolset",
+		mcp.WithDescription("Register a group of ZenTao tools for this session so they can be called. The client is notified that its tool list changed. Toolsets:"+summary.String()),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Toolset or preset to enable"),
+			mcp.Enum(append(toolsets, presets...)...),
+		),
+	)
+
+	s.AddTool(enableTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.GetArguments()["name"].(string)
+
+		logger.LogMCPToolCall("zentao_enable_toolset", map[string]interface{}{
+			"name": name,
+		})
+
+		added, err := d.Enable(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(added) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", name)), nil
+		}
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"toolset": name,
+			"enabled": added,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	})
+
+	logger.Debug("tools", "Registered discovery tools", map[string]interface{}{
+		"toolsets": len(toolsets),
+	})
+}
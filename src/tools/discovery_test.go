@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+)
+
+// notifiedSession is a client session that collects server notifications
+type notifiedSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (n *notifiedSession) Initialize()       {}
+func (n *notifiedSession) Initialized() bool { return true }
+func (n *notifiedSession) SessionID() string { return "discovery-test" }
+func (n *notifiedSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return n.notifications
+}
+
+// toolSession is a client session with its own tools, like a streamable HTTP session
+type toolSession struct {
+	notifiedSession
+	id    string
+	mu    sync.Mutex
+	tools map[string]server.ServerTool
+}
+
+func (t *toolSession) SessionID() string { return t.id }
+func (t *toolSession) GetSessionTools() map[string]server.ServerTool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tools
+}
+func (t *toolSession) SetSessionTools(tools map[string]server.ServerTool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tools = tools
+}
+
+func newTestDiscovery(t *testing.T, toolsets, exclude string) (*server.MCPServer, *Discovery) {
+	t.Helper()
+	sel, err := ParseToolsetSelection(toolsets, exclude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.NewMCPServer("test-server", "1.0.0", server.WithToolCapabilities(true))
+	d := NewDiscovery(s, &mockZenTaoClient{authMethod: client.AuthApp}, sel, AddPaginationArguments)
+	d.EnableCore()
+	RegisterDiscoveryTools(s, d)
+	return s, d
+}
+
+func TestDiscoverySearch(t *testing.T) {
+	_, d := newTestDiscovery(t, "", "")
+
+	matches := d.Search(context.Background(), "assign bug", "", 5)
+	if len(matches) == 0 || matches[0].Name != "assign_bug" || matches[0].Toolset != "bugs" || matches[0].Enabled {
+		t.Fatalf("Expected assign_bug first, got %+v", matches)
+	}
+
+	for _, match := range d.Search(context.Background(), "bug", "kanban", 0) {
+		if match.Toolset != "kanban" {
+			t.Errorf("Expected only kanban tools, got %s", match.Name)
+		}
+	}
+	if matches := d.Search(context.Background(), "nonexistent-word-xyz", "", 0); len(matches) != 0 {
+		t.Errorf("Expected no matches, got %d", len(matches))
+	}
+}
+
+func TestDiscoveryEnableToolset(t *testing.T) {
+	s, d := newTestDiscovery(t, "minimal,kanban", "delete_*")
+	session := &notifiedSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.GetTool("get_bugs") != nil {
+		t.Fatal("Expected bug tools to be hidden until enabled")
+	}
+	if s.GetTool("zentao_search_tools") == nil || s.GetTool("zentao_health") == nil {
+		t.Fatal("Expected the meta-tools and core tools upfront")
+	}
+
+	added, err := d.Enable(context.Background(), "bugs")
+	if err != nil || len(added) == 0 {
+		t.Fatalf("Expected bug tools to be added, got %v", err)
+	}
+	if s.GetTool("get_bugs") == nil {
+		t.Error("Expected get_bugs after enabling bugs")
+	}
+	if s.GetTool("delete_bug") != nil {
+		t.Error("Expected excluded tools to stay hidden")
+	}
+	if _, ok := s.GetTool("get_bugs").Tool.InputSchema.Properties["all"]; !ok {
+		t.Error("Expected enabled tools to be prepared with pagination arguments")
+	}
+
+	select {
+	case notification := <-session.notifications:
+		if notification.Method != mcp.MethodNotificationToolsListChanged {
+			t.Errorf("Expected tools/list_changed, got %s", notification.Method)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected a tools/list_changed notification")
+	}
+
+	if added, _ := d.Enable(context.Background(), "bugs"); len(added) != 0 {
+		t.Error("Expected enabling twice to add nothing")
+	}
+	if _, err := d.Enable(context.Background(), "zanode"); err == nil {
+		t.Error("Expected an error for a toolset outside the selection")
+	}
+	if _, err := d.Enable(context.Background(), "minimal"); err != nil || s.GetTool("create_task") == nil {
+		t.Errorf("Expected the minimal preset to enable its toolsets, got %v", err)
+	}
+}
+
+func TestDiscoveryEnablePerSession(t *testing.T) {
+	s, d := newTestDiscovery(t, "", "")
+	sessions := make([]*toolSession, 2)
+	for i := range sessions {
+		sessions[i] = &toolSession{
+			notifiedSession: notifiedSession{notifications: make(chan mcp.JSONRPCNotification, 10)},
+			id:              fmt.Sprintf("session-%d", i),
+		}
+		if err := s.RegisterSession(context.Background(), sessions[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first := s.WithContext(context.Background(), sessions[0])
+	second := s.WithContext(context.Background(), sessions[1])
+
+	if added, err := d.Enable(first, "bugs"); err != nil || len(added) == 0 {
+		t.Fatalf("Expected bug tools to be added, got %v", err)
+	}
+	if _, ok := sessions[0].GetSessionTools()["get_bugs"]; !ok {
+		t.Error("Expected get_bugs in the first session")
+	}
+	if _, ok := sessions[1].GetSessionTools()["get_bugs"]; ok {
+		t.Error("Expected the second session to be unaffected")
+	}
+	if s.GetTool("get_bugs") != nil {
+		t.Error("Expected get_bugs to stay off the shared tool list")
+	}
+
+	select {
+	case notification := <-sessions[0].notifications:
+		if notification.Method != mcp.MethodNotificationToolsListChanged {
+			t.Errorf("Expected tools/list_changed, got %s", notification.Method)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected a tools/list_changed notification for the first session")
+	}
+	select {
+	case notification := <-sessions[1].notifications:
+		t.Errorf("Expected no notification for the second session, got %s", notification.Method)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if matches := d.Search(first, "assign bug", "bugs", 1); len(matches) != 1 || !matches[0].Enabled {
+		t.Errorf("Expected bugs to be enabled for the first session, got %+v", matches)
+	}
+	if matches := d.Search(second, "assign bug", "bugs", 1); len(matches) != 1 || matches[0].Enabled {
+		t.Errorf("Expected bugs to be disabled for the second session, got %+v", matches)
+	}
+	if added, _ := d.Enable(second, "bugs"); len(added) == 0 {
+		t.Error("Expected the second session to enable bugs for itself")
+	}
+
+	d.ForgetSession(sessions[0].id)
+	if matches := d.Search(first, "assign bug", "bugs", 1); len(matches) != 1 || matches[0].Enabled {
+		t.Errorf("Expected a forgotten session to start over, got %+v", matches)
+	}
+}
+
+func TestDiscoveryMetaTools(t *testing.T) {
+	s, _ := newTestDiscovery(t, "", "")
+	call := func(name string, args map[string]any) string {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+		result, err := s.GetTool(name).Handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	if text := call("zentao_search_tools", map[string]any{"query": "resolve bug"}); !strings.Contains(text, `"resolve_bug"`) {
+		t.Errorf("Expected resolve_bug in the search results: %s", text)
+	}
+	if text := call("zentao_describe_tool", map[string]any{"name": "resolve_bug"}); !strings.Contains(text, `"inputSchema"`) || !strings.Contains(text, "zentao_enable_toolset") {
+		t.Errorf("Expected the schema and an enable hint: %s", text)
+	}
+	if text := call("zentao_enable_toolset", map[string]any{"name": "bugs"}); !strings.Contains(text, "resolve_bug") {
+		t.Errorf("Expected resolve_bug to be enabled: %s", text)
+	}
+	if s.GetTool("resolve_bug") == nil {
+		t.Error("Expected resolve_bug to be registered")
+	}
+}
@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -21,7 +22,7 @@ import (
 )
 
 // RegisterDocTools registers all documentation-related tools
-func RegisterDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterDocTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Space Management
 	registerSpaceTools(s, client)
 	// Library Management
@@ -38,7 +39,7 @@ func RegisterDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	registerCatalogTools(s, client)
 }
 
-func registerSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerSpaceTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createSpaceTool := mcp.NewTool("doc_create_space",
 		mcp.WithDescription("Create a new documentation space"),
 		mcp.WithString("type",
@@ -55,7 +56,7 @@ func registerSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=doc&f=createSpace&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=doc&f=createSpace&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create space: %v", err)), nil
 		}
@@ -74,7 +75,7 @@ func registerSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		spaceID := int(args["spaceID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=editSpace&t=json&spaceID=%d", spaceID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=editSpace&t=json&spaceID=%d", spaceID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit space: %v", err)), nil
 		}
@@ -93,7 +94,7 @@ func registerSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		libID := int(args["libID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=deleteSpace&t=json&libID=%d", libID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=deleteSpace&t=json&libID=%d", libID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete space: %v", err)), nil
 		}
@@ -101,7 +102,7 @@ func registerSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerLibTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createLibTool := mcp.NewTool("doc_create_lib",
 		mcp.WithDescription("Create a new documentation library"),
 		mcp.WithString("type",
@@ -127,7 +128,7 @@ func registerLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["libID"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/index.php?m=doc&f=createLib&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=doc&f=createLib&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create library: %v", err)), nil
 		}
@@ -146,7 +147,7 @@ func registerLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		libID := int(args["libID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=editLib&t=json&libID=%d", libID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=editLib&t=json&libID=%d", libID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit library: %v", err)), nil
 		}
@@ -165,7 +166,7 @@ func registerLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		libID := int(args["libID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=deleteLib&t=json&libID=%d", libID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=deleteLib&t=json&libID=%d", libID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete library: %v", err)), nil
 		}
@@ -173,7 +174,7 @@ func registerLibTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerDocTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createDocTool := mcp.NewTool("doc_create",
 		mcp.WithDescription("Create a new document"),
 		mcp.WithString("objectType",
@@ -213,7 +214,7 @@ func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["appendLib"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/index.php?m=doc&f=create&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=doc&f=create&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create document: %v", err)), nil
 		}
@@ -242,7 +243,7 @@ func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["appendLib"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=doc&f=edit&t=json&docID=%d", docID), params)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=edit&t=json&docID=%d", docID), params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit document: %v", err)), nil
 		}
@@ -261,7 +262,7 @@ func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		docID := int(args["docID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=delete&t=json&docID=%d", docID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=delete&t=json&docID=%d", docID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete document: %v", err)), nil
 		}
@@ -287,7 +288,7 @@ func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&version=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view document: %v", err)), nil
 		}
@@ -329,7 +330,7 @@ func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["moduleID"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/index.php?m=doc&f=uploadDocs&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=doc&f=uploadDocs&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to upload documents: %v", err)), nil
 		}
@@ -337,7 +338,7 @@ func registerDocTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerTemplateTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerTemplateTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createTemplateTool := mcp.NewTool("doc_create_template",
 		mcp.WithDescription("Create a new document template"),
 		mcp.WithNumber("moduleID",
@@ -352,7 +353,7 @@ func registerTemplateTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		params["moduleID"] = int(args["moduleID"].(float64))
 
-		resp, err := client.Post("/index.php?m=doc&f=createTemplate&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=doc&f=createTemplate&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create template: %v", err)), nil
 		}
@@ -371,7 +372,7 @@ func registerTemplateTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		docID := int(args["docID"].(float64))
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=doc&f=editTemplate&t=json&docID=%d", docID), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=editTemplate&t=json&docID=%d", docID), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit template: %v", err)), nil
 		}
@@ -390,7 +391,7 @@ func registerTemplateTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		templateID := int(args["templateID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=deleteTemplate&t=json&templateID=%d", templateID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=deleteTemplate&t=json&templateID=%d", templateID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete template: %v", err)), nil
 		}
@@ -430,15 +431,15 @@ func registerTemplateTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=doc&f=browseTemplate&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=doc&f=browseTemplate&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse templates: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
 	})
 }
 
-func registerBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerBrowseTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	mySpaceTool := mcp.NewTool("doc_my_space",
 		mcp.WithDescription("Browse my documentation space"),
 		mcp.WithNumber("objectID", mcp.Description("Object ID")),
@@ -495,7 +496,7 @@ func registerBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["search"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=doc&f=mySpace&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=doc&f=mySpace&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse my space: %v", err)), nil
 		}
@@ -560,7 +561,7 @@ func registerBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["search"] = v
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=productSpace&t=json&objectID=%d", objectID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=productSpace&t=json&objectID=%d", objectID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse product space: %v", err)), nil
 		}
@@ -625,7 +626,7 @@ func registerBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["search"] = v
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=projectSpace&t=json&objectID=%d", objectID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=projectSpace&t=json&objectID=%d", objectID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse project space: %v", err)), nil
 		}
@@ -687,7 +688,7 @@ func registerBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=tableContents&t=json&type=%s", docType))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=tableContents&t=json&type=%s", docType))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get table contents: %v", err)), nil
 		}
@@ -695,7 +696,7 @@ func registerBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerFileTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerFileTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	showFilesTool := mcp.NewTool("doc_show_files",
 		mcp.WithDescription("Show files in documentation"),
 		mcp.WithString("type", mcp.Description("Type")),
@@ -745,7 +746,7 @@ func registerFileTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["searchTitle"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=doc&f=showFiles&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=doc&f=showFiles&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to show files: %v", err)), nil
 		}
@@ -776,7 +777,7 @@ func registerFileTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			url += fmt.Sprintf("&confirm=%s", v)
 		}
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete file: %v", err)), nil
 		}
@@ -784,7 +785,7 @@ func registerFileTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerCatalogTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerCatalogTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	editCatalogTool := mcp.NewTool("doc_edit_catalog",
 		mcp.WithDescription("Edit a document catalog"),
 		mcp.WithNumber("moduleID",
@@ -803,7 +804,7 @@ func registerCatalogTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		moduleID := int(args["moduleID"].(float64))
 		docType := args["type"].(string)
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=editCatalog&t=json&moduleID=%d&type=%s", moduleID, docType))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=editCatalog&t=json&moduleID=%d&type=%s", moduleID, docType))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit catalog: %v", err)), nil
 		}
@@ -822,7 +823,7 @@ func registerCatalogTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		moduleID := int(args["moduleID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=doc&f=deleteCatalog&t=json&moduleID=%d", moduleID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=doc&f=deleteCatalog&t=json&moduleID=%d", moduleID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete catalog: %v", err)), nil
 		}
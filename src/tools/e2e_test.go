@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/ztfake"
+)
+
+// newFakeServer starts ztfake and returns an MCP server whose tools talk to
+// it through a real client using app authentication
+func newFakeServer(t *testing.T, register ...func(*server.MCPServer, client.ZenTaoAPI)) (*ztfake.Server, *server.MCPServer) {
+	t.Helper()
+
+	fake := ztfake.New(ztfake.Options{})
+	t.Cleanup(fake.Close)
+
+	opts := fake.Options()
+	c := client.NewZenTaoClientWithApp(fake.BaseURL(), opts.AppCode, opts.AppKey)
+	c.SetRetryPolicy(client.RetryPolicy{MaxRetries: 0})
+
+	s := server.NewMCPServer("test-server", "1.0.0")
+	for _, r := range register {
+		r(s, c)
+	}
+	return fake, s
+}
+
+func TestEndToEndProductLifecycle(t *testing.T) {
+	fake, s := newFakeServer(t, RegisterProductTools)
+
+	result := callTool(t, s, "create_product", map[string]interface{}{"name": "Widget", "code": "WGT"})
+	if result.IsError {
+		t.Fatalf("create_product failed: %s", resultText(t, result))
+	}
+	if !strings.Contains(resultText(t, result), `"id":1`) {
+		t.Errorf("Expected new product ID in response, got %s", resultText(t, result))
+	}
+
+	result = callTool(t, s, "update_product", map[string]interface{}{"id": float64(1), "name": "Widget Pro"})
+	if result.IsError {
+		t.Fatalf("update_product failed: %s", resultText(t, result))
+	}
+	if product, _ := fake.Record("product", 1); product["name"] != "Widget Pro" || product["code"] != "WGT" {
+		t.Errorf("Expected renamed product, got %v", product)
+	}
+
+	result = callTool(t, s, "get_product", map[string]interface{}{"id": float64(1)})
+	if result.IsError || !strings.Contains(resultText(t, result), `"name":"Widget Pro"`) {
+		t.Errorf("Expected get_product to return the decoded product, got %s", resultText(t, result))
+	}
+
+	fake.Inject(ztfake.Fault{Module: "product", Function: "view", Times: 1,
+		Body: `{"status":"success","data":"{\"name\":\"Widget\"}","md5":"00000000000000000000000000000000"}`})
+	result = callTool(t, s, "get_product", map[string]interface{}{"id": float64(1)})
+	if !result.IsError || !strings.Contains(resultText(t, result), "md5 mismatch") {
+		t.Errorf("Expected a checksum mismatch to fail the tool, got %s", resultText(t, result))
+	}
+
+	result = callTool(t, s, "delete_product", map[string]interface{}{"id": float64(1)})
+	if result.IsError {
+		t.Fatalf("delete_product failed: %s", resultText(t, result))
+	}
+
+	result = callTool(t, s, "get_product", map[string]interface{}{"id": float64(1)})
+	if !result.IsError || !strings.Contains(resultText(t, result), "not found") {
+		t.Errorf("Expected deleted product to be reported missing, got %s", resultText(t, result))
+	}
+
+	for _, req := range fake.Requests() {
+		if req.Query.Get("token") == "" {
+			t.Errorf("Request m=%s&f=%s was sent without a token", req.Module, req.Function)
+		}
+	}
+}
+
+func TestEndToEndBugWorkflow(t *testing.T) {
+	fake, s := newFakeServer(t, RegisterBugTools)
+	fake.Seed("bug", map[string]interface{}{"title": "Crash on save", "product": 1})
+	fake.Seed("bug", map[string]interface{}{"title": "Typo", "product": 2})
+
+	result := callTool(t, s, "browse_bugs", map[string]interface{}{"productID": float64(1)})
+	if result.IsError {
+		t.Fatalf("browse_bugs failed: %s", resultText(t, result))
+	}
+	if text := resultText(t, result); !strings.Contains(text, "Crash on save") || strings.Contains(text, "Typo") {
+		t.Errorf("Expected only product 1 bugs, got %s", text)
+	}
+
+	result = callTool(t, s, "resolve_bug", map[string]interface{}{"bugID": float64(1), "resolution": "fixed"})
+	if result.IsError {
+		t.Fatalf("resolve_bug failed: %s", resultText(t, result))
+	}
+	if bug, _ := fake.Record("bug", 1); bug["status"] != "resolved" {
+		t.Errorf("Expected bug to be resolved, got %v", bug)
+	}
+
+	result = callTool(t, s, "get_bug", map[string]interface{}{"id": float64(1)})
+	if result.IsError || !strings.Contains(resultText(t, result), "resolved") {
+		t.Errorf("Expected get_bug to show the resolution, got %s", resultText(t, result))
+	}
+}
+
+func TestEndToEndInjectedFaults(t *testing.T) {
+	fake, s := newFakeServer(t, RegisterStoryTools)
+	fake.Seed("story", map[string]interface{}{"title": "Checkout", "product": 1})
+
+	fake.FailNext("story", "view", 1, http.StatusInternalServerError)
+	result := callTool(t, s, "get_story", map[string]interface{}{"id": float64(1)})
+	if !result.IsError || !strings.HasPrefix(resultText(t, result), "Failed to get story") {
+		t.Errorf("Expected injected 500 to surface as a tool error, got %s", resultText(t, result))
+	}
+
+	result = callTool(t, s, "get_story", map[string]interface{}{"id": float64(1)})
+	if result.IsError || !strings.Contains(resultText(t, result), "Checkout") {
+		t.Errorf("Expected story once the fault is used up, got %s", resultText(t, result))
+	}
+
+	fake.Inject(ztfake.Fault{Module: "story", Function: "create", Body: map[string]interface{}{
+		"result":  "fail",
+		"message": map[string]interface{}{"title": []string{"Title already exists"}},
+	}})
+	result = callTool(t, s, "create_story", map[string]interface{}{
+		"title": "Checkout", "product": float64(1), "pri": float64(3), "category": "feature",
+	})
+	if !result.IsError || !strings.Contains(resultText(t, result), "Title already exists") {
+		t.Errorf("Expected validation message in tool error, got %s", resultText(t, result))
+	}
+	if fake.Count("story") != 1 {
+		t.Errorf("Expected rejected story not to be stored, got %d stories", fake.Count("story"))
+	}
+}
+
+func TestEndToEndSessionRelogin(t *testing.T) {
+	fake := ztfake.New(ztfake.Options{})
+	defer fake.Close()
+	fake.Seed("task", map[string]interface{}{"name": "Write docs", "execution": 3})
+
+	opts := fake.Options()
+	c := client.NewZenTaoClientWithSession(fake.BaseURL())
+	c.SetRetryPolicy(client.RetryPolicy{MaxRetries: 0})
+	c.SetSessionOptions(client.SessionOptions{Account: opts.Account, Password: opts.Password})
+
+	s := server.NewMCPServer("test-server", "1.0.0")
+	RegisterTaskTools(s, c)
+
+	for i := 0; i < 2; i++ {
+		result := callTool(t, s, "get_task", map[string]interface{}{"id": float64(1)})
+		if result.IsError || !strings.Contains(resultText(t, result), "Write docs") {
+			t.Fatalf("Call %d: expected task, got %s", i, resultText(t, result))
+		}
+		// The second call must survive a server-side session timeout
+		fake.ExpireSessions()
+	}
+
+	logins := 0
+	for _, req := range fake.Requests() {
+		if req.Module == "user" && req.Function == "login" {
+			logins++
+		}
+	}
+	if logins != 2 {
+		t.Errorf("Expected lazy login plus one re-login, got %d logins", logins)
+	}
+}
+
+func TestEndToEndLegacyBrowseView(t *testing.T) {
+	fake, s := newFakeServer(t, RegisterAdminTools)
+	fake.Inject(ztfake.Fault{Module: "company", Function: "browse",
+		Body: `{"status":"success","data":"{\"users\":[{\"account\":\"admin\"}]}"}`})
+
+	result := callTool(t, s, "company_browse", map[string]interface{}{"browseType": "inside", "recPerPage": float64(20), "pageID": float64(2)})
+	if result.IsError || resultText(t, result) != `{"users":[{"account":"admin"}]}` {
+		t.Errorf("Expected company_browse to return the decoded data, got %s", resultText(t, result))
+	}
+
+	requests := fake.Requests()
+	query := requests[len(requests)-1].Query
+	if query.Get("browseType") != "inside" || query.Get("recPerPage") != "20" || query.Get("pageID") != "2" {
+		t.Errorf("Expected the browse filters in the query, got %v", query)
+	}
+}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterEntryTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterEntryTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createEntryTool := mcp.NewTool("create_entry",
 		mcp.WithDescription("Create a new entry in ZenTao"),
 		mcp.WithString("name",
@@ -64,7 +65,7 @@ func RegisterEntryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["version"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=entry&f=create&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=entry&f=create&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create entry: %v", err)), nil
 		}
@@ -122,7 +123,7 @@ func RegisterEntryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["version"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=entry&f=edit&t=json&id=%d", int(args["id"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=entry&f=edit&t=json&id=%d", int(args["id"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit entry: %v", err)), nil
 		}
@@ -142,7 +143,7 @@ func RegisterEntryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=entry&f=delete&t=json&id=%d", id))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=entry&f=delete&t=json&id=%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete entry: %v", err)), nil
 		}
@@ -187,8 +188,8 @@ func RegisterEntryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=entry&f=browse&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=entry&f=browse&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse entries: %v", err)), nil
 		}
 
@@ -233,8 +234,8 @@ func RegisterEntryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=entry&f=log&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=entry&f=log&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get entry log: %v", err)), nil
 		}
 
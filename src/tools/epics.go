@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterEpicTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Epic CRUD operations
 	createEpicTool := mcp.NewTool("create_epic",
 		mcp.WithDescription("Create a new epic"),
@@ -108,7 +109,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["estimate"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=create&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=create&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create epic: %v", err)), nil
 		}
@@ -186,7 +187,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["specs"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=batchCreate&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=batchCreate&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create epics: %v", err)), nil
 		}
@@ -219,8 +220,8 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&param=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=view&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=epic&f=view&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view epic: %v", err)), nil
 		}
 
@@ -273,7 +274,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["estimate"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=edit&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=edit&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit epic: %v", err)), nil
 		}
@@ -339,7 +340,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["specs"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=batchEdit&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=batchEdit&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit epics: %v", err)), nil
 		}
@@ -373,7 +374,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=delete&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=delete&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete epic: %v", err)), nil
 		}
@@ -431,7 +432,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=linkStories&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=linkStories&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link stories to epic: %v", err)), nil
 		}
@@ -488,7 +489,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=linkRequirements&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=linkRequirements&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link requirements to epic: %v", err)), nil
 		}
@@ -528,7 +529,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&projectID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=import&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=import&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import epics: %v", err)), nil
 		}
@@ -567,7 +568,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=export&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=export&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export epics: %v", err)), nil
 		}
@@ -600,7 +601,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&storyType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=epic&f=exportTemplate&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=exportTemplate&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export epic template: %v", err)), nil
 		}
@@ -628,7 +629,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"assignedTo": int(args["assignedTo"].(float64)),
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=assignTo&t=json&storyID=%d", int(args["storyID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=assignTo&t=json&storyID=%d", int(args["storyID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign epic: %v", err)), nil
 		}
@@ -664,7 +665,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["storyType"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=epic&f=batchAssignTo&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=epic&f=batchAssignTo&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch assign epics: %v", err)), nil
 		}
@@ -693,7 +694,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=close&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=close&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close epic: %v", err)), nil
 		}
@@ -733,7 +734,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=batchClose&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=batchClose&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch close epics: %v", err)), nil
 		}
@@ -754,7 +755,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=activate&t=json&storyID=%d", int(args["storyID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=activate&t=json&storyID=%d", int(args["storyID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate epic: %v", err)), nil
 		}
@@ -785,7 +786,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=review&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=epic&f=review&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to review epic: %v", err)), nil
 		}
@@ -815,7 +816,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["reason"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=epic&f=batchReview&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=epic&f=batchReview&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch review epics: %v", err)), nil
 		}
@@ -875,7 +876,7 @@ func RegisterEpicTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=epic&f=report&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(retrySafe(ctx), fmt.Sprintf("/index.php?m=epic&f=report&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate epic report: %v", err)), nil
 		}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterExecutionTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseExecutionTool := mcp.NewTool("browse_execution",
 		mcp.WithDescription("Browse execution details"),
 		mcp.WithNumber("executionID",
@@ -31,8 +32,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		executionID := int(args["executionID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=browse&t=json&executionID=%d", executionID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=browse&t=json&executionID=%d", executionID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse execution: %v", err)), nil
 		}
 
@@ -100,8 +101,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&blockID=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=task&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=task&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution tasks: %v", err)), nil
 		}
 
@@ -177,8 +178,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&blockID=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=story&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=story&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution stories: %v", err)), nil
 		}
 
@@ -250,8 +251,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=bug&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=bug&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution bugs: %v", err)), nil
 		}
 
@@ -308,8 +309,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=build&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=build&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution builds: %v", err)), nil
 		}
 
@@ -349,8 +350,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&burnBy=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=burn&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=burn&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution burn chart: %v", err)), nil
 		}
 
@@ -395,8 +396,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&end=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=execution&f=cfd&t=json&%s", queryParams), nil)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.PostJSON(retrySafe(ctx), fmt.Sprintf("/index.php?m=execution&f=cfd&t=json&%s", queryParams), nil, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution CFD: %v", err)), nil
 		}
 
@@ -436,8 +437,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&groupBy=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=kanban&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=kanban&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution kanban: %v", err)), nil
 		}
 
@@ -456,8 +457,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		executionID := int(args["executionID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=team&t=json&executionID=%d", executionID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=team&t=json&executionID=%d", executionID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution team: %v", err)), nil
 		}
 
@@ -498,7 +499,7 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&dept=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=execution&f=manageMembers&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=manageMembers&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage execution members: %v", err)), nil
 		}
@@ -521,7 +522,7 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unlinkExecutionMemberTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=unlinkMember&t=json&executionID=%d&userID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=unlinkMember&t=json&executionID=%d&userID=%d",
 			int(args["executionID"].(float64)), int(args["userID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink execution member: %v", err)), nil
@@ -586,7 +587,7 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&storyType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=execution&f=linkStory&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=linkStory&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link story to execution: %v", err)), nil
 		}
@@ -636,7 +637,7 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&columnID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=unlinkStory&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=unlinkStory&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink story from execution: %v", err)), nil
 		}
@@ -656,7 +657,7 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		executionID := int(args["executionID"].(float64))
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=execution&f=batchUnlinkStory&t=json&executionID=%d", executionID), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=execution&f=batchUnlinkStory&t=json&executionID=%d", executionID), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink stories from execution: %v", err)), nil
 		}
@@ -708,8 +709,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&direction=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=dynamic&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=dynamic&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution dynamic: %v", err)), nil
 		}
 
@@ -767,8 +768,8 @@ func RegisterExecutionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=execution&f=all&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=execution&f=all&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse all executions: %v", err)), nil
 		}
 
@@ -21,7 +21,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterFeedbackTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterFeedbackTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createFeedbackTool := mcp.NewTool("create_feedback",
 		mcp.WithDescription("Create a new feedback in ZenTao"),
 		mcp.WithNumber("product",
@@ -85,7 +85,7 @@ func RegisterFeedbackTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["feedbackBy"] = v
 		}
 
-		resp, err := client.Post("/feedbacks", body)
+		resp, err := client.PostCtx(ctx, "/feedbacks", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create feedback: %v", err)), nil
 		}
@@ -162,7 +162,7 @@ func RegisterFeedbackTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["feedbackBy"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/feedbacks/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/feedbacks/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update feedback: %v", err)), nil
 		}
@@ -203,7 +203,7 @@ func RegisterFeedbackTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["mailto"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/feedbacks/%d/assign", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/feedbacks/%d/assign", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign feedback: %v", err)), nil
 		}
@@ -238,7 +238,7 @@ func RegisterFeedbackTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["comment"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/feedbacks/%d/close", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/feedbacks/%d/close", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close feedback: %v", err)), nil
 		}
@@ -258,7 +258,7 @@ func RegisterFeedbackTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/feedbacks/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/feedbacks/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete feedback: %v", err)), nil
 		}
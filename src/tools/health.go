@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+)
+
+// instanceResolver is implemented by *client.ZenTaoClient, which carries the
+// breaker and auth state the health report needs
+type instanceResolver interface {
+	ForContext(ctx context.Context) *client.ZenTaoClient
+}
+
+func RegisterHealthTools(s *server.MCPServer, client client.ZenTaoAPI) {
+	// Handle nil client for testing
+	if client == nil {
+		logger.Warn("tools", "Nil client provided to RegisterHealthTools", nil)
+		return
+	}
+
+	healthTool := mcp.NewTool("zentao_health",
+		mcp.WithDescription("Report ZenTao connectivity: circuit breaker state, auth status, server clock skew, deduplicated requests and optionally a live probe"),
+		mcp.WithBoolean("probe",
+			mcp.Description("Send a lightweight request to ZenTao and report the result (fails fast while the breaker is open)"),
+		),
+	)
+
+	s.AddTool(healthTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		probe, _ := args["probe"].(bool)
+
+		logger.LogMCPToolCall("zentao_health", map[string]interface{}{
+			"probe": probe,
+		})
+
+		resolver, ok := client.(instanceResolver)
+		if !ok {
+			return mcp.NewToolResultError("Failed to read health: client does not expose connectivity details"), nil
+		}
+
+		// Report on the instance selected for this call
+		target := resolver.ForContext(ctx)
+
+		breaker := target.BreakerStatus()
+		health := map[string]interface{}{
+			"base_url":        target.BaseURL,
+			"auth_method":     authMethodName(target.GetAuthMethod()),
+			"api_mode":        target.GetAPIMode().String(),
+			"authenticated":   target.IsAuthenticated(),
+			"circuit_breaker": breaker,
+			"available":       breaker.State != "open",
+			"coalescing":      target.CoalesceStats(),
+			"clock_skew":      target.ClockSkew(),
+		}
+
+		if probe {
+			start := time.Now()
+			_, err := target.GetCtx(ctx, "/index.php?m=misc&f=ping")
+			result := map[string]interface{}{
+				"ok":          err == nil,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				result["error"] = err.Error()
+			}
+			health["probe"] = result
+			health["circuit_breaker"] = target.BreakerStatus()
+
+			// Measure the clock offset separately: it needs no valid token
+			if _, err := target.ProbeClockSkew(ctx); err != nil {
+				result["clock_probe_error"] = err.Error()
+			}
+			health["clock_skew"] = target.ClockSkew()
+		}
+
+		logger.Info("health", "Health check completed", map[string]interface{}{
+			"breaker_state": breaker.State,
+			"probe":         probe,
+		})
+
+		resp, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode health status: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(resp)), nil
+	})
+}
+
+func authMethodName(method client.AuthMethod) string {
+	switch method {
+	case client.AuthApp:
+		return "app"
+	case client.AuthSession:
+		return "session"
+	case client.AuthToken:
+		return "token"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+)
+
+// instanceArgument is the optional argument every tool accepts to pick a ZenTao instance
+const instanceArgument = "instance"
+
+// InstanceMiddleware routes each tool call to the client named by its optional
+// "instance" argument; calls without it go to the pool's default instance.
+// Calls from an HTTP caller with their own credentials use that caller's client.
+func InstanceMiddleware(pool *client.Pool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.GetArguments()[instanceArgument].(string)
+			if _, _, isCaller := client.CallerFromContext(ctx); name == "" && !isCaller {
+				return next(ctx, request)
+			}
+
+			target, err := pool.ClientFor(ctx, name)
+			if err != nil {
+				logger.Warn("tools", "Failed to select instance for tool call", map[string]interface{}{
+					"tool":     request.Params.Name,
+					"instance": name,
+				})
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to select instance: %v", err)), nil
+			}
+
+			logger.Debug("tools", "Routing tool call to instance", map[string]interface{}{
+				"tool":     request.Params.Name,
+				"instance": name,
+			})
+			return next(client.WithClient(ctx, target), request)
+		}
+	}
+}
+
+// AddInstanceArgument adds the optional "instance" property to every registered
+// tool. Call it after all Register*Tools functions.
+func AddInstanceArgument(s *server.MCPServer, names []string, defaultName string) {
+	registered := s.ListTools()
+	updated := make([]server.ServerTool, 0, len(registered))
+
+	for _, tool := range registered {
+		if tool.Tool.InputSchema.Properties == nil {
+			if len(tool.Tool.RawInputSchema) > 0 {
+				continue
+			}
+			tool.Tool.InputSchema.Properties = map[string]any{}
+		}
+		if _, exists := tool.Tool.InputSchema.Properties[instanceArgument]; exists {
+			continue
+		}
+
+		properties := make(map[string]any, len(tool.Tool.InputSchema.Properties)+1)
+		for key, value := range tool.Tool.InputSchema.Properties {
+			properties[key] = value
+		}
+		properties[instanceArgument] = map[string]any{
+			"type":        "string",
+			"description": fmt.Sprintf("ZenTao instance to use (default: %s)", defaultName),
+			"enum":        names,
+		}
+		tool.Tool.InputSchema.Properties = properties
+		updated = append(updated, *tool)
+	}
+
+	s.AddTools(updated...)
+
+	logger.Debug("tools", "Added instance argument to tools", map[string]interface{}{
+		"tool_count": len(updated),
+		"instances":  names,
+	})
+}
+
+// RegisterInstanceTools registers list_instances
+func RegisterInstanceTools(s *server.MCPServer, pool *client.Pool) {
+	// Handle nil pool for testing
+	if pool == nil {
+		logger.Warn("tools", "Nil pool provided to RegisterInstanceTools", nil)
+		return
+	}
+
+	listTool := mcp.NewTool("list_instances",
+		mcp.WithDescription("List the configured ZenTao instances with their connectivity and auth status"),
+		mcp.WithBoolean("probe",
+			mcp.Description("Ping every instance and include the result"),
+		),
+	)
+
+	s.AddTool(listTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		probe, _ := args["probe"].(bool)
+
+		logger.LogMCPToolCall("list_instances", map[string]interface{}{
+			"probe": probe,
+		})
+
+		names := pool.Names()
+		instances := make([]map[string]interface{}, len(names))
+
+		var wg sync.WaitGroup
+		for i, name := range names {
+			wg.Add(1)
+			go func(i int, name string) {
+				defer wg.Done()
+				instances[i] = describeInstance(ctx, pool, name, probe)
+			}(i, name)
+		}
+		wg.Wait()
+
+		resp, err := json.MarshalIndent(map[string]interface{}{
+			"default_instance": pool.DefaultName(),
+			"instances":        instances,
+		}, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode instances: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(resp)), nil
+	})
+}
+
+// describeInstance reports an instance as the caller's tools see it: on the
+// HTTP transport that is the caller's own client, not the server's account
+func describeInstance(ctx context.Context, pool *client.Pool, name string, probe bool) map[string]interface{} {
+	profile, _ := pool.Profile(name)
+	info := map[string]interface{}{
+		"name":     name,
+		"base_url": profile.BaseURL,
+		"default":  name == pool.DefaultName(),
+	}
+
+	c, err := pool.ClientFor(ctx, name)
+	if err != nil {
+		info["available"] = false
+		info["error"] = err.Error()
+		return info
+	}
+	info["auth_method"] = authMethodName(c.GetAuthMethod())
+	info["api_mode"] = c.GetAPIMode().String()
+	info["authenticated"] = c.IsAuthenticated()
+
+	if probe {
+		start := time.Now()
+		_, err := c.GetCtx(client.WithClient(ctx, c), "/index.php?m=misc&f=ping")
+		result := map[string]interface{}{
+			"ok":          err == nil,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			result["error"] = err.Error()
+		}
+		info["probe"] = result
+	}
+
+	breaker := c.BreakerStatus()
+	info["circuit_breaker"] = breaker.State
+	info["available"] = breaker.State != "open"
+	return info
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+)
+
+func newTestPool(t *testing.T) *client.Pool {
+	t.Helper()
+	profiles := []client.InstanceProfile{
+		{Name: "eng", BaseURL: "http://eng.example.com/index.php", AuthMethod: "app"},
+		{Name: "partner", BaseURL: "http://partner.example.com/index.php", AuthMethod: "session"},
+	}
+	pool, err := client.NewPool(profiles, "eng", client.DefaultClientOptions())
+	if err != nil {
+		t.Fatalf("Failed to build pool: %v", err)
+	}
+	return pool
+}
+
+func TestInstanceMiddleware(t *testing.T) {
+	pool := newTestPool(t)
+
+	var selected *client.ZenTaoClient
+	handler := InstanceMiddleware(pool)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		selected = pool.Default().ForContext(ctx)
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	tests := []struct {
+		instance string
+		expected string
+		isError  bool
+	}{
+		{"", "http://eng.example.com/index.php", false},
+		{"partner", "http://partner.example.com/index.php", false},
+		{"unknown", "", true},
+	}
+
+	for _, test := range tests {
+		selected = nil
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "get_product"
+		request.Params.Arguments = map[string]any{"id": 1, "instance": test.instance}
+
+		result, err := handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.IsError != test.isError {
+			t.Errorf("instance %q: expected IsError=%v", test.instance, test.isError)
+		}
+		if !test.isError && selected.BaseURL != test.expected {
+			t.Errorf("instance %q: routed to %s, expected %s", test.instance, selected.BaseURL, test.expected)
+		}
+	}
+}
+
+func TestAddInstanceArgument(t *testing.T) {
+	pool := newTestPool(t)
+	s := server.NewMCPServer("test", "1.0.0")
+
+	RegisterInstanceTools(s, pool)
+	s.AddTool(mcp.NewTool("get_product", mcp.WithNumber("id", mcp.Required())), nil)
+	AddInstanceArgument(s, pool.Names(), pool.DefaultName())
+
+	for name, tool := range s.ListTools() {
+		property, ok := tool.Tool.InputSchema.Properties["instance"].(map[string]any)
+		if !ok {
+			t.Errorf("Tool %s has no instance argument", name)
+			continue
+		}
+		if enum, _ := property["enum"].([]string); len(enum) != 2 {
+			t.Errorf("Tool %s: expected instance enum of 2 names, got %v", name, property["enum"])
+		}
+		for _, required := range tool.Tool.InputSchema.Required {
+			if required == "instance" {
+				t.Errorf("Tool %s: instance must be optional", name)
+			}
+		}
+	}
+}
+
+func TestDescribeInstanceUsesCallerClient(t *testing.T) {
+	pool := newTestPool(t)
+	ctx := client.WithCaller(context.Background(), "s1", client.Caller{Account: "alice", Password: "pw1"})
+
+	info := describeInstance(ctx, pool, "eng", false)
+	if info["error"] == nil || info["available"] != false {
+		t.Errorf("Expected the caller's client error without per-caller credentials, got %v", info)
+	}
+
+	pool.EnableCallers(client.CallerConfig{Bearer: true})
+	defer pool.Close()
+
+	// App auth instances log callers in with a session
+	info = describeInstance(ctx, pool, "eng", false)
+	if info["auth_method"] != "session" || info["error"] != nil {
+		t.Errorf("Expected the caller's session client, got %v", info)
+	}
+	if info = describeInstance(context.Background(), pool, "eng", false); info["auth_method"] != "app" {
+		t.Errorf("Expected the shared client without a caller, got %v", info)
+	}
+}
+
+func TestRegisterInstanceToolsWithNilPool(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	RegisterInstanceTools(s, nil)
+}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterKanbanTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Kanban Space Management
 	getKanbanSpacesTool := mcp.NewTool("get_kanban_spaces",
 		mcp.WithDescription("Get kanban spaces"),
@@ -54,8 +55,8 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=space&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=space&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get kanban spaces: %v", err)), nil
 		}
 
@@ -89,7 +90,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=createSpace&t=json&type=%s", args["type"]), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=createSpace&t=json&type=%s", args["type"]), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create kanban space: %v", err)), nil
 		}
@@ -123,7 +124,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=editSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=editSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit kanban space: %v", err)), nil
 		}
@@ -144,7 +145,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=activateSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=activateSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate kanban space: %v", err)), nil
 		}
@@ -165,7 +166,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=closeSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=closeSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close kanban space: %v", err)), nil
 		}
@@ -184,7 +185,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteKanbanSpaceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=deleteSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=deleteSpace&t=json&spaceID=%d", int(args["spaceID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete kanban space: %v", err)), nil
 		}
@@ -239,7 +240,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=create&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=create&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create kanban: %v", err)), nil
 		}
@@ -273,7 +274,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=edit&t=json&kanbanID=%d", int(args["kanbanID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=edit&t=json&kanbanID=%d", int(args["kanbanID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit kanban: %v", err)), nil
 		}
@@ -300,8 +301,8 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&regionID=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=view&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=view&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view kanban: %v", err)), nil
 		}
 
@@ -319,7 +320,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteKanbanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=delete&t=json&kanbanID=%d", int(args["kanbanID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=delete&t=json&kanbanID=%d", int(args["kanbanID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete kanban: %v", err)), nil
 		}
@@ -363,7 +364,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=createRegion&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=createRegion&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create kanban region: %v", err)), nil
 		}
@@ -397,7 +398,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=editRegion&t=json&regionID=%d", int(args["regionID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=editRegion&t=json&regionID=%d", int(args["regionID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit kanban region: %v", err)), nil
 		}
@@ -416,7 +417,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteKanbanRegionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=deleteRegion&t=json&regionID=%d", int(args["regionID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=deleteRegion&t=json&regionID=%d", int(args["regionID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete kanban region: %v", err)), nil
 		}
@@ -464,7 +465,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["color"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=createLane&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=createLane&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create kanban lane: %v", err)), nil
 		}
@@ -487,7 +488,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteKanbanLaneTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=deleteLane&t=json&regionID=%d&laneID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=deleteLane&t=json&regionID=%d&laneID=%d",
 			int(args["regionID"].(float64)), int(args["laneID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete kanban lane: %v", err)), nil
@@ -529,7 +530,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["limit"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=createColumn&t=json&fromColumnID=%d&position=%s",
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=createColumn&t=json&fromColumnID=%d&position=%s",
 			int(args["fromColumnID"].(float64)), args["position"]), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create kanban column: %v", err)), nil
@@ -557,7 +558,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"name": args["name"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=splitColumn&t=json&columnID=%d", int(args["columnID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=splitColumn&t=json&columnID=%d", int(args["columnID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to split kanban column: %v", err)), nil
 		}
@@ -576,7 +577,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(archiveKanbanColumnTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=archiveColumn&t=json&columnID=%d", int(args["columnID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=archiveColumn&t=json&columnID=%d", int(args["columnID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to archive kanban column: %v", err)), nil
 		}
@@ -595,7 +596,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteKanbanColumnTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=deleteColumn&t=json&columnID=%d", int(args["columnID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=deleteColumn&t=json&columnID=%d", int(args["columnID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete kanban column: %v", err)), nil
 		}
@@ -660,7 +661,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["color"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=createCard&t=json&kanbanID=%d&regionID=%d&groupID=%d&columnID=%d",
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=createCard&t=json&kanbanID=%d&regionID=%d&groupID=%d&columnID=%d",
 			int(args["kanbanID"].(float64)), int(args["regionID"].(float64)), int(args["groupID"].(float64)), int(args["columnID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create kanban card: %v", err)), nil
@@ -713,7 +714,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["color"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=editCard&t=json&cardID=%d", int(args["cardID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=editCard&t=json&cardID=%d", int(args["cardID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit kanban card: %v", err)), nil
 		}
@@ -732,8 +733,8 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(viewKanbanCardTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=viewCard&t=json&cardID=%d", int(args["cardID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=viewCard&t=json&cardID=%d", int(args["cardID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view kanban card: %v", err)), nil
 		}
 
@@ -784,7 +785,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&showModal=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=moveCard&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=moveCard&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to move kanban card: %v", err)), nil
 		}
@@ -803,7 +804,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(finishKanbanCardTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=finishCard&t=json&cardID=%d", int(args["cardID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=finishCard&t=json&cardID=%d", int(args["cardID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to finish kanban card: %v", err)), nil
 		}
@@ -824,7 +825,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=activateCard&t=json&cardID=%d", int(args["cardID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=activateCard&t=json&cardID=%d", int(args["cardID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate kanban card: %v", err)), nil
 		}
@@ -843,7 +844,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(archiveKanbanCardTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=archiveCard&t=json&cardID=%d", int(args["cardID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=archiveCard&t=json&cardID=%d", int(args["cardID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to archive kanban card: %v", err)), nil
 		}
@@ -862,7 +863,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteKanbanCardTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=deleteCard&t=json&cardID=%d", int(args["cardID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=deleteCard&t=json&cardID=%d", int(args["cardID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete kanban card: %v", err)), nil
 		}
@@ -921,7 +922,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=kanban&f=importPlan&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=importPlan&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import cards from plan: %v", err)), nil
 		}
@@ -944,7 +945,7 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(setCardColorTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=setCardColor&t=json&cardID=%d&color=%s",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=kanban&f=setCardColor&t=json&cardID=%d&color=%s",
 			int(args["cardID"].(float64)), args["color"]))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to set kanban card color: %v", err)), nil
@@ -986,8 +987,8 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=ajaxGetLanes&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=ajaxGetLanes&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get kanban lanes: %v", err)), nil
 		}
 
@@ -1005,8 +1006,8 @@ func RegisterKanbanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getKanbanColumnsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=kanban&f=ajaxGetColumns&t=json&laneID=%d", int(args["laneID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=kanban&f=ajaxGetColumns&t=json&laneID=%d", int(args["laneID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get kanban columns: %v", err)), nil
 		}
 
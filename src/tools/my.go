@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,15 +19,15 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterMyTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Dashboard/Index
 	getMyDashboardTool := mcp.NewTool("get_my_dashboard",
 		mcp.WithDescription("Get user's personal dashboard in ZenTao"),
 	)
 
 	s.AddTool(getMyDashboardTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=my&f=index&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=my&f=index&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get dashboard: %v", err)), nil
 		}
 
@@ -65,8 +66,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=score&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=score&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get score: %v", err)), nil
 		}
 
@@ -79,8 +80,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getMyCalendarTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=my&f=calendar&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=my&f=calendar&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get calendar: %v", err)), nil
 		}
 
@@ -143,8 +144,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=work&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=work&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get work: %v", err)), nil
 		}
 
@@ -207,8 +208,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=contribute&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=contribute&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get contributions: %v", err)), nil
 		}
 
@@ -271,8 +272,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=todo&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=todo&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get todos: %v", err)), nil
 		}
 
@@ -329,8 +330,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=story&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=story&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get stories: %v", err)), nil
 		}
 
@@ -387,8 +388,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=task&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=task&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get tasks: %v", err)), nil
 		}
 
@@ -445,8 +446,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=bug&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=bug&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get bugs: %v", err)), nil
 		}
 
@@ -498,8 +499,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=project&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=project&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get projects: %v", err)), nil
 		}
 
@@ -551,8 +552,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=execution&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=execution&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get executions: %v", err)), nil
 		}
 
@@ -565,8 +566,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getMyProfileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=my&f=profile&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=my&f=profile&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get profile: %v", err)), nil
 		}
 
@@ -643,7 +644,7 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["weixin"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=my&f=editProfile&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=my&f=editProfile&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit profile: %v", err)), nil
 		}
@@ -671,7 +672,7 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"passwordConfirmation": args["passwordConfirmation"],
 		}
 
-		resp, err := client.Post("/index.php?m=my&f=changePassword&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=my&f=changePassword&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to change password: %v", err)), nil
 		}
@@ -717,8 +718,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=dynamic&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=dynamic&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get dynamic: %v", err)), nil
 		}
 
@@ -763,8 +764,8 @@ func RegisterMyTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=my&f=team&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=my&f=team&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get team: %v", err)), nil
 		}
 
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+)
+
+// MaxCollectedItems is the hard cap for all=true and max_items, so a single
+// tool call cannot pull an entire ZenTao instance into the conversation
+const MaxCollectedItems = 2000
+
+// pagingStyle tells which arguments a list tool uses to select a page
+type pagingStyle struct {
+	perPage string // recPerPage or limit
+	page    string // pageID, or offset when offset is set
+	offset  bool
+}
+
+// pagingStyleFor detects paged list tools by their arguments
+func pagingStyleFor(tool mcp.Tool) (pagingStyle, bool) {
+	if !strings.HasPrefix(tool.Name, "get_") && !strings.HasPrefix(tool.Name, "browse_") {
+		return pagingStyle{}, false
+	}
+	properties := tool.InputSchema.Properties
+	has := func(name string) bool {
+		_, ok := properties[name]
+		return ok
+	}
+	switch {
+	case has("recPerPage") && has("pageID"):
+		return pagingStyle{perPage: "recPerPage", page: "pageID"}, true
+	case has("limit") && has("offset"):
+		return pagingStyle{perPage: "limit", page: "offset", offset: true}, true
+	}
+	return pagingStyle{}, false
+}
+
+// addOffsetPaging copies the limit and offset arguments of a list tool into
+// params, together with the recPerPage and pageID they correspond to. Legacy
+// browse views ignore offset, so without pageID every offset would return the
+// first page.
+func addOffsetPaging(params map[string]string, args map[string]interface{}) {
+	limit, hasLimit := args["limit"].(float64)
+	if hasLimit && limit > 0 {
+		params["limit"] = fmt.Sprintf("%.0f", limit)
+		params["recPerPage"] = fmt.Sprintf("%.0f", limit)
+	}
+	if offset, ok := args["offset"].(float64); ok && offset >= 0 {
+		params["offset"] = fmt.Sprintf("%.0f", offset)
+		if hasLimit && limit > 0 {
+			params["pageID"] = strconv.Itoa(int(offset)/int(limit) + 1)
+		}
+	}
+}
+
+// AddPaginationArguments adds all and max_items to every paged list tool.
+// With either set, the tool walks the pages itself and returns the records
+// of all pages in one result. Call it after all tools are registered.
+func AddPaginationArguments(s *server.MCPServer) {
+	registered := s.ListTools()
+	updated := make([]server.ServerTool, 0)
+
+	for _, tool := range registered {
+		style, ok := pagingStyleFor(tool.Tool)
+		if !ok {
+			continue
+		}
+		if _, exists := tool.Tool.InputSchema.Properties["all"]; exists {
+			continue
+		}
+
+		properties := make(map[string]any, len(tool.Tool.InputSchema.Properties)+2)
+		for key, value := range tool.Tool.InputSchema.Properties {
+			properties[key] = value
+		}
+		properties["all"] = map[string]any{
+			"type":        "boolean",
+			"description": fmt.Sprintf("Fetch every page and return all records (at most %d)", MaxCollectedItems),
+		}
+		properties["max_items"] = map[string]any{
+			"type":        "number",
+			"description": fmt.Sprintf("Fetch pages until this many records are collected (at most %d)", MaxCollectedItems),
+		}
+		tool.Tool.InputSchema.Properties = properties
+		tool.Handler = collectPages(tool.Tool.Name, style, tool.Handler)
+		updated = append(updated, *tool)
+	}
+
+	s.AddTools(updated...)
+
+	logger.Debug("tools", "Added pagination arguments to list tools", map[string]interface{}{
+		"tool_count": len(updated),
+	})
+}
+
+// collectPages wraps a list tool handler; without all or max_items the
+// original handler runs unchanged
+func collectPages(name string, style pagingStyle, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		all, _ := args["all"].(bool)
+		maxItems, _ := args["max_items"].(float64)
+		if !all && maxItems <= 0 {
+			return handler(ctx, request)
+		}
+
+		limit := MaxCollectedItems
+		if maxItems > 0 && int(maxItems) < limit {
+			limit = int(maxItems)
+		}
+		perPage := client.DefaultPageSize
+		if n, ok := args[style.perPage].(float64); ok && n > 0 {
+			perPage = int(n)
+		}
+
+		logger.LogMCPToolCall(name, map[string]interface{}{
+			"all":       all,
+			"max_items": limit,
+			"per_page":  perPage,
+		})
+
+		fetch := func(ctx context.Context, pageID, perPage int) (client.Page, error) {
+			pageArgs := make(map[string]any, len(args))
+			for key, value := range args {
+				if key != "all" && key != "max_items" {
+					pageArgs[key] = value
+				}
+			}
+			pageArgs[style.perPage] = float64(perPage)
+			if style.offset {
+				pageArgs[style.page] = float64((pageID - 1) * perPage)
+			} else {
+				pageArgs[style.page] = float64(pageID)
+			}
+
+			pageRequest := request
+			pageRequest.Params.Arguments = pageArgs
+			result, err := handler(ctx, pageRequest)
+			if err != nil {
+				return client.Page{}, err
+			}
+			text := toolResultText(result)
+			if result.IsError {
+				return client.Page{}, fmt.Errorf("page %d: %s", pageID, text)
+			}
+			page, err := client.ParsePage([]byte(text))
+			if err != nil {
+				return client.Page{}, fmt.Errorf("page %d: %w", pageID, err)
+			}
+			return page, nil
+		}
+
+		key := "items"
+		items := make([]json.RawMessage, 0)
+		recTotal, pages, truncated := 0, 0, false
+		for page, err := range client.Pages(ctx, perPage, fetch) {
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to collect pages: %v", err)), nil
+			}
+			pages++
+			if page.Key != "" {
+				key = page.Key
+			}
+			if page.HasPager {
+				recTotal = page.Pager.RecTotal
+			}
+			items = append(items, page.Items...)
+			if len(items) >= limit {
+				truncated = len(items) > limit || recTotal > limit
+				items = items[:limit]
+				break
+			}
+		}
+		// Paging can also stop short of recTotal, e.g. when the server keeps
+		// answering with the first page; never report that as complete
+		if recTotal > len(items) {
+			truncated = true
+		}
+
+		result := map[string]interface{}{
+			key: items,
+			"pager": map[string]interface{}{
+				"recTotal":  recTotal,
+				"collected": len(items),
+				"pages":     pages,
+				"truncated": truncated,
+			},
+		}
+		jsonData, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal records: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(jsonData)), nil
+	}
+}
+
+// toolResultText joins the text content of a tool result
+func toolResultText(result *mcp.CallToolResult) string {
+	var text strings.Builder
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			text.WriteString(textContent.Text)
+		}
+	}
+	return text.String()
+}
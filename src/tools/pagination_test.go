@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type collectedBugs struct {
+	Bugs  []map[string]interface{} `json:"bugs"`
+	Pager struct {
+		RecTotal  int  `json:"recTotal"`
+		Collected int  `json:"collected"`
+		Pages     int  `json:"pages"`
+		Truncated bool `json:"truncated"`
+	} `json:"pager"`
+}
+
+func TestPaginationArguments(t *testing.T) {
+	fake, s := newFakeServer(t, RegisterBugTools, RegisterProductTools)
+	AddPaginationArguments(s)
+	for i := 1; i <= 45; i++ {
+		fake.Seed("bug", map[string]interface{}{"title": fmt.Sprintf("Bug %d", i), "product": 1})
+	}
+
+	for _, name := range []string{"browse_bugs", "get_products"} {
+		if _, ok := s.GetTool(name).Tool.InputSchema.Properties["all"]; !ok {
+			t.Errorf("Expected %s to accept all", name)
+		}
+	}
+	if _, ok := s.GetTool("get_bug").Tool.InputSchema.Properties["all"]; ok {
+		t.Error("Expected get_bug to be left alone")
+	}
+
+	t.Run("all", func(t *testing.T) {
+		fake.ResetRequests()
+		result := callTool(t, s, "browse_bugs", map[string]interface{}{
+			"productID": float64(1), "recPerPage": float64(20), "all": true,
+		})
+		if result.IsError {
+			t.Fatalf("browse_bugs failed: %s", resultText(t, result))
+		}
+		var got collectedBugs
+		if err := json.Unmarshal([]byte(resultText(t, result)), &got); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		if len(got.Bugs) != 45 || got.Pager.RecTotal != 45 || got.Pager.Pages != 3 || got.Pager.Truncated {
+			t.Errorf("Expected all 45 bugs over 3 pages, got %d bugs, pager %+v", len(got.Bugs), got.Pager)
+		}
+		if n := len(fake.Requests()); n != 3 {
+			t.Errorf("Expected 3 page requests, got %d", n)
+		}
+	})
+
+	t.Run("max_items", func(t *testing.T) {
+		result := callTool(t, s, "browse_bugs", map[string]interface{}{
+			"productID": float64(1), "recPerPage": float64(20), "max_items": float64(25),
+		})
+		var got collectedBugs
+		if err := json.Unmarshal([]byte(resultText(t, result)), &got); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		if len(got.Bugs) != 25 || got.Pager.Pages != 2 || !got.Pager.Truncated {
+			t.Errorf("Expected 25 bugs from 2 pages marked truncated, got %d bugs, pager %+v", len(got.Bugs), got.Pager)
+		}
+	})
+
+	t.Run("offset tool", func(t *testing.T) {
+		fake.ResetRequests()
+		result := callTool(t, s, "get_bugs", map[string]interface{}{
+			"product": float64(1), "limit": float64(20), "all": true,
+		})
+		if result.IsError {
+			t.Fatalf("get_bugs failed: %s", resultText(t, result))
+		}
+		var got collectedBugs
+		if err := json.Unmarshal([]byte(resultText(t, result)), &got); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		if len(got.Bugs) != 45 || got.Pager.Pages != 3 || got.Pager.Truncated {
+			t.Errorf("Expected all 45 bugs over 3 pages, got %d bugs, pager %+v", len(got.Bugs), got.Pager)
+		}
+		for i, req := range fake.Requests() {
+			if req.Query.Get("pageID") != fmt.Sprint(i+1) || req.Query.Get("recPerPage") != "20" {
+				t.Errorf("Expected request %d to ask for page %d of 20, got %v", i, i+1, req.Query)
+			}
+		}
+	})
+
+	t.Run("single page unchanged", func(t *testing.T) {
+		result := callTool(t, s, "browse_bugs", map[string]interface{}{"productID": float64(1), "recPerPage": float64(20)})
+		var page struct {
+			Bugs []map[string]interface{} `json:"bugs"`
+		}
+		if err := json.Unmarshal([]byte(resultText(t, result)), &page); err != nil || len(page.Bugs) != 20 {
+			t.Errorf("Expected the plain first page, got %s", resultText(t, result))
+		}
+	})
+
+	t.Run("paging ignored", func(t *testing.T) {
+		// A view that answers every page with the first one must not look complete
+		handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"bugs":[{"id":1},{"id":2}],"pager":{"recTotal":45,"recPerPage":2,"pageID":1}}`), nil
+		}
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]interface{}{"limit": float64(2), "all": true}
+		result, _ := collectPages("get_bugs", pagingStyle{perPage: "limit", page: "offset", offset: true}, handler)(context.Background(), request)
+		var got collectedBugs
+		if err := json.Unmarshal([]byte(resultText(t, result)), &got); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		if len(got.Bugs) != 2 || !got.Pager.Truncated {
+			t.Errorf("Expected a truncated result, got %d bugs, pager %+v", len(got.Bugs), got.Pager)
+		}
+	})
+
+	t.Run("page error", func(t *testing.T) {
+		fake.FailNext("bug", "browse", 1, 500)
+		result := callTool(t, s, "browse_bugs", map[string]interface{}{"productID": float64(1), "all": true})
+		if !result.IsError {
+			t.Errorf("Expected a failed page to fail the tool, got %s", resultText(t, result))
+		}
+	})
+}
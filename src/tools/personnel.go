@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterPersonnelTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterPersonnelTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getAccessiblePersonnelTool := mcp.NewTool("get_accessible_personnel",
 		mcp.WithDescription("Get accessible personnel list"),
 		mcp.WithNumber("programID",
@@ -74,8 +75,8 @@ func RegisterPersonnelTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=accessible&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=personnel&f=accessible&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get accessible personnel: %v", err)), nil
 		}
 
@@ -97,8 +98,8 @@ func RegisterPersonnelTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = fmt.Sprintf("programID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=invest&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=personnel&f=invest&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get personnel invest: %v", err)), nil
 		}
 
@@ -169,8 +170,8 @@ func RegisterPersonnelTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=whitelist&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=personnel&f=whitelist&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get personnel whitelist: %v", err)), nil
 		}
 
@@ -237,7 +238,7 @@ func RegisterPersonnelTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=personnel&f=addWhitelist&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=personnel&f=addWhitelist&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to add personnel whitelist: %v", err)), nil
 		}
@@ -257,7 +258,7 @@ func RegisterPersonnelTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=personnel&f=unbindWhitelist&t=json&id=%d", id))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=personnel&f=unbindWhitelist&t=json&id=%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unbind personnel whitelist: %v", err)), nil
 		}
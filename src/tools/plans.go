@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterPlanTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createPlanTool := mcp.NewTool("create_plan",
 		mcp.WithDescription("Create a new product plan in ZenTao"),
 		mcp.WithNumber("product",
@@ -73,7 +74,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["parent"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/products/%d/plans", productID), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/products/%d/plans", productID), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create plan: %v", err)), nil
 		}
@@ -126,7 +127,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/productplans/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/productplans/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update plan: %v", err)), nil
 		}
@@ -146,7 +147,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/productsplan/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/productsplan/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete plan: %v", err)), nil
 		}
@@ -175,7 +176,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"stories": args["stories"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/productplans/%d/linkstories", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/productplans/%d/linkstories", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link stories: %v", err)), nil
 		}
@@ -204,7 +205,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"stories": args["stories"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/productplans/%d/unlinkstories", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/productplans/%d/unlinkstories", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink stories: %v", err)), nil
 		}
@@ -233,7 +234,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs": args["bugs"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/productplans/%d/linkbugs", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/productplans/%d/linkbugs", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link bugs: %v", err)), nil
 		}
@@ -262,7 +263,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"bugs": args["bugs"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/productplans/%d/unlinkbugs", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/productplans/%d/unlinkbugs", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink bugs: %v", err)), nil
 		}
@@ -312,12 +313,7 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if end, ok := args["end"].(string); ok && end != "" {
 			params["end"] = end
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/productplans"
 		if len(params) > 0 {
@@ -331,8 +327,8 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get plans: %v", err)), nil
 		}
 
@@ -352,8 +348,8 @@ func RegisterPlanTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/productplan/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/productplan/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get plan: %v", err)), nil
 		}
 
@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProductTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createProductTool := mcp.NewTool("create_product",
 		mcp.WithDescription("Create a new product in ZenTao"),
 		mcp.WithString("name",
@@ -93,7 +94,7 @@ func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["acl"] = v
 		}
 
-		resp, err := client.Post("/products", body)
+		resp, err := client.PostCtx(ctx, "/products", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create product: %v", err)), nil
 		}
@@ -160,7 +161,7 @@ func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/product/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/product/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update product: %v", err)), nil
 		}
@@ -180,7 +181,7 @@ func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/product/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/product/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete product: %v", err)), nil
 		}
@@ -224,12 +225,7 @@ func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if line, ok := args["line"].(float64); ok && line > 0 {
 			params["line"] = fmt.Sprintf("%.0f", line)
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/products"
 		if len(params) > 0 {
@@ -243,8 +239,8 @@ func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get products: %v", err)), nil
 		}
 
@@ -264,8 +260,8 @@ func RegisterProductTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/product/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/product/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get product: %v", err)), nil
 		}
 
@@ -103,3 +103,40 @@ func BenchmarkProductToolList(b *testing.B) {
 		_ = s.ListTools()
 	}
 }
+
+func TestProductToolHandlers(t *testing.T) {
+	runHandlerCases(t, RegisterProductTools, []handlerCase{
+		{
+			tool:       "create_product",
+			args:       map[string]interface{}{"name": "Shop", "code": "shop", "program": float64(5), "acl": "private"},
+			wantMethod: "POST",
+			wantPath:   "/products",
+			wantBody:   map[string]interface{}{"name": "Shop", "code": "shop", "program": 5, "acl": "private"},
+		},
+		{
+			tool:       "update_product",
+			args:       map[string]interface{}{"id": float64(3), "name": "Shop 2"},
+			wantMethod: "PUT",
+			wantPath:   "/product/3",
+			wantBody:   map[string]interface{}{"name": "Shop 2"},
+		},
+		{
+			tool:       "delete_product",
+			args:       map[string]interface{}{"id": float64(3)},
+			wantMethod: "DELETE",
+			wantPath:   "/product/3",
+		},
+		{
+			tool:       "get_products",
+			args:       map[string]interface{}{"status": "closed"},
+			wantMethod: "GET",
+			wantPath:   "/products?status=closed",
+		},
+		{
+			tool:       "get_product",
+			args:       map[string]interface{}{"id": float64(42)},
+			wantMethod: "GET",
+			wantPath:   "/product/42",
+		},
+	})
+}
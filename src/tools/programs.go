@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProgramTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseProgramsTool := mcp.NewTool("browse_programs",
 		mcp.WithDescription("Browse programs in ZenTao"),
 		mcp.WithString("status",
@@ -68,8 +69,8 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=browse&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=browse&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse programs: %v", err)), nil
 		}
 
@@ -91,8 +92,8 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = fmt.Sprintf("browseType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=kanban&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=kanban&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get program kanban: %v", err)), nil
 		}
 
@@ -142,8 +143,8 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=product&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=product&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get program products: %v", err)), nil
 		}
 
@@ -215,7 +216,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&extra=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=create&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=create&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create program: %v", err)), nil
 		}
@@ -281,7 +282,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&parentProgramID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=edit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=edit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit program: %v", err)), nil
 		}
@@ -309,7 +310,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["comment"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=close&t=json&programID=%d", int(args["programID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=close&t=json&programID=%d", int(args["programID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close program: %v", err)), nil
 		}
@@ -337,7 +338,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["comment"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=start&t=json&programID=%d", int(args["programID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=start&t=json&programID=%d", int(args["programID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start program: %v", err)), nil
 		}
@@ -365,7 +366,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["comment"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=activate&t=json&programID=%d", int(args["programID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=activate&t=json&programID=%d", int(args["programID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate program: %v", err)), nil
 		}
@@ -393,7 +394,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["comment"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=suspend&t=json&programID=%d", int(args["programID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=suspend&t=json&programID=%d", int(args["programID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to suspend program: %v", err)), nil
 		}
@@ -422,7 +423,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = fmt.Sprintf("&confirm=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=delete&t=json&programID=%d%s", programID, queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=program&f=delete&t=json&programID=%d%s", programID, queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete program: %v", err)), nil
 		}
@@ -473,8 +474,8 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=project&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=project&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get program projects: %v", err)), nil
 		}
 
@@ -518,8 +519,8 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=stakeholder&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=stakeholder&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get program stakeholders: %v", err)), nil
 		}
 
@@ -538,8 +539,8 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		programID := int(args["programID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=program&f=view&t=json&programID=%d", programID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=program&f=view&t=json&programID=%d", programID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view program: %v", err)), nil
 		}
 
@@ -567,7 +568,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&orderBy=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=program&f=export&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=program&f=export&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export programs: %v", err)), nil
 		}
@@ -580,7 +581,7 @@ func RegisterProgramTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(refreshProgramStatsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=program&f=refreshStats&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=program&f=refreshStats&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to refresh program stats: %v", err)), nil
 		}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProjectBuildTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseProjectBuildsTool := mcp.NewTool("browse_project_builds",
 		mcp.WithDescription("Browse builds for a project"),
 		mcp.WithNumber("projectID",
@@ -69,8 +70,8 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=browse&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=browse&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse project builds: %v", err)), nil
 		}
 
@@ -127,7 +128,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["date"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=projectbuild&f=create&t=json&projectID=%d", int(args["projectID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=create&t=json&projectID=%d", int(args["projectID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create project build: %v", err)), nil
 		}
@@ -185,7 +186,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["date"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=projectbuild&f=edit&t=json&buildID=%d", int(args["buildID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=edit&t=json&buildID=%d", int(args["buildID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit project build: %v", err)), nil
 		}
@@ -248,8 +249,8 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=view&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=view&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view project build: %v", err)), nil
 		}
 
@@ -268,7 +269,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		buildID := int(args["buildID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=delete&t=json&buildID=%d", buildID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=delete&t=json&buildID=%d", buildID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete project build: %v", err)), nil
 		}
@@ -325,7 +326,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=linkStory&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=linkStory&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link story to project build: %v", err)), nil
 		}
@@ -348,7 +349,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 	s.AddTool(unlinkStoryFromProjectBuildTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=unlinkStory&t=json&buildID=%d&storyID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=unlinkStory&t=json&buildID=%d&storyID=%d",
 			int(args["buildID"].(float64)), int(args["storyID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink story from project build: %v", err)), nil
@@ -369,7 +370,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		buildID := int(args["buildID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=batchUnlinkStory&t=json&buildID=%d", buildID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=batchUnlinkStory&t=json&buildID=%d", buildID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink stories from project build: %v", err)), nil
 		}
@@ -426,7 +427,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=linkBug&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=linkBug&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link bug to project build: %v", err)), nil
 		}
@@ -449,7 +450,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 	s.AddTool(unlinkBugFromProjectBuildTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=unlinkBug&t=json&buildID=%d&bugID=%d",
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=unlinkBug&t=json&buildID=%d&bugID=%d",
 			int(args["buildID"].(float64)), int(args["bugID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink bug from project build: %v", err)), nil
@@ -470,7 +471,7 @@ func RegisterProjectBuildTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		buildID := int(args["buildID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=projectbuild&f=batchUnlinkBug&t=json&buildID=%d", buildID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=projectbuild&f=batchUnlinkBug&t=json&buildID=%d", buildID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink bugs from project build: %v", err)), nil
 		}
@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterProjectTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createProjectTool := mcp.NewTool("create_project",
 		mcp.WithDescription("Create a new project in ZenTao"),
 		mcp.WithString("name",
@@ -79,7 +80,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["parent"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/projects", body)
+		resp, err := client.PostCtx(ctx, "/projects", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create project: %v", err)), nil
 		}
@@ -158,7 +159,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["acl"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/projects/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/projects/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update project: %v", err)), nil
 		}
@@ -185,12 +186,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		projectID := int(args["project_id"].(float64))
 
 		params := make(map[string]string)
-		if v, ok := args["limit"]; ok && v != nil {
-			params["limit"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
-		if v, ok := args["offset"]; ok && v != nil {
-			params["offset"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
+		addOffsetPaging(params, args)
 
 		queryString := ""
 		if len(params) > 0 {
@@ -201,8 +197,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryString = queryString[:len(queryString)-1] // Remove trailing &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/projects/%d/stories%s", projectID, queryString))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/projects/%d/stories%s", projectID, queryString), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project stories: %v", err)), nil
 		}
 
@@ -247,8 +243,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=index&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=index&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project index: %v", err)), nil
 		}
 
@@ -304,8 +300,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=browse&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=browse&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse projects: %v", err)), nil
 		}
 
@@ -317,8 +313,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getProjectKanbanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=project&f=kanban&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=project&f=kanban&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project kanban: %v", err)), nil
 		}
 
@@ -356,7 +352,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&branchID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=createGuide&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=project&f=createGuide&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create project guide: %v", err)), nil
 		}
@@ -385,7 +381,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&orderBy=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=project&f=export&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=project&f=export&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export projects: %v", err)), nil
 		}
@@ -405,8 +401,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		projectID := int(args["projectID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=team&t=json&projectID=%d", projectID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=team&t=json&projectID=%d", projectID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project team: %v", err)), nil
 		}
 
@@ -447,7 +443,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&copyProjectID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=project&f=manageMembers&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=project&f=manageMembers&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage project members: %v", err)), nil
 		}
@@ -479,7 +475,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&removeExecution=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=unlinkMember&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=project&f=unlinkMember&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink project member: %v", err)), nil
 		}
@@ -542,8 +538,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&queryID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=execution&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=execution&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project executions: %v", err)), nil
 		}
 
@@ -615,8 +611,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=bug&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=bug&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project bugs: %v", err)), nil
 		}
 
@@ -688,8 +684,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=testcase&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=testcase&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project test cases: %v", err)), nil
 		}
 
@@ -746,8 +742,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=project&f=build&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=project&f=build&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project builds: %v", err)), nil
 		}
 
@@ -766,7 +762,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/projects/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/projects/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete project: %v", err)), nil
 		}
@@ -859,7 +855,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/projects/%d/executions", int(args["project"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/projects/%d/executions", int(args["project"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create execution: %v", err)), nil
 		}
@@ -955,7 +951,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/executions/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/executions/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update execution: %v", err)), nil
 		}
@@ -982,12 +978,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		executionID := int(args["execution_id"].(float64))
 
 		params := make(map[string]string)
-		if v, ok := args["limit"]; ok && v != nil {
-			params["limit"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
-		if v, ok := args["offset"]; ok && v != nil {
-			params["offset"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
+		addOffsetPaging(params, args)
 
 		queryString := ""
 		if len(params) > 0 {
@@ -998,8 +989,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryString = queryString[:len(queryString)-1] // Remove trailing &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/executions/%d/stories%s", executionID, queryString))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/executions/%d/stories%s", executionID, queryString), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution stories: %v", err)), nil
 		}
 
@@ -1018,7 +1009,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/executions/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/executions/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete execution: %v", err)), nil
 		}
@@ -1069,12 +1060,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if pm, ok := args["PM"].(float64); ok && pm > 0 {
 			params["PM"] = fmt.Sprintf("%.0f", pm)
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/projects"
 		if len(params) > 0 {
@@ -1088,8 +1074,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get projects: %v", err)), nil
 		}
 
@@ -1109,8 +1095,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/project/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/project/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project: %v", err)), nil
 		}
 
@@ -1154,12 +1140,7 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if execType, ok := args["type"].(string); ok && execType != "" {
 			params["type"] = execType
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/executions"
 		if len(params) > 0 {
@@ -1173,8 +1154,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get executions: %v", err)), nil
 		}
 
@@ -1194,8 +1175,8 @@ func RegisterProjectTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/execution/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/execution/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution: %v", err)), nil
 		}
 
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterQaTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterQaTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getQaIndexTool := mcp.NewTool("get_qa_index",
 		mcp.WithDescription("Get QA module index"),
 		mcp.WithString("locate",
@@ -46,8 +47,8 @@ func RegisterQaTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&projectID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=qa&f=index&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=qa&f=index&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get QA index: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
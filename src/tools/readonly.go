@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"strings"
+
+	"github.com/zentao/mcp-server/client"
+)
+
+// readOnlyVerbs name the tool families that only read ZenTao data
+var readOnlyVerbs = map[string]bool{
+	"get":    true,
+	"browse": true,
+	"view":   true,
+	"report": true,
+}
+
+// ReadOnlyTool reports whether the tool called name leaves ZenTao unchanged.
+// Tools of the server itself (zentao_login, zentao_health, the discovery
+// meta-tools, list_instances) always are. Otherwise the first verb in the name
+// decides, so get_bugs, tree_browse and tree_ajax_get_modules are read-only
+// while group_manage_view and search_delete_query are not.
+func ReadOnlyTool(name string) bool {
+	if strings.HasPrefix(name, "zentao_") || name == "list_instances" {
+		return true
+	}
+	for _, word := range strings.Split(name, "_") {
+		if readOnlyVerbs[word] {
+			return true
+		}
+		if client.MutatingWord(word) {
+			return false
+		}
+	}
+	return false
+}
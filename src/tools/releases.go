@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterReleaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterReleaseTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getProjectReleasesTool := mcp.NewTool("get_project_releases",
 		mcp.WithDescription("Get releases for a specific project"),
 		mcp.WithNumber("project_id",
@@ -38,12 +39,7 @@ func RegisterReleaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		projectID := int(args["project_id"].(float64))
 
 		params := make(map[string]string)
-		if v, ok := args["limit"]; ok && v != nil {
-			params["limit"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
-		if v, ok := args["offset"]; ok && v != nil {
-			params["offset"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
+		addOffsetPaging(params, args)
 
 		queryString := ""
 		if len(params) > 0 {
@@ -54,8 +50,8 @@ func RegisterReleaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryString = queryString[:len(queryString)-1] // Remove trailing &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/projects/%d/releases%s", projectID, queryString))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/projects/%d/releases%s", projectID, queryString), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project releases: %v", err)), nil
 		}
 
@@ -81,12 +77,7 @@ func RegisterReleaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		productID := int(args["product_id"].(float64))
 
 		params := make(map[string]string)
-		if v, ok := args["limit"]; ok && v != nil {
-			params["limit"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
-		if v, ok := args["offset"]; ok && v != nil {
-			params["offset"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
+		addOffsetPaging(params, args)
 
 		queryString := ""
 		if len(params) > 0 {
@@ -97,8 +88,8 @@ func RegisterReleaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryString = queryString[:len(queryString)-1] // Remove trailing &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/products/%d/releases%s", productID, queryString))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/products/%d/releases%s", productID, queryString), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get product releases: %v", err)), nil
 		}
 
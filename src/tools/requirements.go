@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterRequirementTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	// Requirement CRUD operations
 	createRequirementTool := mcp.NewTool("create_requirement",
 		mcp.WithDescription("Create a new requirement"),
@@ -108,7 +109,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["estimate"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=create&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=create&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create requirement: %v", err)), nil
 		}
@@ -186,7 +187,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["specs"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchCreate&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchCreate&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create requirements: %v", err)), nil
 		}
@@ -219,8 +220,8 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&param=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=view&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=requirement&f=view&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view requirement: %v", err)), nil
 		}
 
@@ -273,7 +274,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["estimate"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=edit&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=edit&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit requirement: %v", err)), nil
 		}
@@ -339,7 +340,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["specs"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchEdit&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchEdit&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit requirements: %v", err)), nil
 		}
@@ -373,7 +374,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=delete&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=delete&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete requirement: %v", err)), nil
 		}
@@ -420,7 +421,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&queryID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=linkStory&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=linkStory&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link story to requirement: %v", err)), nil
 		}
@@ -477,7 +478,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=linkRequirements&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=linkRequirements&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link requirements: %v", err)), nil
 		}
@@ -517,7 +518,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&projectID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=import&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=import&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import requirements: %v", err)), nil
 		}
@@ -556,7 +557,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=export&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=export&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export requirements: %v", err)), nil
 		}
@@ -589,7 +590,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&storyType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=requirement&f=exportTemplate&t=json&%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=exportTemplate&t=json&%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export requirement template: %v", err)), nil
 		}
@@ -617,7 +618,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			"assignedTo": int(args["assignedTo"].(float64)),
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=assignTo&t=json&storyID=%d", int(args["storyID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=assignTo&t=json&storyID=%d", int(args["storyID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign requirement: %v", err)), nil
 		}
@@ -653,7 +654,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["storyType"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=requirement&f=batchAssignTo&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=requirement&f=batchAssignTo&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch assign requirements: %v", err)), nil
 		}
@@ -682,7 +683,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=close&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=close&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close requirement: %v", err)), nil
 		}
@@ -722,7 +723,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchClose&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchClose&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch close requirements: %v", err)), nil
 		}
@@ -743,7 +744,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=activate&t=json&storyID=%d", int(args["storyID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=activate&t=json&storyID=%d", int(args["storyID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate requirement: %v", err)), nil
 		}
@@ -774,7 +775,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=review&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=review&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to review requirement: %v", err)), nil
 		}
@@ -804,7 +805,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["reason"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=requirement&f=batchReview&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=requirement&f=batchReview&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch review requirements: %v", err)), nil
 		}
@@ -864,7 +865,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 
 		body := map[string]interface{}{}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=report&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(retrySafe(ctx), fmt.Sprintf("/index.php?m=requirement&f=report&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate requirement report: %v", err)), nil
 		}
@@ -899,7 +900,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&storyIdList=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchChangeBranch&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchChangeBranch&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change requirement branch: %v", err)), nil
 		}
@@ -918,7 +919,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 	s.AddTool(batchChangeRequirementModuleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchChangeModule&t=json&moduleID=%d", int(args["moduleID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchChangeModule&t=json&moduleID=%d", int(args["moduleID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change requirement module: %v", err)), nil
 		}
@@ -945,7 +946,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&storyType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchChangeParent&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchChangeParent&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change requirement parent: %v", err)), nil
 		}
@@ -972,7 +973,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&storyType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchChangeGrade&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchChangeGrade&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change requirement grade: %v", err)), nil
 		}
@@ -999,7 +1000,7 @@ func RegisterRequirementTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&oldPlanID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=requirement&f=batchChangePlan&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=requirement&f=batchChangePlan&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change requirement plan: %v", err)), nil
 		}
@@ -0,0 +1,20 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import "github.com/zentao/mcp-server/client"
+
+// retrySafe marks a POST that only reads data (report_bugs, search_index) as
+// safe to retry. Handlers shadow the client package with their client
+// parameter, hence the alias.
+var retrySafe = client.WithRetrySafe
@@ -21,13 +21,13 @@ import (
 )
 
 // RegisterSearchTools registers all search/query tools
-func RegisterSearchTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterSearchTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	registerSearchFormTools(s, client)
 	registerSearchQueryTools(s, client)
 	registerSearchIndexTools(s, client)
 }
 
-func registerSearchFormTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerSearchFormTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	buildFormTool := mcp.NewTool("search_build_form",
 		mcp.WithDescription("Build search form"),
 		mcp.WithString("module",
@@ -47,7 +47,7 @@ func registerSearchFormTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		params["module"] = args["module"].(string)
 		params["mode"] = args["mode"].(string)
 
-		resp, err := client.Get("/index.php?m=search&f=buildForm&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=search&f=buildForm&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to build form: %v", err)), nil
 		}
@@ -68,7 +68,7 @@ func registerSearchFormTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		params["module"] = args["module"].(string)
 
-		resp, err := client.Get("/index.php?m=search&f=buildOldForm&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=search&f=buildOldForm&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to build old form: %v", err)), nil
 		}
@@ -76,7 +76,7 @@ func registerSearchFormTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerSearchQueryTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	buildQueryTool := mcp.NewTool("search_build_query",
 		mcp.WithDescription("Build search query"),
 		mcp.WithString("mode",
@@ -91,7 +91,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 
 		params["mode"] = args["mode"].(string)
 
-		resp, err := client.Post("/index.php?m=search&f=buildQuery&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=search&f=buildQuery&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to build query: %v", err)), nil
 		}
@@ -103,7 +103,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 	)
 
 	s.AddTool(buildOldQueryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=search&f=buildOldQuery&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=search&f=buildOldQuery&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to build old query: %v", err)), nil
 		}
@@ -131,7 +131,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 			params["onMenuBar"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=search&f=saveQuery&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=search&f=saveQuery&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save query: %v", err)), nil
 		}
@@ -159,7 +159,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 			params["onMenuBar"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=search&f=saveOldQuery&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=search&f=saveOldQuery&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to save old query: %v", err)), nil
 		}
@@ -178,7 +178,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		queryID := int(args["queryID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=search&f=deleteQuery&t=json&queryID=%d", queryID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=search&f=deleteQuery&t=json&queryID=%d", queryID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete query: %v", err)), nil
 		}
@@ -204,7 +204,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 		url += fmt.Sprintf("&module=%s", args["module"].(string))
 		url += fmt.Sprintf("&queryID=%d", int(args["queryID"].(float64)))
 
-		resp, err := client.Get(url)
+		resp, err := client.GetCtx(ctx, url)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get query: %v", err)), nil
 		}
@@ -223,7 +223,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		queryID := int(args["queryID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=search&f=ajaxRemoveMenu&t=json&queryID=%d", queryID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=search&f=ajaxRemoveMenu&t=json&queryID=%d", queryID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to remove menu: %v", err)), nil
 		}
@@ -231,7 +231,7 @@ func registerSearchQueryTools(s *server.MCPServer, client *client.ZenTaoClient)
 	})
 }
 
-func registerSearchIndexTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerSearchIndexTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	buildIndexTool := mcp.NewTool("search_build_index",
 		mcp.WithDescription("Build search index"),
 		mcp.WithString("mode",
@@ -256,7 +256,7 @@ func registerSearchIndexTools(s *server.MCPServer, client *client.ZenTaoClient)
 			params["lastID"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/index.php?m=search&f=buildIndex&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=search&f=buildIndex&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to build index: %v", err)), nil
 		}
@@ -280,7 +280,7 @@ func registerSearchIndexTools(s *server.MCPServer, client *client.ZenTaoClient)
 			params["pageID"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/index.php?m=search&f=index&t=json", params)
+		resp, err := client.PostCtx(retrySafe(ctx), "/index.php?m=search&f=index&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to index search: %v", err)), nil
 		}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterSpaceTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseSpacesTool := mcp.NewTool("browse_spaces",
 		mcp.WithDescription("Browse spaces with filtering and pagination"),
 		mcp.WithNumber("spaceID",
@@ -64,8 +65,8 @@ func RegisterSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=space&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=space&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse spaces: %v", err)), nil
 		}
 
@@ -105,7 +106,7 @@ func RegisterSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["code"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=space&f=createApplication&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=space&f=createApplication&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create application: %v", err)), nil
 		}
@@ -124,8 +125,8 @@ func RegisterSpaceTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getStoreAppInfoTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=space&f=getStoreAppInfo&t=json&appID=%d", int(args["appID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=space&f=getStoreAppInfo&t=json&appID=%d", int(args["appID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get store app info: %v", err)), nil
 		}
 
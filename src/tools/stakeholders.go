@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterStakeholderTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseStakeholdersTool := mcp.NewTool("browse_stakeholders",
 		mcp.WithDescription("Browse stakeholders for a project"),
 		mcp.WithNumber("projectID",
@@ -69,8 +70,8 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=browse&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=browse&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse stakeholders: %v", err)), nil
 		}
 
@@ -152,7 +153,7 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["weixin"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=stakeholder&f=create&t=json&objectID=%d", int(args["objectID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=create&t=json&objectID=%d", int(args["objectID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create stakeholder: %v", err)), nil
 		}
@@ -193,7 +194,7 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&parentID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=stakeholder&f=batchCreate&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=batchCreate&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create stakeholders: %v", err)), nil
 		}
@@ -270,7 +271,7 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["key"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=stakeholder&f=edit&t=json&stakeholderID=%d", int(args["stakeholderID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=edit&t=json&stakeholderID=%d", int(args["stakeholderID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit stakeholder: %v", err)), nil
 		}
@@ -299,8 +300,8 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&projectID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=ajaxGetMembers&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=ajaxGetMembers&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get stakeholder members: %v", err)), nil
 		}
 
@@ -328,8 +329,8 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			queryParams += fmt.Sprintf("&projectID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=ajaxGetCompanyUser&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=ajaxGetCompanyUser&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get company users: %v", err)), nil
 		}
 
@@ -348,8 +349,8 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		objectID := int(args["objectID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=ajaxGetOutsideUser&t=json&objectID=%d", objectID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=ajaxGetOutsideUser&t=json&objectID=%d", objectID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get outside users: %v", err)), nil
 		}
 
@@ -368,7 +369,7 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		userID := int(args["userID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=delete&t=json&userID=%d", userID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=delete&t=json&userID=%d", userID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete stakeholder: %v", err)), nil
 		}
@@ -388,8 +389,8 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		stakeholderID := int(args["stakeholderID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=view&t=json&stakeholderID=%d", stakeholderID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=view&t=json&stakeholderID=%d", stakeholderID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view stakeholder: %v", err)), nil
 		}
 
@@ -440,7 +441,7 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["feedback"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=stakeholder&f=communicate&t=json&stakeholderID=%d", int(args["stakeholderID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=communicate&t=json&stakeholderID=%d", int(args["stakeholderID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to communicate with stakeholder: %v", err)), nil
 		}
@@ -474,7 +475,7 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 			body["keyNote"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=stakeholder&f=expect&t=json&stakeholderID=%d", int(args["stakeholderID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=expect&t=json&stakeholderID=%d", int(args["stakeholderID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to record stakeholder expectations: %v", err)), nil
 		}
@@ -494,8 +495,8 @@ func RegisterStakeholderTools(s *server.MCPServer, client *client.ZenTaoClient)
 		args := request.GetArguments()
 		stakeholderID := int(args["stakeholderID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=stakeholder&f=userIssue&t=json&stakeholderID=%d", stakeholderID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=stakeholder&f=userIssue&t=json&stakeholderID=%d", stakeholderID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get stakeholder issues: %v", err)), nil
 		}
 
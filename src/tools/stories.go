@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterStoryTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createStoryTool := mcp.NewTool("create_story",
 		mcp.WithDescription("Create a new user story in ZenTao"),
 		mcp.WithString("title",
@@ -89,7 +90,7 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["keywords"] = v
 		}
 
-		resp, err := client.Post("/stories", body)
+		resp, err := client.PostCtx(ctx, "/stories", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create story: %v", err)), nil
 		}
@@ -155,7 +156,7 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["keywords"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/stories/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/stories/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update story: %v", err)), nil
 		}
@@ -196,7 +197,7 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["verify"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/stories/%d/change", id), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/stories/%d/change", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to change story: %v", err)), nil
 		}
@@ -216,7 +217,7 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/stories/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/stories/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete story: %v", err)), nil
 		}
@@ -279,12 +280,7 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if pri, ok := args["pri"].(float64); ok && pri > 0 && pri <= 9 {
 			params["pri"] = fmt.Sprintf("%.0f", pri)
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/stories"
 		if len(params) > 0 {
@@ -298,8 +294,8 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get stories: %v", err)), nil
 		}
 
@@ -319,8 +315,8 @@ func RegisterStoryTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/story/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/story/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get story: %v", err)), nil
 		}
 
@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTaskTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createTaskTool := mcp.NewTool("create_task",
 		mcp.WithDescription("Create a new task in ZenTao"),
 		mcp.WithNumber("execution",
@@ -96,7 +97,7 @@ func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["estimate"] = v.(float64)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/executions/%d/tasks", executionID), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/executions/%d/tasks", executionID), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create task: %v", err)), nil
 		}
@@ -180,7 +181,7 @@ func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["estimate"] = v.(float64)
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/tasks/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/tasks/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update task: %v", err)), nil
 		}
@@ -200,7 +201,7 @@ func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/tasks/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/tasks/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete task: %v", err)), nil
 		}
@@ -269,12 +270,7 @@ func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if pri, ok := args["pri"].(float64); ok && pri > 0 && pri <= 9 {
 			params["pri"] = fmt.Sprintf("%.0f", pri)
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/tasks"
 		if len(params) > 0 {
@@ -288,8 +284,8 @@ func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get tasks: %v", err)), nil
 		}
 
@@ -309,8 +305,8 @@ func RegisterTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/task/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/task/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get task: %v", err)), nil
 		}
 
@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestCaseTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createTestCaseTool := mcp.NewTool("create_testcase",
 		mcp.WithDescription("Create a new test case in ZenTao"),
 		mcp.WithNumber("product",
@@ -97,7 +98,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["keywords"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/products/%d/testcases", productID), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/products/%d/testcases", productID), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create test case: %v", err)), nil
 		}
@@ -182,7 +183,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["keywords"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/testcases/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/testcases/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update test case: %v", err)), nil
 		}
@@ -202,7 +203,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=delete&t=json&caseID=%d", id))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=delete&t=json&caseID=%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete test case: %v", err)), nil
 		}
@@ -289,8 +290,8 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&blockID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testcase&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse test cases: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -333,8 +334,8 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&stepsType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=view&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testcase&f=view&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view test case: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -379,7 +380,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=batchCreate&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=batchCreate&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create test cases: %v", err)), nil
 		}
@@ -425,7 +426,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=batchEdit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=batchEdit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit test cases: %v", err)), nil
 		}
@@ -447,7 +448,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=testcase&f=batchDelete&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=testcase&f=batchDelete&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch delete test cases: %v", err)), nil
 		}
@@ -473,7 +474,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["review_data"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=review&t=json&caseID=%d", int(args["caseID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=review&t=json&caseID=%d", int(args["caseID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to review test case: %v", err)), nil
 		}
@@ -500,7 +501,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post("/index.php?m=testcase&f=batchReview&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=testcase&f=batchReview&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch review test cases: %v", err)), nil
 		}
@@ -526,7 +527,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=batchChangeBranch&t=json&branchID=%d", int(args["branchID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=batchChangeBranch&t=json&branchID=%d", int(args["branchID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change branch: %v", err)), nil
 		}
@@ -552,7 +553,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=batchChangeModule&t=json&moduleID=%d", int(args["moduleID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=batchChangeModule&t=json&moduleID=%d", int(args["moduleID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change module: %v", err)), nil
 		}
@@ -578,7 +579,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=batchChangeType&t=json&type=%s", args["type"]), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=batchChangeType&t=json&type=%s", args["type"]), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch change type: %v", err)), nil
 		}
@@ -628,7 +629,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=linkCases&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=linkCases&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link test cases: %v", err)), nil
 		}
@@ -684,7 +685,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=linkBugs&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=linkBugs&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link bugs to test case: %v", err)), nil
 		}
@@ -720,7 +721,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&runID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=createBug&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=createBug&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create bug from test case: %v", err)), nil
 		}
@@ -758,7 +759,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=export&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=export&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export test cases: %v", err)), nil
 		}
@@ -776,7 +777,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(exportTestCaseTemplateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=exportTemplate&t=json&productID=%d", int(args["productID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=exportTemplate&t=json&productID=%d", int(args["productID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export template: %v", err)), nil
 		}
@@ -810,7 +811,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"import_data": args["import_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=import&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=import&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import test cases: %v", err)), nil
 		}
@@ -890,7 +891,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=importFromLib&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=importFromLib&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import from library: %v", err)), nil
 		}
@@ -908,7 +909,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(importToLibTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=importToLib&t=json&caseID=%d", int(args["caseID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=importToLib&t=json&caseID=%d", int(args["caseID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import to library: %v", err)), nil
 		}
@@ -964,8 +965,8 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=browseScene&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testcase&f=browseScene&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse scenes: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -1002,7 +1003,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"scene_data": args["scene_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=createScene&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=createScene&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create scene: %v", err)), nil
 		}
@@ -1028,7 +1029,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"scene_data": args["scene_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testcase&f=editScene&t=json&sceneID=%d", int(args["sceneID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=editScene&t=json&sceneID=%d", int(args["sceneID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit scene: %v", err)), nil
 		}
@@ -1054,7 +1055,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&confirm=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=deleteScene&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=deleteScene&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete scene: %v", err)), nil
 		}
@@ -1102,7 +1103,7 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=groupCase&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testcase&f=groupCase&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to group test cases: %v", err)), nil
 		}
@@ -1158,8 +1159,8 @@ func RegisterTestCaseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testcase&f=zeroCase&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testcase&f=zeroCase&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get zero test cases: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
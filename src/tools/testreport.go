@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestReportTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseTestReportsTool := mcp.NewTool("browse_testreports",
 		mcp.WithDescription("Browse test reports with filtering and pagination"),
 		mcp.WithNumber("objectID",
@@ -67,8 +68,8 @@ func RegisterTestReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testreport&f=browse&t=json%s", queryParams), nil)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.PostJSON(retrySafe(ctx), fmt.Sprintf("/index.php?m=testreport&f=browse&t=json%s", queryParams), nil, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse test reports: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -118,7 +119,7 @@ func RegisterTestReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"report_data": args["report_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testreport&f=create&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testreport&f=create&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create test report: %v", err)), nil
 		}
@@ -158,7 +159,7 @@ func RegisterTestReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"report_data": args["report_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testreport&f=edit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testreport&f=edit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit test report: %v", err)), nil
 		}
@@ -202,8 +203,8 @@ func RegisterTestReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testreport&f=view&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testreport&f=view&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view test report: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -220,7 +221,7 @@ func RegisterTestReportTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteTestReportTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testreport&f=delete&t=json&reportID=%d", int(args["reportID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testreport&f=delete&t=json&reportID=%d", int(args["reportID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete test report: %v", err)), nil
 		}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,14 +19,14 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestSuiteTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getTestSuiteIndexTool := mcp.NewTool("get_testsuite_index",
 		mcp.WithDescription("Get test suite index"),
 	)
 
 	s.AddTool(getTestSuiteIndexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=testsuite&f=index&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=testsuite&f=index&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get test suite index: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -74,8 +75,8 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testsuite&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testsuite&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse test suites: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -100,7 +101,7 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"suite_data": args["suite_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testsuite&f=create&t=json&productID=%d", int(args["productID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testsuite&f=create&t=json&productID=%d", int(args["productID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create test suite: %v", err)), nil
 		}
@@ -144,8 +145,8 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testsuite&f=view&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testsuite&f=view&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view test suite: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -170,7 +171,7 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"suite_data": args["suite_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testsuite&f=edit&t=json&suiteID=%d", int(args["suiteID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testsuite&f=edit&t=json&suiteID=%d", int(args["suiteID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit test suite: %v", err)), nil
 		}
@@ -188,7 +189,7 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteTestSuiteTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testsuite&f=delete&t=json&suiteID=%d", int(args["suiteID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testsuite&f=delete&t=json&suiteID=%d", int(args["suiteID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete test suite: %v", err)), nil
 		}
@@ -246,7 +247,7 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testsuite&f=linkCase&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testsuite&f=linkCase&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link case to suite: %v", err)), nil
 		}
@@ -268,7 +269,7 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unlinkCaseFromSuiteTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testsuite&f=unlinkCase&t=json&suiteID=%d&caseID=%d", int(args["suiteID"].(float64)), int(args["caseID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testsuite&f=unlinkCase&t=json&suiteID=%d&caseID=%d", int(args["suiteID"].(float64)), int(args["caseID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink case from suite: %v", err)), nil
 		}
@@ -294,7 +295,7 @@ func RegisterTestSuiteTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testsuite&f=batchUnlinkCases&t=json&suiteID=%d", int(args["suiteID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testsuite&f=batchUnlinkCases&t=json&suiteID=%d", int(args["suiteID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink cases: %v", err)), nil
 		}
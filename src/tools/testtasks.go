@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTestTaskTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createTestTaskTool := mcp.NewTool("create_testtask",
 		mcp.WithDescription("Create a new test task in ZenTao"),
 		mcp.WithNumber("project",
@@ -65,7 +66,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post("/testtasks", body)
+		resp, err := client.PostCtx(ctx, "/testtasks", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create test task: %v", err)), nil
 		}
@@ -93,12 +94,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if v, ok := args["project"]; ok && v != nil {
 			params["project"] = fmt.Sprintf("%d", int(v.(float64)))
 		}
-		if v, ok := args["limit"]; ok && v != nil {
-			params["limit"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
-		if v, ok := args["offset"]; ok && v != nil {
-			params["offset"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
+		addOffsetPaging(params, args)
 
 		queryString := ""
 		if len(params) > 0 {
@@ -109,8 +105,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryString = queryString[:len(queryString)-1] // Remove trailing &
 		}
 
-		resp, err := client.Get("/testtasks" + queryString)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/testtasks" + queryString, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get test tasks: %v", err)), nil
 		}
 
@@ -129,8 +125,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/testtasks/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/testtasks/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get test task: %v", err)), nil
 		}
 
@@ -156,12 +152,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		projectID := int(args["project_id"].(float64))
 
 		params := make(map[string]string)
-		if v, ok := args["limit"]; ok && v != nil {
-			params["limit"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
-		if v, ok := args["offset"]; ok && v != nil {
-			params["offset"] = fmt.Sprintf("%d", int(v.(float64)))
-		}
+		addOffsetPaging(params, args)
 
 		queryString := ""
 		if len(params) > 0 {
@@ -172,8 +163,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryString = queryString[:len(queryString)-1] // Remove trailing &
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/projects/%d/testtasks%s", projectID, queryString))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/projects/%d/testtasks%s", projectID, queryString), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project test tasks: %v", err)), nil
 		}
 
@@ -192,7 +183,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=delete&t=json&taskID=%d", id))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=delete&t=json&taskID=%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete test task: %v", err)), nil
 		}
@@ -263,8 +254,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&endTime=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testtask&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse test tasks: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -289,7 +280,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"task_data": args["task_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=edit&t=json&taskID=%d", int(args["taskID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=edit&t=json&taskID=%d", int(args["taskID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit test task: %v", err)), nil
 		}
@@ -307,7 +298,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(startTestTaskTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=start&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=start&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start test task: %v", err)), nil
 		}
@@ -325,7 +316,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(closeTestTaskTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=close&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=close&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close test task: %v", err)), nil
 		}
@@ -343,7 +334,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(blockTestTaskTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=block&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=block&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to block test task: %v", err)), nil
 		}
@@ -361,7 +352,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(activateTestTaskTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=activate&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=activate&t=json&taskID=%d", int(args["taskID"].(float64))), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate test task: %v", err)), nil
 		}
@@ -418,8 +409,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=cases&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testtask&f=cases&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get test task cases: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -462,8 +453,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=unitCases&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testtask&f=unitCases&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get unit cases: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -520,7 +511,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"cases_data": args["cases_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=linkCase&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=linkCase&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to link case to test task: %v", err)), nil
 		}
@@ -538,7 +529,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(unlinkCaseFromTestTaskTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=unlinkCase&t=json&runID=%d", int(args["runID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=unlinkCase&t=json&runID=%d", int(args["runID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to unlink case: %v", err)), nil
 		}
@@ -564,7 +555,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"runs_data": args["runs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=batchUnlinkCases&t=json&taskID=%d", int(args["taskID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=batchUnlinkCases&t=json&taskID=%d", int(args["taskID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch unlink cases: %v", err)), nil
 		}
@@ -608,7 +599,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"result_data": args["result_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=runCase&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=runCase&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to run test case: %v", err)), nil
 		}
@@ -658,7 +649,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"runs_data": args["runs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=batchRun&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=batchRun&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch run test cases: %v", err)), nil
 		}
@@ -710,8 +701,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&deployID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=results&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testtask&f=results&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get test results: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -736,7 +727,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["assignedTo"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=assignCase&t=json&runID=%d", int(args["runID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=assignCase&t=json&runID=%d", int(args["runID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign test case: %v", err)), nil
 		}
@@ -766,7 +757,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"runs_data": args["runs_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=batchAssign&t=json&taskID=%d&account=%s", int(args["taskID"].(float64)), args["account"]), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=batchAssign&t=json&taskID=%d&account=%s", int(args["taskID"].(float64)), args["account"]), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch assign test cases: %v", err)), nil
 		}
@@ -814,7 +805,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&chartType=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=report&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(retrySafe(ctx), fmt.Sprintf("/index.php?m=testtask&f=report&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate test task report: %v", err)), nil
 		}
@@ -840,7 +831,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&browseType=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=groupCase&t=json%s", queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=groupCase&t=json%s", queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to group test task cases: %v", err)), nil
 		}
@@ -890,8 +881,8 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=testtask&f=browseUnits&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=testtask&f=browseUnits&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse unit test tasks: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -916,7 +907,7 @@ func RegisterTestTaskTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"result_data": args["result_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=testtask&f=importUnitResult&t=json&productID=%d", int(args["productID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=testtask&f=importUnitResult&t=json&productID=%d", int(args["productID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import unit test result: %v", err)), nil
 		}
@@ -21,7 +21,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTicketTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTicketTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createTicketTool := mcp.NewTool("create_ticket",
 		mcp.WithDescription("Create a new ticket in ZenTao"),
 		mcp.WithNumber("product",
@@ -60,7 +60,7 @@ func RegisterTicketTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Post("/tickets", body)
+		resp, err := client.PostCtx(ctx, "/tickets", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create ticket: %v", err)), nil
 		}
@@ -113,7 +113,7 @@ func RegisterTicketTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["desc"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/tickets/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/tickets/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update ticket: %v", err)), nil
 		}
@@ -133,7 +133,7 @@ func RegisterTicketTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/tickets/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/tickets/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete ticket: %v", err)), nil
 		}
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTodoTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createTodoTool := mcp.NewTool("create_todo",
 		mcp.WithDescription("Create a new todo item"),
 		mcp.WithString("date",
@@ -91,7 +92,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = queryParams[1:] // Remove leading &
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=create&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=create&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create todo: %v", err)), nil
 		}
@@ -127,7 +128,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 
 		queryParams := fmt.Sprintf("&date=%s", args["date"])
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=batchCreate&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=batchCreate&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch create todos: %v", err)), nil
 		}
@@ -192,7 +193,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["assignedTo"] = int(v.(float64))
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=edit&t=json&todoID=%d", int(args["todoID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=edit&t=json&todoID=%d", int(args["todoID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit todo: %v", err)), nil
 		}
@@ -254,7 +255,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&status=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=batchEdit&t=json%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=batchEdit&t=json%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch edit todos: %v", err)), nil
 		}
@@ -274,7 +275,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		todoID := int(args["todoID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=start&t=json&todoID=%d", todoID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=start&t=json&todoID=%d", todoID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start todo: %v", err)), nil
 		}
@@ -294,7 +295,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		todoID := int(args["todoID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=activate&t=json&todoID=%d", todoID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=activate&t=json&todoID=%d", todoID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to activate todo: %v", err)), nil
 		}
@@ -314,7 +315,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		todoID := int(args["todoID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=close&t=json&todoID=%d", todoID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=close&t=json&todoID=%d", todoID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close todo: %v", err)), nil
 		}
@@ -348,7 +349,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["comment"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=assignTo&t=json&todoID=%d", int(args["todoID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=assignTo&t=json&todoID=%d", int(args["todoID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to assign todo: %v", err)), nil
 		}
@@ -377,8 +378,8 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = fmt.Sprintf("&from=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=view&t=json&todoID=%d%s", todoID, queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=todo&f=view&t=json&todoID=%d%s", todoID, queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view todo: %v", err)), nil
 		}
 
@@ -406,7 +407,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = fmt.Sprintf("&confirm=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=delete&t=json&todoID=%d%s", todoID, queryParams))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=delete&t=json&todoID=%d%s", todoID, queryParams))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete todo: %v", err)), nil
 		}
@@ -426,7 +427,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		todoID := int(args["todoID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=finish&t=json&todoID=%d", todoID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=finish&t=json&todoID=%d", todoID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to finish todo: %v", err)), nil
 		}
@@ -439,7 +440,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(batchFinishTodosTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=todo&f=batchFinish&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=todo&f=batchFinish&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch finish todos: %v", err)), nil
 		}
@@ -452,7 +453,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(batchCloseTodosTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=todo&f=batchClose&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=todo&f=batchClose&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to batch close todos: %v", err)), nil
 		}
@@ -471,7 +472,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(importTodoToTodayTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=import2Today&t=json&todoID=%s", args["todoID"]), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=import2Today&t=json&todoID=%s", args["todoID"]), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import todo to today: %v", err)), nil
 		}
@@ -500,7 +501,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&orderBy=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=todo&f=export&t=json%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=todo&f=export&t=json%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export todos: %v", err)), nil
 		}
@@ -520,8 +521,8 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		todoID := int(args["todoID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=ajaxGetDetail&t=json&todoID=%d", todoID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=todo&f=ajaxGetDetail&t=json&todoID=%d", todoID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get todo detail: %v", err)), nil
 		}
 
@@ -543,9 +544,9 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getProgramIDTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=ajaxGetProgramID&t=json&objectID=%d&objectType=%s",
-			int(args["objectID"].(float64)), args["objectType"]))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=todo&f=ajaxGetProgramID&t=json&objectID=%d&objectType=%s",
+			int(args["objectID"].(float64)), args["objectType"]), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get program ID: %v", err)), nil
 		}
 
@@ -564,8 +565,8 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		projectID := int(args["projectID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=ajaxGetExecutionPairs&t=json&projectID=%d", projectID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=todo&f=ajaxGetExecutionPairs&t=json&projectID=%d", projectID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution pairs: %v", err)), nil
 		}
 
@@ -584,8 +585,8 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		projectID := int(args["projectID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=todo&f=ajaxGetProductPairs&t=json&projectID=%d", projectID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=todo&f=ajaxGetProductPairs&t=json&projectID=%d", projectID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get product pairs: %v", err)), nil
 		}
 
@@ -597,7 +598,7 @@ func RegisterTodoTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(createCycleTodoTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=todo&f=createCycle&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=todo&f=createCycle&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create cycle todo: %v", err)), nil
 		}
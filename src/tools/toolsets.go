@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+)
+
+// Toolset is a group of tools, usually one ZenTao module, that is enabled or
+// disabled as a unit
+type Toolset struct {
+	Name        string
+	Description string
+	Register    func(s *server.MCPServer, client client.ZenTaoAPI)
+	Core        bool // registered whatever the selection, e.g. login and health
+}
+
+// Toolsets lists every tool group in registration order
+var Toolsets = []Toolset{
+	{Name: "auth", Description: "Log in and manage the ZenTao session or token", Register: RegisterAuthTools, Core: true},
+	{Name: "health", Description: "Check connectivity, auth and clock skew", Register: RegisterHealthTools, Core: true},
+	{Name: "cache", Description: "Inspect and clear the response cache", Register: RegisterCacheTools, Core: true},
+	{Name: "products", Description: "Products", Register: RegisterProductTools},
+	{Name: "projects", Description: "Projects and their members, stories and bugs", Register: RegisterProjectTools},
+	{Name: "stories", Description: "User stories", Register: RegisterStoryTools},
+	{Name: "tasks", Description: "Tasks", Register: RegisterTaskTools},
+	{Name: "bugs", Description: "Bugs", Register: RegisterBugTools},
+	{Name: "testcases", Description: "Test cases", Register: RegisterTestCaseTools},
+	{Name: "plans", Description: "Product plans", Register: RegisterPlanTools},
+	{Name: "builds", Description: "Builds", Register: RegisterBuildTools},
+	{Name: "users", Description: "Users", Register: RegisterUserTools},
+	{Name: "feedback", Description: "Feedback", Register: RegisterFeedbackTools},
+	{Name: "tickets", Description: "Tickets", Register: RegisterTicketTools},
+	{Name: "programs", Description: "Programs", Register: RegisterProgramTools},
+	{Name: "testtasks", Description: "Test tasks and test runs", Register: RegisterTestTaskTools},
+	{Name: "releases", Description: "Releases", Register: RegisterReleaseTools},
+	{Name: "apilibs", Description: "API libraries", Register: RegisterApiLibTools},
+	{Name: "entries", Description: "Entries", Register: RegisterEntryTools},
+	{Name: "my", Description: "Work assigned to or created by the current user", Register: RegisterMyTools},
+	{Name: "todos", Description: "Todos", Register: RegisterTodoTools},
+	{Name: "personnel", Description: "Accessible personnel, investment and whitelists", Register: RegisterPersonnelTools},
+	{Name: "stakeholders", Description: "Stakeholders", Register: RegisterStakeholderTools},
+	{Name: "branches", Description: "Product branches", Register: RegisterBranchTools},
+	{Name: "designs", Description: "Designs", Register: RegisterDesignTools},
+	{Name: "projectbuilds", Description: "Project builds", Register: RegisterProjectBuildTools},
+	{Name: "executions", Description: "Executions (sprints and stages)", Register: RegisterExecutionTools},
+	{Name: "kanban", Description: "Kanban spaces, boards and cards", Register: RegisterKanbanTools},
+	{Name: "epics", Description: "Epics", Register: RegisterEpicTools},
+	{Name: "requirements", Description: "Requirements", Register: RegisterRequirementTools},
+	{Name: "spaces", Description: "Spaces and store applications", Register: RegisterSpaceTools},
+	{Name: "transfer", Description: "Import and export", Register: RegisterTransferTools},
+	{Name: "zai", Description: "ZAI settings and vectorization", Register: RegisterZaiTools},
+	{Name: "ai", Description: "AI administration, mini programs and prompts", Register: RegisterAiTools},
+	{Name: "zanode", Description: "ZaNode hosts and execution nodes", Register: RegisterZanodeTools},
+	{Name: "caselibs", Description: "Case libraries", Register: RegisterCaseLibTools},
+	{Name: "qa", Description: "QA dashboard", Register: RegisterQaTools},
+	{Name: "testreports", Description: "Test reports", Register: RegisterTestReportTools},
+	{Name: "testsuites", Description: "Test suites", Register: RegisterTestSuiteTools},
+	{Name: "docs", Description: "Documents and document libraries", Register: RegisterDocTools},
+	{Name: "datatable", Description: "Datatables and reports", Register: RegisterDatatableTools},
+	{Name: "admin", Description: "Administration", Register: RegisterAdminTools},
+	{Name: "aiapp", Description: "AI apps", Register: RegisterAiappTools},
+	{Name: "bi", Description: "BI data sync and query helpers", Register: RegisterBiTools},
+	{Name: "tree", Description: "Module trees", Register: RegisterTreeTools},
+	{Name: "search", Description: "Search forms and saved queries", Register: RegisterSearchTools},
+}
+
+// ToolsetPresets are names that expand to several toolsets
+var ToolsetPresets = map[string][]string{
+	"minimal": {"my", "products", "projects", "stories", "tasks", "bugs"},
+}
+
+// FindToolset returns the toolset called name
+func FindToolset(name string) (Toolset, bool) {
+	for _, toolset := range Toolsets {
+		if toolset.Name == name {
+			return toolset, true
+		}
+	}
+	return Toolset{}, false
+}
+
+// ToolsetSelection is the set of enabled toolsets plus tool name globs to leave out
+type ToolsetSelection struct {
+	enabled  map[string]bool // nil enables every toolset
+	exclude  []string        // path.Match patterns such as "delete_*"
+	readOnly bool            // leave out every tool that can change ZenTao
+}
+
+// ParseToolsetSelection parses a comma-separated list of toolsets and presets
+// ("all", "minimal", "bugs,tasks,my") and a comma-separated list of tool name
+// globs to exclude. An empty list enables every toolset.
+func ParseToolsetSelection(toolsets, excludeTools string) (ToolsetSelection, error) {
+	var sel ToolsetSelection
+
+	for _, pattern := range splitList(excludeTools) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return sel, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+		sel.exclude = append(sel.exclude, pattern)
+	}
+
+	names := splitList(toolsets)
+	if len(names) == 0 {
+		return sel, nil
+	}
+	sel.enabled = map[string]bool{}
+	for _, name := range names {
+		if name == "all" {
+			sel.enabled = nil
+			continue
+		}
+		if preset, ok := ToolsetPresets[name]; ok {
+			for _, member := range preset {
+				sel.enable(member)
+			}
+			continue
+		}
+		if _, ok := FindToolset(name); !ok {
+			return sel, fmt.Errorf("unknown toolset %q (available: %s)", name, strings.Join(toolsetNames(), ", "))
+		}
+		sel.enable(name)
+	}
+	return sel, nil
+}
+
+func (sel *ToolsetSelection) enable(name string) {
+	if sel.enabled != nil {
+		sel.enabled[name] = true
+	}
+}
+
+// SetReadOnly leaves out every tool that is not ReadOnlyTool
+func (sel *ToolsetSelection) SetReadOnly(readOnly bool) {
+	sel.readOnly = readOnly
+}
+
+// ReadOnly reports whether mutating tools are left out
+func (sel ToolsetSelection) ReadOnly() bool {
+	return sel.readOnly
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func toolsetNames() []string {
+	names := make([]string, 0, len(Toolsets)+len(ToolsetPresets)+1)
+	for _, toolset := range Toolsets {
+		names = append(names, toolset.Name)
+	}
+	for preset := range ToolsetPresets {
+		names = append(names, preset)
+	}
+	names = append(names, "all")
+	sort.Strings(names)
+	return names
+}
+
+// Enabled reports whether the toolset called name is selected. Core toolsets
+// are always enabled.
+func (sel ToolsetSelection) Enabled(name string) bool {
+	if sel.enabled == nil {
+		return true
+	}
+	if toolset, ok := FindToolset(name); ok && toolset.Core {
+		return true
+	}
+	return sel.enabled[name]
+}
+
+// Excluded reports whether tool matches one of the --exclude-tools globs or
+// can change ZenTao in read-only mode
+func (sel ToolsetSelection) Excluded(tool string) bool {
+	if sel.readOnly && !ReadOnlyTool(tool) {
+		return true
+	}
+	for _, pattern := range sel.exclude {
+		if matched, _ := path.Match(pattern, tool); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterToolsets registers the selected toolsets and then removes the
+// excluded tools. It returns the names of the registered toolsets.
+func RegisterToolsets(s *server.MCPServer, c client.ZenTaoAPI, sel ToolsetSelection) []string {
+	var registered []string
+	for _, toolset := range Toolsets {
+		if !sel.Enabled(toolset.Name) {
+			logger.Debug("server", "Skipping disabled toolset", map[string]interface{}{
+				"toolset": toolset.Name,
+			})
+			continue
+		}
+		logger.Debug("server", "Registering toolset", map[string]interface{}{
+			"toolset": toolset.Name,
+		})
+		toolset.Register(s, c)
+		registered = append(registered, toolset.Name)
+	}
+
+	sel.RemoveExcluded(s)
+	return registered
+}
+
+// RemoveExcluded deletes every registered tool that Excluded reports
+func (sel ToolsetSelection) RemoveExcluded(s *server.MCPServer) {
+	if len(sel.exclude) == 0 && !sel.readOnly {
+		return
+	}
+	var names []string
+	for name := range s.ListTools() {
+		if sel.Excluded(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+	s.DeleteTools(names...)
+
+	logger.Debug("server", "Excluded tools", map[string]interface{}{
+		"patterns":  sel.exclude,
+		"read_only": sel.readOnly,
+		"tools":     names,
+	})
+}
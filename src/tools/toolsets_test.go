@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+)
+
+func TestParseToolsetSelection(t *testing.T) {
+	sel, err := ParseToolsetSelection("minimal, testcases", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, name := range []string{"bugs", "tasks", "my", "testcases", "auth", "health"} {
+		if !sel.Enabled(name) {
+			t.Errorf("Expected %s to be enabled", name)
+		}
+	}
+	if sel.Enabled("kanban") {
+		t.Error("Expected kanban to be disabled")
+	}
+
+	all, _ := ParseToolsetSelection("", "")
+	if !all.Enabled("zanode") {
+		t.Error("Expected every toolset without a selection")
+	}
+	all, _ = ParseToolsetSelection("bugs,all", "")
+	if !all.Enabled("zanode") {
+		t.Error("Expected all to enable every toolset")
+	}
+
+	if _, err := ParseToolsetSelection("bugs,gantt", ""); err == nil {
+		t.Error("Expected an error for an unknown toolset")
+	}
+	if _, err := ParseToolsetSelection("", "delete_["); err == nil {
+		t.Error("Expected an error for a malformed glob")
+	}
+}
+
+func TestEveryToolsetRegistersTools(t *testing.T) {
+	for _, toolset := range Toolsets {
+		s := server.NewMCPServer("test-server", "1.0.0")
+		toolset.Register(s, &mockZenTaoClient{authMethod: client.AuthApp})
+		if len(s.ListTools()) == 0 {
+			t.Errorf("Toolset %s registered no tools", toolset.Name)
+		}
+	}
+}
+
+func TestRegisterToolsetsFiltersAndExcludes(t *testing.T) {
+	sel, err := ParseToolsetSelection("bugs", "delete_*,batch_*")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	s := server.NewMCPServer("test-server", "1.0.0")
+	registered := RegisterToolsets(s, &mockZenTaoClient{authMethod: client.AuthApp}, sel)
+	if len(registered) != 4 {
+		t.Errorf("Expected the core toolsets plus bugs, got %v", registered)
+	}
+
+	tools := s.ListTools()
+	if tools["get_bugs"] == nil || tools["zentao_login"] == nil {
+		t.Error("Expected bug and auth tools to be registered")
+	}
+	if tools["get_kanban_space"] != nil {
+		t.Error("Expected kanban tools to be left out")
+	}
+	for name := range tools {
+		if sel.Excluded(name) {
+			t.Errorf("Expected %s to be excluded", name)
+		}
+	}
+}
+
+func TestReadOnlyTool(t *testing.T) {
+	for name, want := range map[string]bool{
+		"get_bugs":              true,
+		"tree_browse":           true,
+		"company_view":          true,
+		"report_bugs":           true,
+		"tree_ajax_get_modules": true,
+		"get_import_options":    true,
+		"zentao_health":         true,
+		"list_instances":        true,
+		"create_bug":            false,
+		"delete_kanban_space":   false,
+		"group_manage_view":     false,
+		"search_delete_query":   false,
+		"export_bugs":           false,
+	} {
+		if got := ReadOnlyTool(name); got != want {
+			t.Errorf("ReadOnlyTool(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRegisterToolsetsReadOnly(t *testing.T) {
+	sel, err := ParseToolsetSelection("bugs,kanban", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sel.SetReadOnly(true)
+
+	s := server.NewMCPServer("test-server", "1.0.0")
+	RegisterToolsets(s, &mockZenTaoClient{authMethod: client.AuthApp}, sel)
+
+	tools := s.ListTools()
+	if tools["get_bugs"] == nil || tools["zentao_login"] == nil {
+		t.Error("Expected read tools and core tools to be registered")
+	}
+	for name := range tools {
+		if !ReadOnlyTool(name) {
+			t.Errorf("Expected mutating tool %s to be left out", name)
+		}
+	}
+	if tools["delete_bug"] != nil || tools["create_bug"] != nil {
+		t.Error("Expected delete_bug and create_bug to be left out")
+	}
+}
+
+// blockedCalls wraps a read-only client and records requests it refuses
+type blockedCalls struct {
+	*client.ZenTaoClient
+	mu    sync.Mutex
+	paths []string
+}
+
+func (b *blockedCalls) note(method, path string, err error) {
+	if errors.Is(err, client.ErrReadOnly) {
+		b.mu.Lock()
+		b.paths = append(b.paths, method+" "+path)
+		b.mu.Unlock()
+	}
+}
+
+func (b *blockedCalls) Get(path string) ([]byte, error) {
+	return b.GetCtx(context.Background(), path)
+}
+func (b *blockedCalls) Post(path string, body interface{}) ([]byte, error) {
+	return b.PostCtx(context.Background(), path, body)
+}
+func (b *blockedCalls) Put(path string, body interface{}) ([]byte, error) {
+	return b.PutCtx(context.Background(), path, body)
+}
+func (b *blockedCalls) Delete(path string) ([]byte, error) {
+	return b.DeleteCtx(context.Background(), path)
+}
+func (b *blockedCalls) GetCtx(ctx context.Context, path string) ([]byte, error) {
+	resp, err := b.ZenTaoClient.GetCtx(ctx, path)
+	b.note(http.MethodGet, path, err)
+	return resp, err
+}
+func (b *blockedCalls) PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	resp, err := b.ZenTaoClient.PostCtx(ctx, path, body)
+	b.note(http.MethodPost, path, err)
+	return resp, err
+}
+func (b *blockedCalls) PutCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	resp, err := b.ZenTaoClient.PutCtx(ctx, path, body)
+	b.note(http.MethodPut, path, err)
+	return resp, err
+}
+func (b *blockedCalls) DeleteCtx(ctx context.Context, path string) ([]byte, error) {
+	resp, err := b.ZenTaoClient.DeleteCtx(ctx, path)
+	b.note(http.MethodDelete, path, err)
+	return resp, err
+}
+func (b *blockedCalls) GetJSON(ctx context.Context, path string, out interface{}) error {
+	err := b.ZenTaoClient.GetJSON(ctx, path, out)
+	b.note(http.MethodGet, path, err)
+	return err
+}
+func (b *blockedCalls) PostJSON(ctx context.Context, path string, body interface{}, out interface{}) error {
+	err := b.ZenTaoClient.PostJSON(ctx, path, body, out)
+	b.note(http.MethodPost, path, err)
+	return err
+}
+
+// schemaArguments fills every argument of tool with a plausible value
+func schemaArguments(tool mcp.Tool) map[string]interface{} {
+	args := make(map[string]interface{}, len(tool.InputSchema.Properties))
+	for name, value := range tool.InputSchema.Properties {
+		property, _ := value.(map[string]any)
+		if enum, ok := property["enum"].([]string); ok && len(enum) > 0 {
+			args[name] = enum[0]
+			continue
+		}
+		switch property["type"] {
+		case "number", "integer":
+			args[name] = float64(1)
+		case "boolean":
+			args[name] = false
+		case "array":
+			args[name] = []interface{}{}
+		case "object":
+			args[name] = map[string]interface{}{}
+		default:
+			args[name] = "1"
+		}
+	}
+	return args
+}
+
+func TestReadOnlyToolsPassClientCheck(t *testing.T) {
+	zentao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"success","data":"{}"}`))
+	}))
+	defer zentao.Close()
+
+	ztClient := client.NewZenTaoClientWithApp(zentao.URL+"/index.php", "code", "key")
+	ztClient.SetRetryPolicy(client.RetryPolicy{MaxRetries: 0})
+	ztClient.SetReadOnly(true)
+	calls := &blockedCalls{ZenTaoClient: ztClient}
+
+	var sel ToolsetSelection
+	sel.SetReadOnly(true)
+	s := server.NewMCPServer("test-server", "1.0.0")
+	RegisterToolsets(s, calls, sel)
+
+	tools := s.ListTools()
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		calls.paths = nil
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = schemaArguments(tools[name].Tool)
+		func() {
+			// Handlers that reject the generated arguments are not the point here
+			defer func() { recover() }()
+			tools[name].Handler(context.Background(), request)
+		}()
+		if len(calls.paths) > 0 {
+			t.Errorf("Read-only tool %s was blocked by the client: %v", name, calls.paths)
+		}
+	}
+}
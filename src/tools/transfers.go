@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTransferTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	exportDataTool := mcp.NewTool("export_data",
 		mcp.WithDescription("Export data from a ZenTao module"),
 		mcp.WithString("module",
@@ -44,7 +45,7 @@ func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&fields=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=transfer&f=export&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=transfer&f=export&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export data: %v", err)), nil
 		}
@@ -78,7 +79,7 @@ func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&format=%s", v)
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=transfer&f=exportTemplate&t=json&%s", queryParams), nil)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=transfer&f=exportTemplate&t=json&%s", queryParams), nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to export template: %v", err)), nil
 		}
@@ -120,7 +121,7 @@ func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"data": args["data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=transfer&f=import&t=json&%s", queryParams), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=transfer&f=import&t=json&%s", queryParams), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to import data: %v", err)), nil
 		}
@@ -159,8 +160,8 @@ func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&limit=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=transfer&f=ajaxGetTbody&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=transfer&f=ajaxGetTbody&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get import table body: %v", err)), nil
 		}
 
@@ -202,8 +203,8 @@ func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&search=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=transfer&f=ajaxGetOptions&t=json&%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=transfer&f=ajaxGetOptions&t=json&%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get import options: %v", err)), nil
 		}
 
@@ -237,7 +238,7 @@ func RegisterTransferTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["rules"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=transfer&f=validate&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=transfer&f=validate&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to validate import data: %v", err)), nil
 		}
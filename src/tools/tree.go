@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -21,14 +22,14 @@ import (
 )
 
 // RegisterTreeTools registers all tree/module management tools
-func RegisterTreeTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterTreeTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	registerTreeBrowseTools(s, client)
 	registerTreeEditTools(s, client)
 	registerTreeManagementTools(s, client)
 	registerTreeOptionTools(s, client)
 }
 
-func registerTreeBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerTreeBrowseTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	browseTool := mcp.NewTool("tree_browse",
 		mcp.WithDescription("Browse tree structure"),
 		mcp.WithNumber("rootID", mcp.Description("Root ID")),
@@ -61,8 +62,8 @@ func registerTreeBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["from"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=tree&f=browse&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=tree&f=browse&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse tree: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -89,15 +90,15 @@ func registerTreeBrowseTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["currentModuleID"] = int(v.(float64))
 		}
 
-		resp, err := client.Get("/index.php?m=tree&f=browseTask&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=tree&f=browseTask&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse tree tasks: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
 	})
 }
 
-func registerTreeEditTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerTreeEditTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	editTool := mcp.NewTool("tree_edit",
 		mcp.WithDescription("Edit tree module"),
 		mcp.WithNumber("moduleID",
@@ -121,7 +122,7 @@ func registerTreeEditTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["branch"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=tree&f=edit&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=tree&f=edit&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit tree module: %v", err)), nil
 		}
@@ -145,7 +146,7 @@ func registerTreeEditTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["type"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=tree&f=fix&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=tree&f=fix&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to fix tree module: %v", err)), nil
 		}
@@ -153,7 +154,7 @@ func registerTreeEditTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	})
 }
 
-func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerTreeManagementTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	updateOrderTool := mcp.NewTool("tree_update_order",
 		mcp.WithDescription("Update tree module order"),
 		mcp.WithNumber("rootID", mcp.Description("Root ID")),
@@ -178,7 +179,7 @@ func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["moduleID"] = int(v.(float64))
 		}
 
-		resp, err := client.Post("/index.php?m=tree&f=updateOrder&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=tree&f=updateOrder&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update order: %v", err)), nil
 		}
@@ -214,7 +215,7 @@ func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["oldPage"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=tree&f=manageChild&t=json", params)
+		resp, err := client.PostCtx(ctx, "/index.php?m=tree&f=manageChild&t=json", params)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to manage child: %v", err)), nil
 		}
@@ -233,8 +234,8 @@ func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 		args := request.GetArguments()
 		productID := int(args["productID"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=tree&f=viewHistory&t=json&productID=%d", productID))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=tree&f=viewHistory&t=json&productID=%d", productID), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view history: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -261,7 +262,7 @@ func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 			params["confirm"] = v
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=tree&f=delete&t=json&moduleID=%d", moduleID))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=tree&f=delete&t=json&moduleID=%d", moduleID))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete tree module: %v", err)), nil
 		}
@@ -273,7 +274,7 @@ func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	)
 
 	s.AddTool(ajaxCreateModuleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Post("/index.php?m=tree&f=ajaxCreateModule&t=json", nil)
+		resp, err := client.PostCtx(ctx, "/index.php?m=tree&f=ajaxCreateModule&t=json", nil)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create module: %v", err)), nil
 		}
@@ -281,7 +282,7 @@ func registerTreeManagementTools(s *server.MCPServer, client *client.ZenTaoClien
 	})
 }
 
-func registerTreeOptionTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func registerTreeOptionTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	ajaxGetOptionMenuTool := mcp.NewTool("tree_ajax_get_option_menu",
 		mcp.WithDescription("Get tree option menu"),
 		mcp.WithNumber("rootID", mcp.Description("Root ID")),
@@ -330,7 +331,7 @@ func registerTreeOptionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["grade"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=tree&f=ajaxGetOptionMenu&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=tree&f=ajaxGetOptionMenu&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get option menu: %v", err)), nil
 		}
@@ -362,7 +363,7 @@ func registerTreeOptionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["extra"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=tree&f=ajaxGetDropMenu&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=tree&f=ajaxGetDropMenu&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get drop menu: %v", err)), nil
 		}
@@ -407,7 +408,7 @@ func registerTreeOptionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["from"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=tree&f=ajaxGetModules&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=tree&f=ajaxGetModules&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get modules: %v", err)), nil
 		}
@@ -435,7 +436,7 @@ func registerTreeOptionTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			params["type"] = v
 		}
 
-		resp, err := client.Get("/index.php?m=tree&f=ajaxGetSonModules&t=json")
+		resp, err := client.GetCtx(ctx, "/index.php?m=tree&f=ajaxGetSonModules&t=json")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get son modules: %v", err)), nil
 		}
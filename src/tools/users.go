@@ -14,6 +14,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +22,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterUserTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	createUserTool := mcp.NewTool("create_user",
 		mcp.WithDescription("Create a new user in ZenTao"),
 		mcp.WithString("account",
@@ -55,7 +56,7 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["visions"] = v
 		}
 
-		resp, err := client.Post("/users", body)
+		resp, err := client.PostCtx(ctx, "/users", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create user: %v", err)), nil
 		}
@@ -114,7 +115,7 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["phone"] = v
 		}
 
-		resp, err := client.Put(fmt.Sprintf("/users/%d", id), body)
+		resp, err := client.PutCtx(ctx, fmt.Sprintf("/users/%d", id), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update user: %v", err)), nil
 		}
@@ -134,7 +135,7 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Delete(fmt.Sprintf("/users/%d", id))
+		resp, err := client.DeleteCtx(ctx, fmt.Sprintf("/users/%d", id))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete user: %v", err)), nil
 		}
@@ -196,12 +197,7 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		if role, ok := args["role"].(string); ok && role != "" {
 			params["role"] = role
 		}
-		if limit, ok := args["limit"].(float64); ok && limit > 0 {
-			params["limit"] = fmt.Sprintf("%.0f", limit)
-		}
-		if offset, ok := args["offset"].(float64); ok && offset >= 0 {
-			params["offset"] = fmt.Sprintf("%.0f", offset)
-		}
+		addOffsetPaging(params, args)
 
 		path := "/users"
 		if len(params) > 0 {
@@ -215,8 +211,8 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			path += "?" + query
 		}
 
-		resp, err := client.Get(path)
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, path, &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get users: %v", err)), nil
 		}
 
@@ -229,8 +225,8 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getMyProfileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/user")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/user", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user profile: %v", err)), nil
 		}
 
@@ -250,8 +246,8 @@ func RegisterUserTools(s *server.MCPServer, client *client.ZenTaoClient) {
 		args := request.GetArguments()
 		id := int(args["id"].(float64))
 
-		resp, err := client.Get(fmt.Sprintf("/user/%d", id))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/user/%d", id), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get user: %v", err)), nil
 		}
 
@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +19,7 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterZaiTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getZaiSettingsTool := mcp.NewTool("get_zai_settings",
 		mcp.WithDescription("Get ZenTao AI (ZAI) module settings"),
 		mcp.WithString("mode",
@@ -34,8 +35,8 @@ func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams = fmt.Sprintf("&mode=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zai&f=setting&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zai&f=setting&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get ZAI settings: %v", err)), nil
 		}
 
@@ -62,7 +63,7 @@ func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"settings": args["settings"],
 		}
 
-		resp, err := client.Post("/index.php?m=zai&f=setting&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=zai&f=setting&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update ZAI settings: %v", err)), nil
 		}
@@ -75,8 +76,8 @@ func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getZaiTokenTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=zai&f=ajaxGetToken&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=zai&f=ajaxGetToken&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get ZAI token: %v", err)), nil
 		}
 
@@ -88,8 +89,8 @@ func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getVectorizationStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=zai&f=vectorized&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=zai&f=vectorized&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get vectorization status: %v", err)), nil
 		}
 
@@ -111,7 +112,7 @@ func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["config"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=zai&f=ajaxEnableVectorization&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=zai&f=ajaxEnableVectorization&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to enable vectorization: %v", err)), nil
 		}
@@ -134,7 +135,7 @@ func RegisterZaiTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["sync_options"] = v
 		}
 
-		resp, err := client.Post("/index.php?m=zai&f=ajaxSyncVectorization&t=json", body)
+		resp, err := client.PostCtx(ctx, "/index.php?m=zai&f=ajaxSyncVectorization&t=json", body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to sync vectorization: %v", err)), nil
 		}
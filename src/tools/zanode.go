@@ -11,6 +11,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,14 +19,14 @@ import (
 	"github.com/zentao/mcp-server/client"
 )
 
-func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
+func RegisterZanodeTools(s *server.MCPServer, client client.ZenTaoAPI) {
 	getInstructionsTool := mcp.NewTool("get_zanode_instructions",
 		mcp.WithDescription("Get instructions for ZenTao Node management"),
 	)
 
 	s.AddTool(getInstructionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=zanode&f=instruction&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=zanode&f=instruction&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get zanode instructions: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -76,8 +77,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=browse&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=browse&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse nodes: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -102,8 +103,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&orderBy=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=nodeList&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=nodeList&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get node list: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -128,7 +129,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"node_data": args["node_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=create&t=json&hostID=%d", int(args["hostID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=create&t=json&hostID=%d", int(args["hostID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create node: %v", err)), nil
 		}
@@ -154,7 +155,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"node_data": args["node_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=edit&t=json&id=%d", int(args["id"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=edit&t=json&id=%d", int(args["id"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit node: %v", err)), nil
 		}
@@ -172,8 +173,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(viewNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=view&t=json&id=%d", int(args["id"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=view&t=json&id=%d", int(args["id"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to view node: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -190,7 +191,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(startNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=start&t=json&nodeID=%d", int(args["nodeID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=start&t=json&nodeID=%d", int(args["nodeID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start node: %v", err)), nil
 		}
@@ -208,7 +209,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(closeNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=close&t=json&nodeID=%d", int(args["nodeID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=close&t=json&nodeID=%d", int(args["nodeID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to close node: %v", err)), nil
 		}
@@ -226,7 +227,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(suspendNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=suspend&t=json&nodeID=%d", int(args["nodeID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=suspend&t=json&nodeID=%d", int(args["nodeID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to suspend node: %v", err)), nil
 		}
@@ -244,7 +245,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(rebootNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=reboot&t=json&nodeID=%d", int(args["nodeID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=reboot&t=json&nodeID=%d", int(args["nodeID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to reboot node: %v", err)), nil
 		}
@@ -262,7 +263,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(resumeNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=resume&t=json&nodeID=%d", int(args["nodeID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=resume&t=json&nodeID=%d", int(args["nodeID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to resume node: %v", err)), nil
 		}
@@ -280,7 +281,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(destroyNodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=destroy&t=json&nodeID=%d", int(args["nodeID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=destroy&t=json&nodeID=%d", int(args["nodeID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to destroy node: %v", err)), nil
 		}
@@ -298,8 +299,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getVNCTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=getVNC&t=json&nodeID=%d", int(args["nodeID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=getVNC&t=json&nodeID=%d", int(args["nodeID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get VNC info: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -324,7 +325,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["image_data"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=createImage&t=json&nodeID=%d", int(args["nodeID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=createImage&t=json&nodeID=%d", int(args["nodeID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create image: %v", err)), nil
 		}
@@ -342,8 +343,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getImagesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImages&t=json&hostID=%d", int(args["hostID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImages&t=json&hostID=%d", int(args["hostID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get images: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -360,8 +361,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImage&t=json&imageID=%d", int(args["imageID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetImage&t=json&imageID=%d", int(args["imageID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get image: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -386,7 +387,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"image_data": args["image_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=ajaxUpdateImage&t=json&imageID=%d", int(args["imageID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxUpdateImage&t=json&imageID=%d", int(args["imageID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to update image: %v", err)), nil
 		}
@@ -412,7 +413,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["snapshot_data"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=createSnapshot&t=json&nodeID=%d", int(args["nodeID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=createSnapshot&t=json&nodeID=%d", int(args["nodeID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create snapshot: %v", err)), nil
 		}
@@ -438,7 +439,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			"snapshot_data": args["snapshot_data"],
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=editSnapshot&t=json&snapshotID=%d", int(args["snapshotID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=editSnapshot&t=json&snapshotID=%d", int(args["snapshotID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to edit snapshot: %v", err)), nil
 		}
@@ -456,7 +457,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(deleteSnapshotTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=deleteSnapshot&t=json&snapshotID=%d", int(args["snapshotID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=deleteSnapshot&t=json&snapshotID=%d", int(args["snapshotID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete snapshot: %v", err)), nil
 		}
@@ -506,8 +507,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&pageID=%d", int(v.(float64)))
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=browseSnapshot&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=browseSnapshot&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to browse snapshots: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -528,7 +529,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(restoreSnapshotTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=restoreSnapshot&t=json&nodeID=%d&snapshotID=%d", int(args["nodeID"].(float64)), int(args["snapshotID"].(float64))))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=restoreSnapshot&t=json&nodeID=%d&snapshotID=%d", int(args["nodeID"].(float64)), int(args["snapshotID"].(float64))))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to restore snapshot: %v", err)), nil
 		}
@@ -566,8 +567,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			queryParams += fmt.Sprintf("&status=%s", v)
 		}
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetTaskStatus&t=json%s", queryParams))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetTaskStatus&t=json%s", queryParams), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get task status: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -584,8 +585,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getServiceStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetServiceStatus&t=json&hostID=%d", int(args["hostID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetServiceStatus&t=json&hostID=%d", int(args["hostID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get service status: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -606,7 +607,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(installServiceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxInstallService&t=json&nodeID=%d&service=%s", int(args["nodeID"].(float64)), args["service"]))
+		resp, err := client.GetCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxInstallService&t=json&nodeID=%d&service=%s", int(args["nodeID"].(float64)), args["service"]))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to install service: %v", err)), nil
 		}
@@ -628,8 +629,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	s.AddTool(getZTFScriptTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		args := request.GetArguments()
 
-		resp, err := client.Get(fmt.Sprintf("/index.php?m=zanode&f=ajaxGetZTFScript&t=json&type=%s&objectID=%d", args["type"], int(args["objectID"].(float64))))
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxGetZTFScript&t=json&type=%s&objectID=%d", args["type"], int(args["objectID"].(float64))), &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get ZTF script: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
@@ -654,7 +655,7 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 			body["script_data"] = v
 		}
 
-		resp, err := client.Post(fmt.Sprintf("/index.php?m=zanode&f=ajaxRunZTFScript&t=json&scriptID=%d", int(args["scriptID"].(float64))), body)
+		resp, err := client.PostCtx(ctx, fmt.Sprintf("/index.php?m=zanode&f=ajaxRunZTFScript&t=json&scriptID=%d", int(args["scriptID"].(float64))), body)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to run ZTF script: %v", err)), nil
 		}
@@ -666,8 +667,8 @@ func RegisterZanodeTools(s *server.MCPServer, client *client.ZenTaoClient) {
 	)
 
 	s.AddTool(getNodesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Get("/index.php?m=zanode&f=ajaxGetNodes&t=json")
-		if err != nil {
+		var resp json.RawMessage
+		if err := client.GetJSON(ctx, "/index.php?m=zanode&f=ajaxGetNodes&t=json", &resp); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get nodes: %v", err)), nil
 		}
 		return mcp.NewToolResultText(string(resp)), nil
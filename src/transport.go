@@ -0,0 +1,353 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/logger"
+	"github.com/zentao/mcp-server/tools"
+)
+
+// serveConfig selects how MCP clients reach the server and which tools they
+// see. Flags override the ZENTAO_TRANSPORT style environment variables.
+type serveConfig struct {
+	Transport       string // "stdio" or "http"
+	Listen          string // listen address of the HTTP transport
+	BasePath        string // URL prefix of the MCP endpoints, "/" by default
+	TLSCertFile     string // serve HTTPS when both cert and key are set
+	TLSKeyFile      string
+	ShutdownTimeout time.Duration // how long in-flight requests may finish on SIGTERM
+
+	Tools     tools.ToolsetSelection // toolsets to register and tools to leave out
+	Discovery bool                   // list only meta-tools and register toolsets on demand
+}
+
+func parseServeConfig(args []string) (serveConfig, error) {
+	cfg := serveConfig{
+		Transport:       envOr("ZENTAO_TRANSPORT", "stdio"),
+		Listen:          envOr("ZENTAO_LISTEN", ":8080"),
+		BasePath:        envOr("ZENTAO_BASE_PATH", "/"),
+		TLSCertFile:     os.Getenv("ZENTAO_TLS_CERT"),
+		TLSKeyFile:      os.Getenv("ZENTAO_TLS_KEY"),
+		ShutdownTimeout: 15 * time.Second,
+	}
+	if v := os.Getenv("ZENTAO_SHUTDOWN_TIMEOUT"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid ZENTAO_SHUTDOWN_TIMEOUT: %w", err)
+		}
+		cfg.ShutdownTimeout = timeout
+	}
+
+	if v := os.Getenv("ZENTAO_TOOL_DISCOVERY"); v != "" {
+		discovery, err := strconv.ParseBool(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid ZENTAO_TOOL_DISCOVERY: %w", err)
+		}
+		cfg.Discovery = discovery
+	}
+
+	flags := flag.NewFlagSet("zentao-mcp-server", flag.ContinueOnError)
+	flags.StringVar(&cfg.Transport, "transport", cfg.Transport, "MCP transport: stdio or http (streamable HTTP and SSE)")
+	flags.StringVar(&cfg.Listen, "listen", cfg.Listen, "listen address for the http transport")
+	flags.StringVar(&cfg.BasePath, "base-path", cfg.BasePath, "URL prefix of the MCP endpoints for the http transport")
+	flags.StringVar(&cfg.TLSCertFile, "tls-cert", cfg.TLSCertFile, "PEM certificate to serve the http transport over TLS")
+	flags.StringVar(&cfg.TLSKeyFile, "tls-key", cfg.TLSKeyFile, "PEM private key to serve the http transport over TLS")
+	flags.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "time in-flight requests may take to finish on SIGTERM")
+	toolsets := flags.String("toolsets", os.Getenv("ZENTAO_TOOLSETS"), "comma-separated toolsets or presets to register, e.g. minimal or bugs,tasks,my (default all)")
+	excludeTools := flags.String("exclude-tools", os.Getenv("ZENTAO_EXCLUDE_TOOLS"), "comma-separated tool name globs to leave out, e.g. delete_*")
+	flags.BoolVar(&cfg.Discovery, "tool-discovery", cfg.Discovery, "list only search/describe/enable meta-tools and register toolsets on demand")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	selection, err := tools.ParseToolsetSelection(*toolsets, *excludeTools)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Tools = selection
+
+	cfg.BasePath = "/" + strings.Trim(cfg.BasePath, "/")
+	switch cfg.Transport {
+	case "stdio", "http":
+	default:
+		return cfg, fmt.Errorf("unknown transport %q (use stdio or http)", cfg.Transport)
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return cfg, fmt.Errorf("both --tls-cert and --tls-key must be set to serve TLS")
+	}
+	return cfg, nil
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// httpTransport serves one MCP server to many clients: streamable HTTP at
+// <base>/mcp and the older SSE transport at <base>/sse and <base>/message
+type httpTransport struct {
+	cfg        serveConfig
+	srv        *http.Server
+	streamable *server.StreamableHTTPServer
+	sse        *server.SSEServer
+	auth       *callerAuth // nil when every caller shares the server's credentials
+}
+
+func newHTTPTransport(s *server.MCPServer, cfg serveConfig, auth *callerAuth) *httpTransport {
+	srv := &http.Server{
+		Addr:              cfg.Listen,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	streamableOpts := []server.StreamableHTTPOption{server.WithStreamableHTTPServer(srv)}
+	sseOpts := []server.SSEOption{
+		server.WithStaticBasePath(cfg.BasePath),
+		server.WithKeepAlive(true),
+		server.WithHTTPServer(srv),
+	}
+	if auth != nil {
+		streamableOpts = append(streamableOpts, server.WithHTTPContextFunc(auth.contextFunc))
+		sseOpts = append(sseOpts, server.WithSSEContextFunc(auth.contextFunc))
+	}
+
+	t := &httpTransport{
+		cfg:        cfg,
+		srv:        srv,
+		streamable: server.NewStreamableHTTPServer(s, streamableOpts...),
+		sse:        server.NewSSEServer(s, sseOpts...),
+		auth:       auth,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(t.endpoint(), t.streamable)
+	mux.Handle(t.sse.CompleteSsePath(), t.sse)
+	mux.Handle(t.sse.CompleteMessagePath(), t.sse)
+	srv.Handler = mux
+	if auth != nil {
+		srv.Handler = auth.middleware(mux)
+	}
+	return t
+}
+
+// endpoint is the streamable HTTP path clients are configured with
+func (t *httpTransport) endpoint() string {
+	return path.Join(t.cfg.BasePath, "mcp")
+}
+
+// serve runs until ctx is done, then shuts down gracefully: new connections
+// are refused, SSE sessions are closed and in-flight tool calls get
+// ShutdownTimeout to finish before remaining connections are dropped
+func (t *httpTransport) serve(ctx context.Context) error {
+	scheme := "http"
+	if t.cfg.TLSCertFile != "" {
+		scheme = "https"
+	}
+	logger.Info("server", "Starting MCP server on HTTP", map[string]interface{}{
+		"listen":              t.cfg.Listen,
+		"scheme":              scheme,
+		"streamable_endpoint": t.endpoint(),
+		"sse_endpoint":        t.sse.CompleteSsePath(),
+		"message_endpoint":    t.sse.CompleteMessagePath(),
+		"caller_auth":         t.auth != nil,
+	})
+	if t.auth == nil {
+		logger.Warn("server", "Every HTTP caller acts as the configured ZenTao account; set ZENTAO_CALLER_AUTH for per-caller credentials", nil)
+	} else if t.auth.cfg.Forward && scheme != "https" {
+		logger.Warn("server", "Forwarded ZenTao passwords are accepted over plain HTTP; use TLS or a TLS-terminating proxy", nil)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		var err error
+		if t.cfg.TLSCertFile != "" {
+			err = t.srv.ListenAndServeTLS(t.cfg.TLSCertFile, t.cfg.TLSKeyFile)
+		} else {
+			err = t.srv.ListenAndServe()
+		}
+		errs <- err
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("server", "Shutting down HTTP transport", map[string]interface{}{
+		"timeout": t.cfg.ShutdownTimeout.String(),
+	})
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), t.cfg.ShutdownTimeout)
+	defer cancel()
+
+	// The SSE server closes its sessions and then shuts the shared http.Server down
+	if err := t.sse.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("server", "Graceful shutdown timed out, closing remaining connections", map[string]interface{}{
+			"error": err.Error(),
+		})
+		t.srv.Close()
+	}
+	if err := <-errs; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	logger.Info("server", "HTTP transport stopped", nil)
+	return nil
+}
+
+// serveHTTP serves s over HTTP until SIGTERM or SIGINT. With per-caller
+// credentials enabled, each MCP session gets its own clients from pool.
+func serveHTTP(s *server.MCPServer, cfg serveConfig, pool *client.Pool, callers client.CallerConfig) error {
+	var auth *callerAuth
+	if callers.Enabled() {
+		var err error
+		if auth, err = newCallerAuth(callers, pool); err != nil {
+			return err
+		}
+		pool.EnableCallers(callers)
+		defer pool.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	return newHTTPTransport(s, cfg, auth).serve(ctx)
+}
+
+// callerAuth resolves which ZenTao account each HTTP request acts as: a bearer
+// token mapped to an account, or the caller's own forwarded credentials
+type callerAuth struct {
+	cfg    client.CallerConfig
+	tokens client.CallerTokens
+	pool   *client.Pool
+}
+
+// Forwarded credentials may come as HTTP Basic auth or as these headers
+const (
+	headerZenTaoAccount  = "X-ZenTao-Account"
+	headerZenTaoPassword = "X-ZenTao-Password"
+)
+
+type callerRequestKey struct{}
+
+func newCallerAuth(cfg client.CallerConfig, pool *client.Pool) (*callerAuth, error) {
+	auth := &callerAuth{cfg: cfg, pool: pool}
+	if cfg.Bearer {
+		tokens, err := client.LoadCallerTokens(cfg.TokensFile)
+		if err != nil {
+			return nil, err
+		}
+		auth.tokens = tokens
+	}
+	return auth, nil
+}
+
+// authenticate returns the caller of r, or an error when r carries no
+// acceptable identity
+func (a *callerAuth) authenticate(r *http.Request) (client.Caller, error) {
+	header := r.Header.Get("Authorization")
+	scheme, credentials, _ := strings.Cut(header, " ")
+
+	switch {
+	case a.cfg.Bearer && strings.EqualFold(scheme, "Bearer"):
+		caller, ok := a.tokens.Lookup(strings.TrimSpace(credentials))
+		if !ok {
+			return client.Caller{}, errors.New("unknown bearer token")
+		}
+		return caller, nil
+	case a.cfg.Forward && strings.EqualFold(scheme, "Basic"):
+		account, password, ok := r.BasicAuth()
+		if !ok || account == "" || password == "" {
+			return client.Caller{}, errors.New("malformed basic credentials")
+		}
+		return client.Caller{Account: account, Password: password, Source: "forward"}, nil
+	case a.cfg.Forward && r.Header.Get(headerZenTaoAccount) != "":
+		account, password := r.Header.Get(headerZenTaoAccount), r.Header.Get(headerZenTaoPassword)
+		if password == "" {
+			return client.Caller{}, fmt.Errorf("%s is set without %s", headerZenTaoAccount, headerZenTaoPassword)
+		}
+		return client.Caller{Account: account, Password: password, Source: "forward"}, nil
+	}
+	return client.Caller{}, errors.New("missing caller credentials")
+}
+
+// middleware rejects requests without a caller identity and passes the caller
+// on to contextFunc
+func (a *callerAuth) middleware(next http.Handler) http.Handler {
+	var challenges []string
+	if a.cfg.Bearer {
+		challenges = append(challenges, `Bearer realm="zentao-mcp"`)
+	}
+	if a.cfg.Forward {
+		challenges = append(challenges, `Basic realm="zentao-mcp"`)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, err := a.authenticate(r)
+		if err != nil {
+			logger.Warn("server", "Rejected HTTP caller", map[string]interface{}{
+				"path":   r.URL.Path,
+				"remote": r.RemoteAddr,
+				"reason": err.Error(),
+			})
+			for _, challenge := range challenges {
+				w.Header().Add("WWW-Authenticate", challenge)
+			}
+			http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerRequestKey{}, caller)))
+	})
+}
+
+// contextFunc binds the caller's client for the current MCP session, so tool
+// calls and resource reads without an instance argument act as the caller.
+// When that client cannot be built, a client that fails every request is
+// bound instead of falling back to the shared account.
+func (a *callerAuth) contextFunc(ctx context.Context, r *http.Request) context.Context {
+	caller, ok := r.Context().Value(callerRequestKey{}).(client.Caller)
+	if !ok {
+		return ctx
+	}
+	sessionID := ""
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+
+	ctx = client.WithCaller(ctx, sessionID, caller)
+	target, err := a.pool.ClientFor(ctx, "")
+	if err != nil {
+		logger.Error("server", "Failed to create per-caller client", err, map[string]interface{}{
+			"account": caller.Account,
+			"session": sessionID,
+		})
+		target = client.FailedClient(err)
+	}
+	return client.WithClient(ctx, target)
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/zentao/mcp-server/client"
+	"github.com/zentao/mcp-server/tools"
+)
+
+func TestParseServeConfig(t *testing.T) {
+	t.Setenv("ZENTAO_TRANSPORT", "http")
+	t.Setenv("ZENTAO_LISTEN", ":9000")
+	t.Setenv("ZENTAO_BASE_PATH", "zentao/")
+
+	cfg, err := parseServeConfig([]string{"--listen", "127.0.0.1:9100"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Transport != "http" || cfg.Listen != "127.0.0.1:9100" || cfg.BasePath != "/zentao" {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+
+	t.Setenv("ZENTAO_TOOLSETS", "minimal")
+	cfg, err = parseServeConfig([]string{"--exclude-tools", "delete_*"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cfg.Tools.Enabled("bugs") || cfg.Tools.Enabled("kanban") || !cfg.Tools.Excluded("delete_bug") {
+		t.Errorf("Expected the minimal toolsets without delete tools")
+	}
+	if _, err := parseServeConfig([]string{"--toolsets=bugs,gantt"}); err == nil {
+		t.Error("Expected an error for an unknown toolset")
+	}
+
+	if _, err := parseServeConfig([]string{"--transport=carrier-pigeon"}); err == nil {
+		t.Error("Expected an error for an unknown transport")
+	}
+	if _, err := parseServeConfig([]string{"--tls-cert=cert.pem"}); err == nil {
+		t.Error("Expected an error for a certificate without a key")
+	}
+}
+
+func newTestMCPServer() *server.MCPServer {
+	return server.NewMCPServer("ZenTao MCP Server", "1.0.0", server.WithToolCapabilities(true))
+}
+
+func TestHTTPTransportRoutes(t *testing.T) {
+	transport := newHTTPTransport(newTestMCPServer(), serveConfig{BasePath: "/zentao"}, nil)
+	ts := httptest.NewServer(transport.srv.Handler)
+	defer ts.Close()
+
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`
+	resp, err := http.Post(ts.URL+"/zentao/mcp", "application/json", strings.NewReader(initialize))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "ZenTao MCP Server") {
+		t.Errorf("Expected an initialize result, got %d: %s", resp.StatusCode, body)
+	}
+
+	// The SSE stream announces the message endpoint under the base path
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/zentao/sse", nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err = http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	buf := make([]byte, 256)
+	n, _ := resp.Body.Read(buf)
+	if !strings.Contains(string(buf[:n]), "/zentao/message?sessionId=") {
+		t.Errorf("Expected the endpoint event, got %q", buf[:n])
+	}
+
+	resp, err = http.Get(ts.URL + "/mcp")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 outside the base path, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPTransportGracefulShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	transport := newHTTPTransport(newTestMCPServer(), serveConfig{Listen: addr, BasePath: "/", ShutdownTimeout: time.Second}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- transport.serve(ctx) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Server did not start: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected a clean shutdown, got %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Server did not shut down")
+	}
+}
+
+func TestHTTPTransportCallerAuth(t *testing.T) {
+	tokensFile := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(tokensFile, []byte(`{"alice-token": {"account": "alice", "password": "pw1"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	profiles := []client.InstanceProfile{{Name: "default", BaseURL: "http://zentao.example.com/index.php", AuthMethod: "app"}}
+	pool, err := client.NewPool(profiles, "default", client.DefaultClientOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	callers := client.CallerConfig{Bearer: true, Forward: true, TokensFile: tokensFile, IdleTimeout: time.Hour}
+	auth, err := newCallerAuth(callers, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.EnableCallers(callers)
+	defer pool.Close()
+
+	// whoami reports the account the bound client acts as
+	s := server.NewMCPServer("ZenTao MCP Server", "1.0.0",
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(tools.InstanceMiddleware(pool)),
+	)
+	s.AddTool(mcp.NewTool("whoami"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		_, caller, _ := client.CallerFromContext(ctx)
+		bound := pool.Default().ForContext(ctx)
+		return mcp.NewToolResultText(fmt.Sprintf("%s shared=%v", caller.Account, bound == pool.Default())), nil
+	})
+
+	ts := httptest.NewServer(newHTTPTransport(s, serveConfig{BasePath: "/"}, auth).srv.Handler)
+	defer ts.Close()
+
+	post := func(header http.Header, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(body))
+		req.Header = header
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return resp
+	}
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`
+	whoami := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"whoami","arguments":{}}}`
+
+	resp := post(http.Header{}, initialize)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized || len(resp.Header.Values("WWW-Authenticate")) != 2 {
+		t.Errorf("Expected 401 with challenges, got %d %v", resp.StatusCode, resp.Header.Values("WWW-Authenticate"))
+	}
+	resp = post(http.Header{"Authorization": {"Bearer wrong"}}, initialize)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unknown token, got %d", resp.StatusCode)
+	}
+
+	for _, test := range []struct {
+		header  http.Header
+		account string
+	}{
+		{http.Header{"Authorization": {"Bearer alice-token"}}, "alice"},
+		{http.Header{"X-Zentao-Account": {"bob"}, "X-Zentao-Password": {"pw2"}}, "bob"},
+	} {
+		resp := post(test.header.Clone(), initialize)
+		resp.Body.Close()
+		sessionID := resp.Header.Get(server.HeaderKeySessionID)
+		if resp.StatusCode != http.StatusOK || sessionID == "" {
+			t.Fatalf("Expected a session, got %d", resp.StatusCode)
+		}
+
+		header := test.header.Clone()
+		header.Set(server.HeaderKeySessionID, sessionID)
+		resp = post(header, whoami)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), test.account+" shared=false") {
+			t.Errorf("Expected the call to act as %s, got %s", test.account, body)
+		}
+	}
+
+	if pool.CallerSessions() != 2 {
+		t.Errorf("Expected one client per session, got %d", pool.CallerSessions())
+	}
+}
+
+func TestHTTPTransportCallerClientFailure(t *testing.T) {
+	var upstream atomic.Int32
+	zentao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream.Add(1)
+		w.Write([]byte(`{"status":"success","data":"{}"}`))
+	}))
+	defer zentao.Close()
+
+	profiles := []client.InstanceProfile{{Name: "default", BaseURL: zentao.URL + "/index.php", AuthMethod: "app"}}
+	pool, err := client.NewPool(profiles, "default", client.DefaultClientOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Per-caller clients are never enabled on the pool, so ClientFor fails
+	auth, err := newCallerAuth(client.CallerConfig{Forward: true}, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := server.NewMCPServer("ZenTao MCP Server", "1.0.0", server.WithResourceCapabilities(true, true))
+	s.AddResource(mcp.NewResource("zentao://products", "Products"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if _, err := pool.Default().GetCtx(ctx, "/index.php?m=product&f=browse&t=json"); err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: "zentao://products", Text: "shared"}}, nil
+	})
+
+	ts := httptest.NewServer(newHTTPTransport(s, serveConfig{BasePath: "/"}, auth).srv.Handler)
+	defer ts.Close()
+
+	post := func(sessionID, body string) string {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Zentao-Account", "alice")
+		req.Header.Set("X-Zentao-Password", "pw1")
+		if sessionID != "" {
+			req.Header.Set(server.HeaderKeySessionID, sessionID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		if sessionID == "" {
+			return resp.Header.Get(server.HeaderKeySessionID)
+		}
+		return string(data)
+	}
+
+	sessionID := post("", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	if sessionID == "" {
+		t.Fatal("Expected a session")
+	}
+	body := post(sessionID, `{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"zentao://products"}}`)
+	if !strings.Contains(body, `"error"`) || strings.Contains(body, "shared") {
+		t.Errorf("Expected the read to be rejected, got %s", body)
+
+	}
+	if upstream.Load() != 0 {
+		t.Errorf("Expected no request with the shared account, got %d", upstream.Load())
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package ztfake
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Fault scripts a failure. Empty Module, Function and Method match any
+// request. Faults are checked before authentication, in the order they were
+// injected.
+type Fault struct {
+	Module   string
+	Function string
+	Method   string
+
+	Status      int           // HTTP status to answer with (default 200)
+	Body        interface{}   // response body; a string or []byte is sent raw, anything else as JSON
+	ContentType string        // default application/json
+	Header      http.Header   // extra response headers, e.g. Retry-After
+	Delay       time.Duration // wait before answering, aborted when the client gives up
+	Drop        bool          // close the connection without a response
+	Times       int           // number of requests to fail; 0 means until cleared
+
+	hits int
+}
+
+// Inject adds a fault
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fault := f
+	s.faults = append(s.faults, &fault)
+}
+
+// FailNext makes the next n requests to module/function answer with an
+// HTTP status and ZenTao's failure envelope
+func (s *Server) FailNext(moduleName, function string, n, status int) {
+	s.Inject(Fault{
+		Module:   moduleName,
+		Function: function,
+		Status:   status,
+		Body:     map[string]interface{}{"status": "fail", "message": http.StatusText(status)},
+		Times:    n,
+	})
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the first active fault for req and uses up one of its
+// hits; callers hold s.mu
+func (s *Server) matchFault(req Request) *Fault {
+	for i, f := range s.faults {
+		if (f.Module != "" && f.Module != req.Module) ||
+			(f.Function != "" && f.Function != req.Function) ||
+			(f.Method != "" && f.Method != req.Method) {
+			continue
+		}
+
+		f.hits++
+		if f.Times > 0 && f.hits >= f.Times {
+			s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) {
+	if f.Delay > 0 {
+		timer := time.NewTimer(f.Delay)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if f.Drop {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	for key, values := range f.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	switch body := f.Body.(type) {
+	case nil:
+		writeJSON(w, status, map[string]interface{}{"status": "fail", "message": "injected fault"})
+	case string:
+		f.writeRaw(w, status, []byte(body))
+	case []byte:
+		f.writeRaw(w, status, body)
+	default:
+		w.Header().Set("Content-Type", f.contentType())
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func (f *Fault) writeRaw(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", f.contentType())
+	w.WriteHeader(status)
+	io.WriteString(w, string(body))
+}
+
+func (f *Fault) contentType() string {
+	if f.ContentType != "" {
+		return f.ContentType
+	}
+	return "application/json"
+}
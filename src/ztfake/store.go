@@ -0,0 +1,362 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package ztfake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// module describes one of the emulated ZenTao modules
+type module struct {
+	name        string            // m= value, e.g. "bug"
+	plural      string            // key of the list in browse responses
+	titleField  string            // field create requires
+	status      string            // status of new records
+	filters     []string          // query parameters browse filters on
+	transitions map[string]string // f= value -> status it sets
+}
+
+var modules = []module{
+	{
+		name: "product", plural: "products", titleField: "name", status: "normal",
+		filters:     []string{"program", "status"},
+		transitions: map[string]string{"close": "closed", "activate": "normal"},
+	},
+	{
+		name: "story", plural: "stories", titleField: "title", status: "active",
+		filters:     []string{"product", "execution", "project", "status", "stage", "pri"},
+		transitions: map[string]string{"close": "closed", "activate": "active", "review": "active"},
+	},
+	{
+		name: "task", plural: "tasks", titleField: "name", status: "wait",
+		filters:     []string{"execution", "project", "story", "status", "assignedTo"},
+		transitions: map[string]string{"start": "doing", "finish": "done", "pause": "pause", "close": "closed", "cancel": "cancel", "activate": "doing"},
+	},
+	{
+		name: "bug", plural: "bugs", titleField: "title", status: "active",
+		filters:     []string{"product", "project", "execution", "status", "severity", "pri", "assignedTo"},
+		transitions: map[string]string{"resolve": "resolved", "close": "closed", "activate": "active"},
+	},
+}
+
+type table struct {
+	module module
+	nextID int
+	rows   map[int]map[string]interface{}
+}
+
+// Seed stores a record directly and returns its ID. fields may carry an "id"
+// to pin the ID; otherwise the next free one is used.
+func (s *Server) Seed(moduleName string, fields map[string]interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tables[moduleName]
+	if !ok {
+		panic(fmt.Sprintf("ztfake: unknown module %q", moduleName))
+	}
+	return t.insert(fields, s.opts.Now())
+}
+
+// Record returns a copy of a stored record, including deleted ones
+func (s *Server) Record(moduleName string, id int) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tables[moduleName]
+	if !ok {
+		return nil, false
+	}
+	row, ok := t.rows[id]
+	if !ok {
+		return nil, false
+	}
+	return copyRow(row), true
+}
+
+// Count returns how many records of a module are not deleted
+func (s *Server) Count(moduleName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tables[moduleName]
+	if !ok {
+		return 0
+	}
+	return len(t.list(nil))
+}
+
+func (t *table) insert(fields map[string]interface{}, now time.Time) int {
+	id := t.nextID + 1
+	if pinned, ok := toInt(fields["id"]); ok && pinned > 0 {
+		id = pinned
+	}
+	if id > t.nextID {
+		t.nextID = id
+	}
+
+	row := map[string]interface{}{
+		"status":     t.module.status,
+		"deleted":    "0",
+		"openedBy":   "admin",
+		"openedDate": now.Format("2006-01-02 15:04:05"),
+	}
+	for key, value := range fields {
+		row[key] = value
+	}
+	row["id"] = id
+	t.rows[id] = row
+	return id
+}
+
+// list returns live records ordered by ID, filtered on the module's filter
+// parameters that are present in query
+func (t *table) list(query map[string][]string) []map[string]interface{} {
+	ids := make([]int, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	rows := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		row := t.rows[id]
+		if row["deleted"] == "1" || !t.matches(row, query) {
+			continue
+		}
+		rows = append(rows, copyRow(row))
+	}
+	return rows
+}
+
+func (t *table) matches(row map[string]interface{}, query map[string][]string) bool {
+	for _, field := range t.module.filters {
+		// ZenTao's own pages name parents productID, executionID and so on
+		values, ok := query[field]
+		if !ok {
+			values, ok = query[field+"ID"]
+		}
+		if !ok || len(values) == 0 || values[0] == "" || values[0] == "0" {
+			continue
+		}
+		if fmt.Sprint(row[field]) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// route dispatches an authenticated request; callers hold s.mu
+func (s *Server) route(req Request) (int, interface{}) {
+	if req.Module == "misc" && req.Function == "ping" {
+		return http.StatusOK, map[string]interface{}{"status": "success"}
+	}
+
+	t, ok := s.tables[req.Module]
+	if !ok {
+		return notFound(fmt.Sprintf("ztfake: unknown route m=%s&f=%s", req.Module, req.Function))
+	}
+
+	switch req.Function {
+	case "browse", "all":
+		return s.browse(t, req)
+	case "view":
+		row, status, payload := t.lookup(req)
+		if row == nil {
+			return status, payload
+		}
+		return http.StatusOK, envelope(map[string]interface{}{
+			"title":       fmt.Sprintf("%s #%v", t.module.name, row["id"]),
+			t.module.name: copyRow(row),
+		})
+	case "create", "batchCreate":
+		return s.create(t, req)
+	case "edit":
+		row, status, payload := t.lookup(req)
+		if row == nil {
+			return status, payload
+		}
+		for key, value := range req.Body {
+			if key != "id" {
+				row[key] = value
+			}
+		}
+		row["lastEditedDate"] = s.opts.Now().Format("2006-01-02 15:04:05")
+		return saved(row["id"])
+	case "delete":
+		row, status, payload := t.lookup(req)
+		if row == nil {
+			return status, payload
+		}
+		row["deleted"] = "1"
+		return saved(row["id"])
+	case "assignTo":
+		row, status, payload := t.lookup(req)
+		if row == nil {
+			return status, payload
+		}
+		row["assignedTo"] = req.Body["assignedTo"]
+		return saved(row["id"])
+	case "confirm", "confirmBug":
+		row, status, payload := t.lookup(req)
+		if row == nil {
+			return status, payload
+		}
+		row["confirmed"] = "1"
+		return saved(row["id"])
+	}
+
+	if next, ok := t.module.transitions[req.Function]; ok {
+		row, status, payload := t.lookup(req)
+		if row == nil {
+			return status, payload
+		}
+		for key, value := range req.Body {
+			if key != "id" {
+				row[key] = value
+			}
+		}
+		row["status"] = next
+		return saved(row["id"])
+	}
+
+	return notFound(fmt.Sprintf("ztfake: unknown route m=%s&f=%s", req.Module, req.Function))
+}
+
+func (s *Server) browse(t *table, req Request) (int, interface{}) {
+	rows := t.list(req.Query)
+
+	perPage := s.opts.PageSize
+	if n, err := strconv.Atoi(req.Query.Get("recPerPage")); err == nil && n > 0 {
+		perPage = n
+	}
+	pageID := 1
+	if n, err := strconv.Atoi(req.Query.Get("pageID")); err == nil && n > 0 {
+		pageID = n
+	}
+
+	start := (pageID - 1) * perPage
+	if start > len(rows) {
+		start = len(rows)
+	}
+	end := start + perPage
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	return http.StatusOK, envelope(map[string]interface{}{
+		"title":         fmt.Sprintf("%s list", t.module.name),
+		t.module.plural: rows[start:end],
+		"pager": map[string]interface{}{
+			"recTotal":   len(rows),
+			"recPerPage": perPage,
+			"pageID":     pageID,
+			"pageTotal":  (len(rows) + perPage - 1) / perPage,
+		},
+	})
+}
+
+func (s *Server) create(t *table, req Request) (int, interface{}) {
+	title, _ := req.Body[t.module.titleField].(string)
+	if title == "" {
+		return http.StatusOK, map[string]interface{}{
+			"result": "fail",
+			"message": map[string]interface{}{
+				t.module.titleField: []string{fmt.Sprintf("『%s』 is required.", t.module.titleField)},
+			},
+		}
+	}
+
+	fields := make(map[string]interface{}, len(req.Body)+2)
+	for key, value := range req.Body {
+		if key != "id" {
+			fields[key] = value
+		}
+	}
+	// Parents named in the URL, e.g. m=task&f=create&execution=3
+	for _, field := range t.module.filters {
+		if value := req.Query.Get(field); value != "" {
+			if _, set := fields[field]; !set {
+				fields[field] = value
+			}
+		}
+	}
+
+	id := t.insert(fields, s.opts.Now())
+	return http.StatusOK, map[string]interface{}{
+		"result":  "success",
+		"message": "Saved",
+		"id":      id,
+	}
+}
+
+// lookup finds the record named by id or <module>ID; on failure it returns
+// the response to send instead
+func (t *table) lookup(req Request) (map[string]interface{}, int, interface{}) {
+	raw := req.Query.Get("id")
+	if raw == "" {
+		raw = req.Query.Get(t.module.name + "ID")
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		status, payload := notFound(fmt.Sprintf("%s ID %q is not valid, record not found", t.module.name, raw))
+		return nil, status, payload
+	}
+
+	row, ok := t.rows[id]
+	if !ok || row["deleted"] == "1" {
+		status, payload := notFound(fmt.Sprintf("%s #%d not found", t.module.name, id))
+		return nil, status, payload
+	}
+	return row, 0, nil
+}
+
+func saved(id interface{}) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"result":  "success",
+		"message": "Saved",
+		"id":      id,
+	}
+}
+
+func notFound(message string) (int, interface{}) {
+	return http.StatusNotFound, map[string]interface{}{
+		"status":  "fail",
+		"message": message,
+	}
+}
+
+func copyRow(row map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(row))
+	for key, value := range row {
+		out[key] = value
+	}
+	return out
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
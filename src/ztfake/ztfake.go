@@ -0,0 +1,402 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+// Package ztfake is an in-memory ZenTao emulator for end-to-end tests. It
+// speaks the legacy index.php?m=<module>&f=<function> protocol, validates app
+// tokens and sessions the way ZenTao does, keeps products, stories, tasks and
+// bugs in memory and can be scripted to fail.
+package ztfake
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionName is the name of the session parameter issued by getSessionID
+const SessionName = "zentaosid"
+
+// Options configures the emulator
+type Options struct {
+	AppCode  string        // app code accepted for token authentication
+	AppKey   string        // app key used to verify tokens
+	Account  string        // account accepted by user-login
+	Password string        // password accepted by user-login
+	TokenTTL time.Duration // how far a token timestamp may drift from Now (default 30s)
+	PageSize int           // default recPerPage for browse (default 20)
+
+	// Now returns the emulator's clock; nil means time.Now
+	Now func() time.Time
+}
+
+// DefaultOptions returns the credentials most tests use
+func DefaultOptions() Options {
+	return Options{
+		AppCode:  "ztfake",
+		AppKey:   "ztfake-key",
+		Account:  "admin",
+		Password: "123456",
+		TokenTTL: 30 * time.Second,
+		PageSize: 20,
+	}
+}
+
+// Request is a request the emulator received, kept for assertions
+type Request struct {
+	Method   string
+	Path     string // URL path, e.g. /index.php or /api.php/v1/tokens
+	Module   string
+	Function string
+	Query    url.Values
+	Token    string // Token header sent by token-authenticated clients
+	Body     map[string]interface{}
+}
+
+// Server is a running emulator. It is also an http.Handler, so it can be
+// mounted elsewhere instead of using the built-in test server.
+type Server struct {
+	opts Options
+	http *httptest.Server
+
+	mu          sync.Mutex
+	tables      map[string]*table
+	sessions    map[string]bool // session ID -> logged in
+	nextSession int
+	faults      []*Fault
+	requests    []Request
+}
+
+// New starts an emulator on a local port. Zero-valued options fall back to
+// DefaultOptions.
+func New(opts Options) *Server {
+	s := NewHandler(opts)
+	s.http = httptest.NewServer(s)
+	return s
+}
+
+// NewHandler returns an emulator without starting a listener
+func NewHandler(opts Options) *Server {
+	defaults := DefaultOptions()
+	if opts.AppCode == "" && opts.AppKey == "" {
+		opts.AppCode, opts.AppKey = defaults.AppCode, defaults.AppKey
+	}
+	if opts.Account == "" && opts.Password == "" {
+		opts.Account, opts.Password = defaults.Account, defaults.Password
+	}
+	if opts.TokenTTL <= 0 {
+		opts.TokenTTL = defaults.TokenTTL
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaults.PageSize
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	s := &Server{
+		opts:     opts,
+		tables:   map[string]*table{},
+		sessions: map[string]bool{},
+	}
+	for _, module := range modules {
+		s.tables[module.name] = &table{module: module, rows: map[int]map[string]interface{}{}}
+	}
+	return s
+}
+
+// URL returns the root URL of the running emulator
+func (s *Server) URL() string {
+	if s.http == nil {
+		return ""
+	}
+	return s.http.URL
+}
+
+// BaseURL returns the index.php URL clients should be configured with
+func (s *Server) BaseURL() string {
+	return s.URL() + "/index.php"
+}
+
+// Options returns the effective options, including defaults
+func (s *Server) Options() Options {
+	return s.opts
+}
+
+// Close shuts the built-in test server down
+func (s *Server) Close() {
+	if s.http != nil {
+		s.http.Close()
+	}
+}
+
+// Token returns the token ZenTao expects for the given timestamp
+func (s *Server) Token(timestamp int64) string {
+	sum := md5.Sum([]byte(s.opts.AppCode + s.opts.AppKey + strconv.FormatInt(timestamp, 10)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExpireSessions logs every session out, like a PHP session timeout
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = map[string]bool{}
+}
+
+// Requests returns the requests received so far, oldest first
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ResetRequests forgets the recorded requests
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := Request{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Module:   query.Get("m"),
+		Function: query.Get("f"),
+		Query:    query,
+		Token:    r.Header.Get("Token"),
+		Body:     readBody(r),
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	fault := s.matchFault(req)
+	s.mu.Unlock()
+
+	// Stamp responses with the emulator's clock, like a server with its own time
+	w.Header().Set("Date", s.opts.Now().UTC().Format(http.TimeFormat))
+
+	if fault != nil {
+		fault.apply(w, r)
+		return
+	}
+
+	switch {
+	case req.Method == http.MethodPost && strings.HasSuffix(req.Path, "/api.php/v1/tokens"):
+		s.issueToken(w, req)
+		return
+	case req.Module == "api" && req.Function == "getSessionID":
+		s.issueSession(w)
+		return
+	case req.Module == "user" && req.Function == "login":
+		s.login(w, req)
+		return
+	}
+
+	if !s.authenticate(w, req) {
+		return
+	}
+
+	s.mu.Lock()
+	status, payload := s.route(req)
+	s.mu.Unlock()
+	writeJSON(w, status, payload)
+}
+
+// authenticate checks the Token header, app token parameters or the session
+// parameter and writes ZenTao's rejection when none is valid
+func (s *Server) authenticate(w http.ResponseWriter, req Request) bool {
+	if req.Token != "" {
+		// Tokens from POST /tokens are session IDs, so they expire with the sessions
+		s.mu.Lock()
+		loggedIn := s.sessions[req.Token]
+		s.mu.Unlock()
+		if !loggedIn {
+			writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "Unauthorized"})
+			return false
+		}
+		return true
+	}
+
+	if req.Query.Has("token") || req.Query.Has("code") {
+		if errcode, errmsg := s.checkToken(req.Query); errcode != 0 {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"errcode": errcode, "errmsg": errmsg})
+			return false
+		}
+		return true
+	}
+
+	if sid := req.Query.Get(SessionName); sid != "" {
+		s.mu.Lock()
+		loggedIn := s.sessions[sid]
+		s.mu.Unlock()
+		if loggedIn {
+			return true
+		}
+	}
+
+	// ZenTao redirects anonymous visitors to its login page
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, `<html><body><div id="loginPanel"></div><script>self.location="index.php?m=user&f=login"</script></body></html>`)
+	return false
+}
+
+func (s *Server) checkToken(query url.Values) (int, string) {
+	if query.Get("code") != s.opts.AppCode {
+		return 401, "Invalid app code"
+	}
+
+	timestamp, err := strconv.ParseInt(query.Get("time"), 10, 64)
+	if err != nil {
+		return 401, "Invalid time parameter"
+	}
+
+	drift := s.opts.Now().Sub(time.Unix(timestamp, 0))
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > s.opts.TokenTTL {
+		return 405, "Token expired"
+	}
+
+	if query.Get("token") != s.Token(timestamp) {
+		return 401, "Invalid token"
+	}
+	return 0, ""
+}
+
+func (s *Server) issueSession(w http.ResponseWriter) {
+	s.mu.Lock()
+	s.nextSession++
+	n := s.nextSession
+	sid := fmt.Sprintf("ztfake%06d", n)
+	s.sessions[sid] = false
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"title":       "",
+			"sessionName": SessionName,
+			"sessionID":   sid,
+			"rand":        n,
+		},
+	})
+}
+
+// issueToken implements POST /api.php/v1/tokens {"account","password"}
+func (s *Server) issueToken(w http.ResponseWriter, req Request) {
+	account, _ := req.Body["account"].(string)
+	password, _ := req.Body["password"].(string)
+	if account != s.opts.Account || password != s.opts.Password {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "Login failed"})
+		return
+	}
+
+	s.mu.Lock()
+	s.nextSession++
+	token := fmt.Sprintf("ztfake%06d", s.nextSession)
+	s.sessions[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"token": token})
+}
+
+func (s *Server) login(w http.ResponseWriter, req Request) {
+	sid := req.Query.Get(SessionName)
+
+	s.mu.Lock()
+	_, issued := s.sessions[sid]
+	s.mu.Unlock()
+	if !issued {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":  "failed",
+			"message": "Session not initialized, request getSessionID first",
+		})
+		return
+	}
+
+	account, _ := req.Body["account"].(string)
+	password, _ := req.Body["password"].(string)
+	if account != s.opts.Account || password != s.opts.Password {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":  "failed",
+			"message": "Invalid account or password",
+		})
+		return
+	}
+
+	s.mu.Lock()
+	s.sessions[sid] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"user":   map[string]interface{}{"account": account, "realname": account},
+	})
+}
+
+// readBody accepts the JSON bodies this server sends and the form posts
+// ZenTao's own pages use
+func readBody(r *http.Request) map[string]interface{} {
+	if r.Body == nil {
+		return nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+
+	var body map[string]interface{}
+	if json.Unmarshal(data, &body) == nil {
+		return body
+	}
+
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil
+	}
+	body = make(map[string]interface{}, len(form))
+	for key, values := range form {
+		body[strings.TrimSuffix(key, "[]")] = values[len(values)-1]
+	}
+	return body
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+// envelope wraps data the way ZenTao's JSON view does: data is itself a JSON
+// string and md5 is its checksum
+func envelope(data interface{}) map[string]interface{} {
+	encoded, _ := json.Marshal(data)
+	sum := md5.Sum(encoded)
+	return map[string]interface{}{
+		"status": "success",
+		"data":   string(encoded),
+		"md5":    hex.EncodeToString(sum[:]),
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Bivex
+//
+// Author: Bivex
+// Contact: support@b-b.top
+//
+// For up-to-date contact information:
+// https://github.com/bivex
+//
+//
+// Licensed under the MIT License.
+// Commercial licensing available upon request.
+
+package ztfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func appQuery(s *Server, timestamp int64) string {
+	return fmt.Sprintf("code=%s&time=%d&token=%s", s.opts.AppCode, timestamp, s.Token(timestamp))
+}
+
+func doJSON(t *testing.T, method, url string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(method, url, reader)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(resp.Body)
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return resp.StatusCode, map[string]interface{}{"raw": string(raw)}
+	}
+	return resp.StatusCode, decoded
+}
+
+func decodeData(t *testing.T, payload map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	data, ok := payload["data"].(string)
+	if !ok {
+		t.Fatalf("Expected double-encoded data, got %v", payload)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+		t.Fatalf("Failed to decode data: %v", err)
+	}
+	return decoded
+}
+
+func TestTokenValidation(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := New(Options{Now: func() time.Time { return now }})
+	defer s.Close()
+
+	tests := []struct {
+		name    string
+		query   string
+		errcode float64
+	}{
+		{"valid", appQuery(s, now.Unix()), 0},
+		{"within ttl", appQuery(s, now.Unix()-20), 0},
+		{"expired", appQuery(s, now.Unix()-60), 405},
+		{"wrong code", "code=other&time=1700000000&token=" + s.Token(now.Unix()), 401},
+		{"wrong token", "code=ztfake&time=1700000000&token=deadbeef", 401},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, payload := doJSON(t, "GET", s.BaseURL()+"?m=misc&f=ping&t=json&"+test.query, nil)
+			errcode, _ := payload["errcode"].(float64)
+			if errcode != test.errcode {
+				t.Errorf("Expected errcode %v, got %v (%v)", test.errcode, errcode, payload)
+			}
+		})
+	}
+
+	_, payload := doJSON(t, "GET", s.BaseURL()+"?m=misc&f=ping&t=json", nil)
+	if raw, _ := payload["raw"].(string); !strings.Contains(raw, "loginPanel") {
+		t.Errorf("Expected login page without credentials, got %v", payload)
+	}
+}
+
+func TestSessionFlow(t *testing.T) {
+	s := New(Options{})
+	defer s.Close()
+
+	_, payload := doJSON(t, "GET", s.BaseURL()+"?m=api&f=getSessionID&t=json", nil)
+	sid := payload["data"].(map[string]interface{})["sessionID"].(string)
+	param := SessionName + "=" + sid
+
+	_, payload = doJSON(t, "POST", s.BaseURL()+"?m=user&f=login&t=json&"+param, map[string]string{"account": "admin", "password": "wrong"})
+	if payload["status"] != "failed" {
+		t.Errorf("Expected wrong password to fail, got %v", payload)
+	}
+
+	_, payload = doJSON(t, "POST", s.BaseURL()+"?m=user&f=login&t=json&"+param, map[string]string{"account": "admin", "password": "123456"})
+	if payload["status"] != "success" {
+		t.Fatalf("Expected login to succeed, got %v", payload)
+	}
+
+	if _, payload = doJSON(t, "GET", s.BaseURL()+"?m=misc&f=ping&t=json&"+param, nil); payload["status"] != "success" {
+		t.Errorf("Expected logged-in session to be accepted, got %v", payload)
+	}
+
+	s.ExpireSessions()
+	if _, payload = doJSON(t, "GET", s.BaseURL()+"?m=misc&f=ping&t=json&"+param, nil); payload["raw"] == nil {
+		t.Errorf("Expected expired session to get the login page, got %v", payload)
+	}
+}
+
+func TestCRUDAndBrowse(t *testing.T) {
+	s := New(Options{PageSize: 2})
+	defer s.Close()
+	auth := "&" + appQuery(s, time.Now().Unix())
+
+	_, payload := doJSON(t, "POST", s.BaseURL()+"?m=bug&f=create&product=1"+auth, map[string]interface{}{})
+	if payload["result"] != "fail" {
+		t.Errorf("Expected validation failure without title, got %v", payload)
+	}
+
+	for i := 1; i <= 3; i++ {
+		_, payload = doJSON(t, "POST", s.BaseURL()+"?m=bug&f=create&product=1"+auth, map[string]interface{}{"title": "Bug " + strconv.Itoa(i)})
+		if payload["id"] != float64(i) {
+			t.Fatalf("Expected bug ID %d, got %v", i, payload)
+		}
+	}
+	s.Seed("bug", map[string]interface{}{"title": "Other product", "product": 2})
+
+	_, payload = doJSON(t, "GET", s.BaseURL()+"?m=bug&f=browse&product=1&pageID=2"+auth, nil)
+	data := decodeData(t, payload)
+	if bugs := data["bugs"].([]interface{}); len(bugs) != 1 {
+		t.Errorf("Expected 1 bug on page 2, got %d", len(bugs))
+	}
+	if pager := data["pager"].(map[string]interface{}); pager["recTotal"] != float64(3) {
+		t.Errorf("Expected recTotal 3, got %v", pager["recTotal"])
+	}
+
+	doJSON(t, "POST", s.BaseURL()+"?m=bug&f=resolve&bugID=2"+auth, map[string]interface{}{"resolution": "fixed"})
+	if bug, _ := s.Record("bug", 2); bug["status"] != "resolved" || bug["resolution"] != "fixed" {
+		t.Errorf("Expected bug 2 resolved as fixed, got %v", bug)
+	}
+
+	doJSON(t, "DELETE", s.BaseURL()+"?m=bug&f=delete&id=1"+auth, nil)
+	status, _ := doJSON(t, "GET", s.BaseURL()+"?m=bug&f=view&bugID=1"+auth, nil)
+	if status != http.StatusNotFound {
+		t.Errorf("Expected deleted bug to be gone, got %d", status)
+	}
+	if s.Count("bug") != 3 {
+		t.Errorf("Expected 3 live bugs, got %d", s.Count("bug"))
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	s := New(Options{})
+	defer s.Close()
+	auth := "&" + appQuery(s, time.Now().Unix())
+
+	s.FailNext("product", "browse", 2, http.StatusServiceUnavailable)
+	s.Inject(Fault{Module: "bug", Body: `<html>Fatal error</html>`, ContentType: "text/html"})
+
+	for i := 0; i < 2; i++ {
+		if status, _ := doJSON(t, "GET", s.BaseURL()+"?m=product&f=browse"+auth, nil); status != http.StatusServiceUnavailable {
+			t.Errorf("Request %d: expected injected 503, got %d", i, status)
+		}
+	}
+	if status, _ := doJSON(t, "GET", s.BaseURL()+"?m=product&f=browse"+auth, nil); status != http.StatusOK {
+		t.Errorf("Expected fault to be used up, got %d", status)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, payload := doJSON(t, "GET", s.BaseURL()+"?m=bug&f=browse"+auth, nil); payload["raw"] != "<html>Fatal error</html>" {
+			t.Errorf("Expected persistent fault, got %v", payload)
+		}
+	}
+
+	s.ClearFaults()
+	if _, payload := doJSON(t, "GET", s.BaseURL()+"?m=bug&f=browse"+auth, nil); payload["status"] != "success" {
+		t.Errorf("Expected cleared faults, got %v", payload)
+	}
+
+	if len(s.Requests()) != 7 {
+		t.Errorf("Expected 7 recorded requests, got %d", len(s.Requests()))
+	}
+}